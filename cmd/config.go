@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configShowEffective bool
+	configProject       bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and edit Ward configuration",
+	Long: `Inspect and edit Ward configuration.
+
+Keys are dotted YAML paths, e.g. severity, output.formats or
+rules.override.AUTH-001.severity. Lists are written comma-separated.`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show [path]",
+	Short: "Print the configuration Ward would use",
+	Long: `Print the configuration Ward would use.
+
+Without flags this is the defaults merged with ~/.ward/config.yaml.
+With --effective, the project config (.ward.yaml or ward.yaml) in path
+(default: current directory) and CLI flags are merged on top, and each
+value is annotated with the layer it came from.
+
+Merge order: defaults, user config, project config, CLI flags.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var (
+			cfg     *config.WardConfig
+			origins config.Origins
+			err     error
+		)
+
+		if configShowEffective {
+			root := "."
+			if len(args) == 1 {
+				root = args[0]
+			}
+			cfg, origins, err = loadConfig(root)
+		} else {
+			cfg, origins, err = config.LoadProject("")
+		}
+		if err != nil {
+			return err
+		}
+
+		if cfg.AI.APIKey != "" {
+			cfg.AI.APIKey = "****"
+		}
+
+		out, err := config.Annotate(cfg, origins)
+		if err != nil {
+			return err
+		}
+
+		if configShowEffective {
+			dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
+			fmt.Println(dim.Render("# Effective Ward configuration (defaults < user < project < flags)"))
+		}
+		fmt.Print(out)
+		return nil
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a value from the effective configuration",
+	Long: `Print a value from the effective configuration for the current
+directory (defaults, user config, .ward.yaml). Lists print comma-separated
+and sections as YAML.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, _, err := loadConfig(".")
+		if err != nil {
+			return err
+		}
+		value, err := config.Get(cfg, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(value)
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a value in the user or project config file",
+	Long: `Set a value in ~/.ward/config.yaml, or in the current project's
+.ward.yaml with --project. Comments and other keys in the file are kept.
+The file is validated before it is written.`,
+	Example: `  ward config set severity medium
+  ward config set output.formats json,sarif
+  ward config set --project rules.override.DEBUG-001.enabled false`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configFilePath()
+		if err != nil {
+			return err
+		}
+		if err := config.SetInFile(path, args[0], args[1]); err != nil {
+			return err
+		}
+
+		dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
+		fmt.Fprintln(os.Stderr, dim.Render(fmt.Sprintf("Set %s in %s", args[0], path)))
+		return nil
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file...]",
+	Short: "Check config files for unknown keys and invalid values",
+	Long: `Check config files for unknown keys, wrong types and invalid values.
+Without arguments, ~/.ward/config.yaml and the current project's
+.ward.yaml are checked if they exist. Exits 1 if any file is invalid.`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files := args
+		if len(files) == 0 {
+			if path, err := config.FilePath("config.yaml"); err == nil {
+				if _, err := os.Stat(path); err == nil {
+					files = append(files, path)
+				}
+			}
+			if path := config.ProjectFile("."); path != "" {
+				files = append(files, path)
+			}
+		}
+		if len(files) == 0 {
+			fmt.Println("No config files found.")
+			return nil
+		}
+
+		success := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#2E7D32", Dark: "#69F0AE"})
+		failure := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#C62828", Dark: "#FF5252"})
+
+		var invalid int
+		for _, path := range files {
+			data, err := os.ReadFile(path)
+			if err == nil {
+				err = config.ValidateFile(path, data)
+			}
+			if err == nil {
+				fmt.Println(success.Render("✓ ") + path)
+				continue
+			}
+
+			invalid++
+			fmt.Println(failure.Render("✗ ") + path)
+			if verr, ok := err.(*config.ValidationError); ok {
+				for _, p := range verr.Problems {
+					fmt.Println("    " + p.String())
+				}
+			} else {
+				fmt.Println("    " + err.Error())
+			}
+		}
+
+		if invalid > 0 {
+			return fmt.Errorf("%d invalid config file(s)", invalid)
+		}
+		return nil
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the path of the user (or --project) config file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configFilePath()
+		if err != nil {
+			return err
+		}
+		fmt.Println(path)
+		return nil
+	},
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema for config files",
+	Long: `Print the JSON Schema for config.yaml and .ward.yaml. ward init also
+writes it to ~/.ward/config.schema.json for editors that support
+yaml-language-server.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Stdout.Write(config.Schema)
+	},
+}
+
+// configFilePath returns the file set and path operate on: the user
+// config, or with --project the current project's config (which need
+// not exist yet).
+func configFilePath() (string, error) {
+	if !configProject {
+		return config.FilePath("config.yaml")
+	}
+	if path := config.ProjectFile("."); path != "" {
+		return filepath.Abs(path)
+	}
+	return filepath.Abs(config.ProjectConfigNames[0])
+}
+
+func init() {
+	configShowCmd.Flags().BoolVar(&configShowEffective, "effective", false, "merge the project config and flags, and show where each value came from")
+	configSetCmd.Flags().BoolVar(&configProject, "project", false, "write to the current project's .ward.yaml instead of ~/.ward/config.yaml")
+	configPathCmd.Flags().BoolVar(&configProject, "project", false, "print the current project's config path")
+	configCmd.AddCommand(configShowCmd, configGetCmd, configSetCmd, configValidateCmd, configPathCmd, configSchemaCmd)
+	rootCmd.AddCommand(configCmd)
+}
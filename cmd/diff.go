@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/reporter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFormat    string
+	diffHTML      string
+	diffFailOnNew string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <before> <after>",
+	Short: "Compare two scans or reports",
+	Long: `Compare two scans and list the findings the second one added, resolved
+and kept, grouped by severity. Each side is a stored scan ID (or a
+unique prefix of one), "latest", or a report written with --output json.
+Findings are matched by rule ID, file and line.
+
+--format prints the diff as text, json or markdown (for a pull request
+comment). --html also writes a standalone HTML page with both scans
+side by side, "-" for stdout instead of the printed diff.
+
+--fail-on-new exits with code 1 when the second scan adds a finding at
+or above a severity, so CI can gate on regressions only.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains([]string{"text", "json", "markdown", "md"}, diffFormat) {
+			return fmt.Errorf("--format must be text, json or markdown, not %q", diffFormat)
+		}
+		if diffFailOnNew != "" && !slices.Contains(config.Severities, strings.ToLower(diffFailOnNew)) {
+			return fmt.Errorf("--fail-on-new must be one of %s, not %q", strings.Join(config.Severities, ", "), diffFailOnNew)
+		}
+
+		before, beforeLabel, err := loadPastReport(args[0])
+		if err != nil {
+			return err
+		}
+		after, afterLabel, err := loadPastReport(args[1])
+		if err != nil {
+			return err
+		}
+		d := reporter.Diff{Before: before, After: after, BeforeLabel: beforeLabel, AfterLabel: afterLabel}
+
+		if diffHTML != "" {
+			if err := reporter.WriteFunc(diffHTML, d.RenderHTML); err != nil {
+				return err
+			}
+			if diffHTML != reporter.Stdout {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Diff written to %s\n", diffHTML)
+			}
+		}
+		if diffHTML != reporter.Stdout {
+			out := cmd.OutOrStdout()
+			switch diffFormat {
+			case "json":
+				err = d.RenderJSON(out)
+			case "markdown", "md":
+				err = d.RenderMarkdown(out)
+			default:
+				printDiff(out, d)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return checkFailOnNew(d)
+	},
+}
+
+// printDiff writes the diff for a terminal.
+func printDiff(out io.Writer, d reporter.Diff) {
+	added, resolved, unchanged := d.Compare()
+
+	dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
+	accent := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#5E35B1", Dark: "#B388FF"}).Bold(true)
+
+	fmt.Fprintf(out, "\n  %s %s → %s\n", accent.Render("Diff"), d.BeforeLabel, d.AfterLabel)
+	fmt.Fprintf(out, "  %d → %d findings: %d new, %d resolved, %d unchanged\n",
+		len(d.Before.Findings), len(d.After.Findings), len(added), len(resolved), len(unchanged))
+
+	for _, group := range []struct {
+		title    string
+		findings []models.Finding
+	}{
+		{"New", added},
+		{"Resolved", resolved},
+		{"Unchanged", unchanged},
+	} {
+		fmt.Fprintf(out, "\n  %s\n", accent.Render(fmt.Sprintf("%s (%d)", group.title, len(group.findings))))
+		for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+			first := true
+			for _, f := range group.findings {
+				if f.Severity != sev {
+					continue
+				}
+				if first {
+					fmt.Fprintf(out, "\n    %s\n", sevStyles[sev].Render(sev.String()))
+					first = false
+				}
+				loc := f.File
+				if f.Line > 0 {
+					loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+				}
+				fmt.Fprintf(out, "      %s %s\n", f.ID, f.Title)
+				fmt.Fprintf(out, "        %s\n", dim.Render(loc))
+				if f.Description != "" {
+					fmt.Fprintf(out, "        %s\n", dim.Render(f.Description))
+				}
+				if f.Remediation != "" {
+					fmt.Fprintf(out, "        %s %s\n", dim.Render("Fix:"), f.Remediation)
+				}
+			}
+		}
+	}
+	fmt.Fprintln(out)
+}
+
+// checkFailOnNew returns an error (causing exit code 1) if the diff adds
+// findings at or above the --fail-on-new severity.
+func checkFailOnNew(d reporter.Diff) error {
+	if diffFailOnNew == "" {
+		return nil
+	}
+	threshold := models.ParseSeverity(diffFailOnNew)
+
+	added, _, _ := d.Compare()
+	counts := make(map[models.Severity]int)
+	for _, f := range added {
+		if f.Severity >= threshold {
+			counts[f.Severity]++
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	var parts []string
+	for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+		if c := counts[sev]; c > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", c, sev))
+		}
+	}
+	return fmt.Errorf("new findings exceed --fail-on-new %s threshold: %s", diffFailOnNew, strings.Join(parts, ", "))
+}
+
+func readJSONReport(path string) (*models.ScanReport, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading report: %w", err)
+	}
+	defer f.Close()
+
+	report, err := reporter.ReadJSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return report, nil
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "print the diff as text, json or markdown")
+	diffCmd.Flags().StringVar(&diffHTML, "html", "", "also write the diff as an HTML page to this path, \"-\" for stdout")
+	diffCmd.Flags().StringVar(&diffFailOnNew, "fail-on-new", "", "exit code 1 if new findings are at or above this severity (info, low, medium, high, critical)")
+	rootCmd.AddCommand(diffCmd)
+}
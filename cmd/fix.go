@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/eventbus"
+	"github.com/eljakani/ward/internal/fixer"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/orchestrator"
+	"github.com/eljakani/ward/internal/provider"
+	"github.com/eljakani/ward/internal/tui/banner"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	fixApply bool
+	fixRules string
+)
+
+var fixCmd = &cobra.Command{
+	Use:   "fix [path]",
+	Short: "Preview or apply suggested fixes for findings",
+	Long: `Scan a local project and print the suggested fixes as a unified diff.
+Pass --apply to write the changes to disk.
+
+Status output goes to stderr so the diff can be piped to patch(1).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targetPath := args[0]
+		if provider.IsGitURL(targetPath) {
+			return fmt.Errorf("ward fix needs a local checkout, not a repository URL")
+		}
+
+		cfg, _, err := loadConfig(targetPath)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, banner.Render(Version))
+
+		dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
+		accent := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#5E35B1", Dark: "#B388FF"}).Bold(true)
+
+		bus := eventbus.New()
+		var report *models.ScanReport
+		bus.Subscribe(eventbus.EventScanCompleted, func(e eventbus.Event) {
+			report = e.Data.(eventbus.ScanCompletedData).Report
+		})
+
+		orch := orchestrator.New(bus, cfg, targetPath, Version)
+		orch.SetSkipOutputs(true)
+		if err := orch.Run(context.Background()); err != nil {
+			return err
+		}
+		if report == nil {
+			return nil
+		}
+
+		ruleIDs := strings.FieldsFunc(fixRules, func(r rune) bool { return r == ',' || r == ' ' })
+		findings := filterByRule(report.Findings, ruleIDs)
+		changes, err := fixer.Plan(report.ProjectContext.RootPath, findings)
+		if err != nil {
+			return err
+		}
+
+		colorize := !noColor && term.IsTerminal(int(os.Stdout.Fd()))
+		var applied, skipped, files int
+		for _, c := range changes {
+			applied += len(c.Applied)
+			skipped += len(c.Skipped)
+			diff := c.Diff()
+			if diff == "" {
+				continue
+			}
+			files++
+			if colorize {
+				diff = colorizeDiff(diff)
+			}
+			fmt.Print(diff)
+
+			if fixApply {
+				if err := c.Write(report.ProjectContext.RootPath); err != nil {
+					return err
+				}
+			}
+		}
+
+		fmt.Fprintln(os.Stderr)
+		switch {
+		case applied == 0:
+			fmt.Fprintln(os.Stderr, dim.Render("  No fixable findings."))
+		case fixApply:
+			fmt.Fprintf(os.Stderr, "  %s %d fix(es) written to %d file(s)\n", accent.Render("Applied."), applied, files)
+		default:
+			fmt.Fprintf(os.Stderr, "  %d fix(es) in %d file(s). %s\n", applied, files, dim.Render("Re-run with --apply to write them."))
+		}
+		if skipped > 0 {
+			fmt.Fprintln(os.Stderr, dim.Render(fmt.Sprintf("  %d fix(es) skipped: the file changed or the fixes overlap.", skipped)))
+		}
+		fmt.Fprintln(os.Stderr)
+
+		return nil
+	},
+}
+
+// filterByRule keeps findings whose rule ID is in ids (all if ids is empty).
+func filterByRule(findings []models.Finding, ids []string) []models.Finding {
+	if len(ids) == 0 {
+		return findings
+	}
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[strings.ToUpper(id)] = true
+	}
+	var result []models.Finding
+	for _, f := range findings {
+		if want[strings.ToUpper(f.ID)] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func colorizeDiff(diff string) string {
+	add := lipgloss.NewStyle().Foreground(lipgloss.Color("#81C784"))
+	del := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5252"))
+	hunk := lipgloss.NewStyle().Foreground(lipgloss.Color("#64B5F6"))
+	bold := lipgloss.NewStyle().Bold(true)
+
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	for i, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "---"), strings.HasPrefix(l, "+++"):
+			lines[i] = bold.Render(l)
+		case strings.HasPrefix(l, "@@"):
+			lines[i] = hunk.Render(l)
+		case strings.HasPrefix(l, "+"):
+			lines[i] = add.Render(l)
+		case strings.HasPrefix(l, "-"):
+			lines[i] = del.Render(l)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func init() {
+	fixCmd.Flags().BoolVar(&fixApply, "apply", false, "write the fixes to disk instead of only previewing them")
+	fixCmd.Flags().StringVar(&fixRules, "rule", "", "only fix findings from these rule IDs (comma-separated)")
+	rootCmd.AddCommand(fixCmd)
+}
@@ -0,0 +1,609 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/provider"
+	"github.com/eljakani/ward/internal/resolver"
+	"github.com/eljakani/ward/internal/store"
+	"github.com/eljakani/ward/internal/tui/components"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyProject string
+	historySince   string
+	historyUntil   string
+	historyRule    string
+	historyFile    string
+	historyLimit   int
+	historyBy      string
+
+	pruneKeepLast int
+	pruneKeepDays int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [path|url]",
+	Short: "List past scans from the scan store",
+	Long: `List the scans saved in ~/.ward/store, most recent first, with their
+finding counts by severity, scanners and duration.
+
+With a path or git URL, only scans of that project are listed: scans
+are kept under the project's identity (project.id in .ward.yaml, else
+its origin remote, composer name or path), so they follow a checkout
+that moves and include remote scans of the same repository. --project
+matches project names instead (case-insensitive substring). --since and
+--until take a date (2026-01-31), an RFC 3339 time, or an age such as
+7d, 4w or 36h; --until includes the whole day of a date. --rule and
+--file list the scans that reported a rule, or a finding in a file or
+directory.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		records, err := historyRecords(args)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		if len(records) == 0 {
+			fmt.Fprintln(out, "No stored scans match.")
+			return nil
+		}
+		matched := len(records)
+		if historyLimit > 0 && len(records) > historyLimit {
+			records = records[:historyLimit]
+		}
+
+		dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
+		fmt.Fprintln(out, dim.Render(fmt.Sprintf("  %-12s  %-16s  %-24s  %5s %5s %5s %5s %5s  %5s  %8s  %s",
+			"ID", "SCANNED", "PROJECT", "CRIT", "HIGH", "MED", "LOW", "INFO", "TOTAL", "DURATION", "SCANNERS")))
+		for _, r := range records {
+			fmt.Fprintf(out, "  %-12s  %-16s  %-24s  %s  %5d  %8s  %s\n",
+				r.ID, r.Timestamp.Local().Format("2006-01-02 15:04"), truncate(r.ProjectName, 24),
+				severityColumns(r.BySeverity), r.FindingCount, r.Duration.Round(time.Millisecond), scannerList(r.ScannersRun))
+		}
+		fmt.Fprintln(out, dim.Render(fmt.Sprintf("\n  %d of %d scans. ward history show <id> for details.", len(records), matched)))
+		return nil
+	},
+}
+
+var historyTrendCmd = &cobra.Command{
+	Use:   "trend [path|url]",
+	Short: "Chart finding counts by severity over time",
+	Long: `Chart the finding counts of each project's past scans by severity as
+terminal sparklines, oldest on the left.
+
+--by day or --by week keeps the last scan of each day or ISO week, so a
+project scanned on every push still shows one point per period.
+--limit caps the number of points. The filters are those of
+ward history.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		period := historyBy
+		if period != "scan" && period != "day" && period != "week" {
+			return fmt.Errorf("--by must be scan, day or week, not %q", period)
+		}
+		records, err := historyRecords(args)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		if len(records) == 0 {
+			fmt.Fprintln(out, "No stored scans match.")
+			return nil
+		}
+
+		dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
+		accent := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#5E35B1", Dark: "#B388FF"}).Bold(true)
+
+		// Records are newest first; group them by project in that order
+		// and chart each oldest first.
+		var projects []string
+		byProject := make(map[string][]store.ScanRecord)
+		for _, r := range records {
+			if _, ok := byProject[r.ProjectID]; !ok {
+				projects = append(projects, r.ProjectID)
+			}
+			byProject[r.ProjectID] = append(byProject[r.ProjectID], r)
+		}
+
+		for _, p := range projects {
+			points := trendPoints(byProject[p], period)
+			if historyLimit > 0 && len(points) > historyLimit {
+				points = points[len(points)-historyLimit:]
+			}
+			first, last := points[0], points[len(points)-1]
+
+			fmt.Fprintf(out, "\n  %s  %s\n", accent.Render(last.ProjectName), dim.Render(p))
+			fmt.Fprintln(out, dim.Render(fmt.Sprintf("  %d points by %s, %s → %s", len(points), period,
+				first.Timestamp.Local().Format("2006-01-02"), last.Timestamp.Local().Format("2006-01-02"))))
+			fmt.Fprintln(out)
+
+			for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+				values := make([]int, len(points))
+				for i, r := range points {
+					values[i] = r.BySeverity[sev.String()]
+				}
+				fmt.Fprintf(out, "    %s %s\n", sevStyles[sev].Render(fmt.Sprintf("%-9s", sev)), trendLine(values, period))
+			}
+			totals := make([]int, len(points))
+			for i, r := range points {
+				totals[i] = r.FindingCount
+			}
+			fmt.Fprintf(out, "    %-9s %s\n", "Total", trendLine(totals, period))
+		}
+		fmt.Fprintln(out)
+		return nil
+	},
+}
+
+var historyPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete old scans from the scan store",
+	Long: `Delete the scans that store.keep_last and store.keep_days no longer
+retain; ward scan does this after saving each scan. --keep-last and
+--keep-days override the config.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, _, err := loadConfig(".")
+		if err != nil {
+			return err
+		}
+		keep := cfg.Store
+		if cmd.Flags().Changed("keep-last") {
+			keep.KeepLast = pruneKeepLast
+		}
+		if cmd.Flags().Changed("keep-days") {
+			keep.KeepDays = pruneKeepDays
+		}
+		if keep.KeepLast <= 0 && keep.KeepDays <= 0 {
+			return fmt.Errorf("no retention set; pass --keep-last or --keep-days, or set store.keep_last or store.keep_days")
+		}
+
+		n, err := store.Prune(keep)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d scans.\n", n)
+		return nil
+	},
+}
+
+var historyMTTRCmd = &cobra.Command{
+	Use:   "mttr [path|url]",
+	Short: "Mean time to remediate by severity, and SLA breaches",
+	Long: `Follow each finding through the stored scans of its project, from the
+scan that first reported it to the first scan without it, and report
+the mean and median time to remediate by severity.
+
+With sla targets in the config (days by severity, e.g. sla.critical: 7),
+resolved findings that took longer are counted as late, and open
+findings past their target are listed as breaches, most severe and
+oldest first.
+
+--since and --until restrict the remediation times to findings resolved
+in that range; open findings are always included. The project, rule and
+file filters are those of ward history.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, _, err := loadConfig(".")
+		if err != nil {
+			return err
+		}
+		id := ""
+		if len(args) == 1 {
+			if id, err = projectIdentity(args[0]); err != nil {
+				return err
+			}
+		}
+		since, err := parseHistoryTime(historySince, false)
+		if err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+		until, err := parseHistoryTime(historyUntil, true)
+		if err != nil {
+			return fmt.Errorf("--until: %w", err)
+		}
+
+		lifecycles, err := store.Lifecycles(id)
+		if err != nil {
+			return err
+		}
+		lifecycles = slices.DeleteFunc(lifecycles, func(l store.FindingLifecycle) bool {
+			return !matchLifecycle(l) ||
+				(!l.Open() && ((!since.IsZero() && l.ResolvedAt.Before(since)) || (!until.IsZero() && !l.ResolvedAt.Before(until))))
+		})
+		out := cmd.OutOrStdout()
+		if len(lifecycles) == 0 {
+			fmt.Fprintln(out, "No findings in the stored scans match.")
+			return nil
+		}
+
+		dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
+		accent := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#5E35B1", Dark: "#B388FF"}).Bold(true)
+		now := time.Now()
+
+		var resolved int
+		var breaches []store.FindingLifecycle
+		fmt.Fprintf(out, "\n  %s\n\n", accent.Render("Time to remediate"))
+		fmt.Fprintf(out, "  %s\n", dim.Render(fmt.Sprintf("%-9s %8s %7s %7s %5s %6s %6s %9s", "SEVERITY", "RESOLVED", "MEAN", "MEDIAN", "SLA", "LATE", "OPEN", "BREACHING")))
+		for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+			sla := time.Duration(cfg.SLA[strings.ToLower(sev.String())]) * 24 * time.Hour
+			var times []time.Duration
+			var late, open, breaching int
+			for _, l := range lifecycles {
+				if l.Finding.Severity != sev {
+					continue
+				}
+				if l.Open() {
+					open++
+					if sla > 0 && now.Sub(l.FirstSeen) > sla {
+						breaching++
+						breaches = append(breaches, l)
+					}
+					continue
+				}
+				times = append(times, l.Age())
+				if sla > 0 && l.Age() > sla {
+					late++
+				}
+			}
+			if len(times) == 0 && open == 0 {
+				continue
+			}
+			resolved += len(times)
+
+			mean, median, slaCol, lateCol := "-", "-", "-", "-"
+			if len(times) > 0 {
+				slices.Sort(times)
+				var sum time.Duration
+				for _, d := range times {
+					sum += d
+				}
+				mean = models.FormatAge(sum / time.Duration(len(times)))
+				median = models.FormatAge(times[len(times)/2])
+			}
+			if sla > 0 {
+				slaCol = models.FormatAge(sla)
+				lateCol = strconv.Itoa(late)
+			}
+			breachCol := fmt.Sprintf("%9d", breaching)
+			if breaching > 0 {
+				breachCol = sevStyles[models.SeverityCritical].Render(breachCol)
+			}
+			fmt.Fprintf(out, "  %s %8d %7s %7s %5s %6s %6d %s\n",
+				sevStyles[sev].Render(fmt.Sprintf("%-9s", sev)), len(times), mean, median, slaCol, lateCol, open, breachCol)
+		}
+		fmt.Fprintln(out, dim.Render(fmt.Sprintf("\n  %d resolved findings across %d lifecycles.", resolved, len(lifecycles))))
+
+		if len(cfg.SLA) == 0 {
+			fmt.Fprintln(out, dim.Render("  Set sla.<severity> to a number of days to track SLA breaches, e.g. ward config set sla.critical 7"))
+			fmt.Fprintln(out)
+			return nil
+		}
+		if len(breaches) == 0 {
+			fmt.Fprintln(out, dim.Render("  No open findings past their SLA."))
+			fmt.Fprintln(out)
+			return nil
+		}
+
+		fmt.Fprintf(out, "\n  %s\n\n", accent.Render(fmt.Sprintf("SLA breaches (%d)", len(breaches))))
+		shown := breaches
+		if historyLimit > 0 && len(shown) > historyLimit {
+			shown = shown[:historyLimit]
+		}
+		for _, l := range shown {
+			f := l.Finding
+			loc := f.File
+			if f.Line > 0 {
+				loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+			}
+			since := l.FirstSeen.Local().Format("2006-01-02")
+			if l.FirstCommit != "" {
+				since += " at " + shortCommit(l.FirstCommit)
+			}
+			fmt.Fprintf(out, "  %s %s %s\n", sevStyles[f.Severity].Render(fmt.Sprintf("%-10s", "["+f.Severity.String()+"]")), f.ID, f.Title)
+			fmt.Fprintf(out, "  %-10s %s\n", "", dim.Render(fmt.Sprintf("%s · open %s, SLA %dd · first seen %s · %s",
+				loc, models.FormatAge(now.Sub(l.FirstSeen)), cfg.SLA[strings.ToLower(f.Severity.String())], since, l.ProjectName)))
+		}
+		if len(shown) < len(breaches) {
+			fmt.Fprintln(out, dim.Render(fmt.Sprintf("\n  %d of %d breaches; --limit 0 lists all.", len(shown), len(breaches))))
+		}
+		fmt.Fprintln(out)
+		return nil
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <scan-id|latest>",
+	Short: "Show one stored scan and its findings",
+	Long: `Show a stored scan and its findings by severity. The scan is a store
+ID, a unique prefix of one, or "latest".`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		record, err := store.FindRecord(args[0])
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
+		accent := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#5E35B1", Dark: "#B388FF"}).Bold(true)
+
+		var findings []models.Finding
+		var commit string
+		var report *models.ScanReport
+		if record.HasReport {
+			if report, err = store.LoadReport(record); err != nil {
+				return err
+			}
+			findings = report.Findings
+			commit = report.ProjectContext.GitCommit
+		} else if findings, err = store.Findings(record.ID); err != nil {
+			return err
+		}
+		models.SortFindings(findings)
+
+		field := func(name, value string) {
+			fmt.Fprintf(out, "  %s %s\n", dim.Render(fmt.Sprintf("%-10s", name)), value)
+		}
+		fmt.Fprintf(out, "\n  %s %s\n\n", accent.Render("Scan"), record.ID)
+		field("Project", fmt.Sprintf("%s (%s)", record.ProjectName, record.ProjectID))
+		if record.ProjectPath != record.ProjectID {
+			field("Path", record.ProjectPath)
+		}
+		field("Scanned", fmt.Sprintf("%s in %s", record.Timestamp.Local().Format("2006-01-02 15:04:05"), record.Duration.Round(time.Millisecond)))
+		if commit != "" {
+			field("Commit", commit)
+		}
+		field("Scanners", strings.Join(record.ScannersRun, ", "))
+		var counts []string
+		for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+			if c := record.BySeverity[sev.String()]; c > 0 {
+				counts = append(counts, sevStyles[sev].Render(fmt.Sprintf("%d %s", c, sev)))
+			}
+		}
+		summary := strconv.Itoa(record.FindingCount)
+		if len(counts) > 0 {
+			summary += " (" + strings.Join(counts, ", ") + ")"
+		}
+		field("Findings", summary)
+		fmt.Fprintln(out)
+
+		if report == nil {
+			fmt.Fprintln(out, dim.Render("  Stored without its full report; findings show rule, file and line only."))
+			fmt.Fprintln(out)
+		}
+		for _, f := range findings {
+			loc := f.File
+			if f.Line > 0 {
+				loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+			}
+			if report == nil {
+				fmt.Fprintf(out, "  %s  %s\n", f.ID, dim.Render(loc))
+				continue
+			}
+			if f.Lifecycle != nil {
+				loc += " · open " + models.FormatAge(f.Lifecycle.Age())
+			}
+			fmt.Fprintf(out, "  %s %s %s\n", sevStyles[f.Severity].Render(fmt.Sprintf("%-10s", "["+f.Severity.String()+"]")), f.ID, f.Title)
+			fmt.Fprintf(out, "  %-10s %s\n", "", dim.Render(loc))
+		}
+		if len(findings) > 0 {
+			fmt.Fprintln(out)
+		}
+		return nil
+	},
+}
+
+// historyRecords returns the stored scans that pass the history filters,
+// most recent first.
+func historyRecords(args []string) ([]store.ScanRecord, error) {
+	f := store.Filter{ProjectName: historyProject, Rule: historyRule, File: historyFile}
+	if len(args) == 1 {
+		id, err := projectIdentity(args[0])
+		if err != nil {
+			return nil, err
+		}
+		f.ProjectID = id
+	}
+
+	var err error
+	if f.Since, err = parseHistoryTime(historySince, false); err != nil {
+		return nil, fmt.Errorf("--since: %w", err)
+	}
+	if f.Until, err = parseHistoryTime(historyUntil, true); err != nil {
+		return nil, fmt.Errorf("--until: %w", err)
+	}
+	return store.ListRecords(f)
+}
+
+// projectIdentity returns the identity the scans of target are stored
+// under, resolved as a scan of it would.
+func projectIdentity(target string) (string, error) {
+	if provider.IsGitURL(target) {
+		return resolver.RemoteID(target), nil
+	}
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return "", fmt.Errorf("resolving path: %w", err)
+	}
+	cfg, _, err := config.LoadProject(abs)
+	if err != nil {
+		return "", fmt.Errorf("loading config: %w", err)
+	}
+
+	pc := &models.ProjectContext{}
+	for _, r := range []resolver.ContextResolver{resolver.NewGitResolver(), resolver.NewIdentityResolver(cfg.Project.ID)} {
+		if err := r.Resolve(context.Background(), abs, pc); err != nil {
+			return "", fmt.Errorf("resolving project: %w", err)
+		}
+	}
+	return pc.ProjectID, nil
+}
+
+// matchLifecycle applies the history project, rule and file filters to a
+// finding lifecycle.
+func matchLifecycle(l store.FindingLifecycle) bool {
+	if historyProject != "" && !strings.Contains(strings.ToLower(l.ProjectName), strings.ToLower(historyProject)) {
+		return false
+	}
+	if historyRule != "" && !strings.EqualFold(l.Finding.ID, historyRule) {
+		return false
+	}
+	if historyFile != "" {
+		dir := strings.TrimSuffix(historyFile, "/")
+		if l.Finding.File != dir && !strings.HasPrefix(l.Finding.File, dir+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// parseHistoryTime parses a date, an RFC 3339 time or an age (7d, 4w,
+// 36h). A date used as an end bound covers the whole day.
+func parseHistoryTime(s string, end bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if n, err := strconv.Atoi(strings.TrimRight(s, "dw")); err == nil && n >= 0 && len(s) > 1 {
+		switch s[len(s)-1] {
+		case 'd':
+			return time.Now().AddDate(0, 0, -n), nil
+		case 'w':
+			return time.Now().AddDate(0, 0, -7*n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date (2026-01-31), RFC 3339 time or age (7d, 4w, 36h)", s)
+}
+
+// trendPoints orders one project's records oldest first and, for day and
+// week periods, keeps the last scan of each period.
+func trendPoints(records []store.ScanRecord, period string) []store.ScanRecord {
+	points := make([]store.ScanRecord, 0, len(records))
+	lastKey := ""
+	for i := len(records) - 1; i >= 0; i-- {
+		r := records[i]
+		key := ""
+		switch period {
+		case "day":
+			key = r.Timestamp.Local().Format("2006-01-02")
+		case "week":
+			y, w := r.Timestamp.Local().ISOWeek()
+			key = fmt.Sprintf("%d-W%02d", y, w)
+		}
+		if key != "" && key == lastKey {
+			points[len(points)-1] = r
+			continue
+		}
+		lastKey = key
+		points = append(points, r)
+	}
+	return points
+}
+
+// trendLine renders a sparkline of values followed by the first and last
+// value and the change since the previous point.
+func trendLine(values []int, period string) string {
+	first, last := values[0], values[len(values)-1]
+	line := fmt.Sprintf("%s  %3d → %-3d", components.Sparkline(values), first, last)
+	if len(values) > 1 {
+		line += fmt.Sprintf("  %s since previous %s", trendDelta(last-values[len(values)-2]), period)
+	}
+	return line
+}
+
+func trendDelta(d int) string {
+	switch {
+	case d > 0:
+		return sevStyles[models.SeverityCritical].Render(fmt.Sprintf("▲%d", d))
+	case d < 0:
+		return sevStyles[models.SeverityLow].Render(fmt.Sprintf("▼%d", -d))
+	default:
+		return "="
+	}
+}
+
+// severityColumns formats counts by severity as fixed-width columns,
+// coloring the non-zero ones.
+func severityColumns(bySeverity map[string]int) string {
+	cols := make([]string, 0, 5)
+	for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+		c := fmt.Sprintf("%5d", bySeverity[sev.String()])
+		if bySeverity[sev.String()] > 0 {
+			c = sevStyles[sev].Render(c)
+		}
+		cols = append(cols, c)
+	}
+	return strings.Join(cols, " ")
+}
+
+// scannerList shortens scanner names for the history table.
+func scannerList(scanners []string) string {
+	names := make([]string, len(scanners))
+	for i, s := range scanners {
+		names[i] = strings.TrimSuffix(s, "-scanner")
+	}
+	return strings.Join(names, ", ")
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
+
+func init() {
+	for _, c := range []*cobra.Command{historyCmd, historyTrendCmd} {
+		c.Flags().StringVar(&historyProject, "project", "", "only scans of projects whose name contains this")
+		c.Flags().StringVar(&historySince, "since", "", "only scans at or after a date, time or age (7d, 4w)")
+		c.Flags().StringVar(&historyUntil, "until", "", "only scans before a time, or up to and including a date")
+		c.Flags().StringVar(&historyRule, "rule", "", "only scans that reported this rule ID")
+		c.Flags().StringVar(&historyFile, "file", "", "only scans with a finding in this file or directory (relative to the project)")
+		c.Flags().IntVarP(&historyLimit, "limit", "n", 20, "show at most this many scans or trend points (0 for all)")
+	}
+	historyMTTRCmd.Flags().StringVar(&historyProject, "project", "", "only findings of projects whose name contains this")
+	historyMTTRCmd.Flags().StringVar(&historySince, "since", "", "only remediation times of findings resolved at or after a date, time or age")
+	historyMTTRCmd.Flags().StringVar(&historyUntil, "until", "", "only remediation times of findings resolved before a time, or by the end of a date")
+	historyMTTRCmd.Flags().StringVar(&historyRule, "rule", "", "only findings of this rule ID")
+	historyMTTRCmd.Flags().StringVar(&historyFile, "file", "", "only findings in this file or directory (relative to the project)")
+	historyMTTRCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "list at most this many SLA breaches (0 for all)")
+	historyTrendCmd.Flags().StringVar(&historyBy, "by", "scan", "one trend point per scan, day or week")
+	historyPruneCmd.Flags().IntVar(&pruneKeepLast, "keep-last", 0, "keep this many scans per project (default store.keep_last)")
+	historyPruneCmd.Flags().IntVar(&pruneKeepDays, "keep-days", 0, "delete scans older than this many days (default store.keep_days)")
+
+	historyCmd.AddCommand(historyTrendCmd)
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historyMTTRCmd)
+	historyCmd.AddCommand(historyPruneCmd)
+	rootCmd.AddCommand(historyCmd)
+}
@@ -34,6 +34,7 @@ var initCmd = &cobra.Command{
 		fmt.Println()
 		fmt.Println(dim.Render("  Created:"))
 		fmt.Println(accent.Render(fmt.Sprintf("    %s/config.yaml", dir)) + dim.Render("       main config"))
+		fmt.Println(accent.Render(fmt.Sprintf("    %s/config.schema.json", dir)) + dim.Render(" editor schema"))
 		fmt.Println(accent.Render(fmt.Sprintf("    %s/rules/", dir)) + dim.Render("            custom rules"))
 		fmt.Println(accent.Render(fmt.Sprintf("    %s/reports/", dir)) + dim.Render("          scan reports"))
 		fmt.Println(accent.Render(fmt.Sprintf("    %s/store/", dir)) + dim.Render("            result store"))
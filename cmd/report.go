@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/eventbus"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/orchestrator"
+	"github.com/eljakani/ward/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFormats string
+	reportFailOn  string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report <scan-id|latest|file.json>",
+	Short: "Render a past scan in any report format without rescanning",
+	Long: `Render the reports of a stored scan again, in any format. The scan is
+a store ID (or a unique prefix of one), "latest" for the most recent
+scan, or a report written with --output json.
+
+Formats default to output.formats from the config in the current
+directory, and reports are written where a scan would write them
+(output.dir, output.name and output.paths). --format takes the same
+format=path syntax as --output.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, _, err := loadPastReport(args[0])
+		if err != nil {
+			return err
+		}
+
+		cfg, _, err := loadConfig(".")
+		if err != nil {
+			return err
+		}
+		if reportFormats != "" {
+			formats, paths := parseOutputFormats(reportFormats)
+			cfg.Output.Formats = formats
+			for f, p := range paths {
+				if cfg.Output.Paths == nil {
+					cfg.Output.Paths = make(map[string]string)
+				}
+				cfg.Output.Paths[f] = p
+			}
+			if err := cfg.Validate(); err != nil {
+				return fmt.Errorf("--format: %w", err)
+			}
+		}
+		cfg.Output.Formats = slices.DeleteFunc(cfg.Output.Formats, func(f string) bool {
+			return f == "terminal" || f == "ndjson"
+		})
+		if len(cfg.Output.Formats) == 0 {
+			return fmt.Errorf("no report formats to render; pass --format")
+		}
+
+		toStdout, err := stdoutReport(cfg)
+		if err != nil {
+			return err
+		}
+		out := io.Writer(os.Stdout)
+		if toStdout != "" {
+			out = os.Stderr
+		}
+
+		dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
+		bus := eventbus.New()
+		bus.Subscribe(eventbus.EventLogMessage, func(e eventbus.Event) {
+			data := e.Data.(eventbus.LogMessageData)
+			fmt.Fprintf(out, "  %s %s\n", dim.Render("["+data.Level+"]"), data.Message)
+		})
+
+		orch := orchestrator.New(bus, cfg, report.ProjectContext.RootPath, Version)
+		orch.SetFailOn(reportFailOn)
+		return orch.WriteReports(context.Background(), report)
+	},
+}
+
+// loadPastReport reads a JSON report file, or the full report of a
+// stored scan, and names it for display.
+func loadPastReport(ref string) (*models.ScanReport, string, error) {
+	if strings.HasSuffix(ref, ".json") {
+		if _, err := os.Stat(ref); err == nil {
+			report, err := readJSONReport(ref)
+			return report, ref, err
+		}
+	}
+
+	record, err := store.FindRecord(ref)
+	if err != nil {
+		return nil, "", err
+	}
+	report, err := store.LoadReport(record)
+	if err != nil {
+		return nil, "", err
+	}
+	return report, fmt.Sprintf("%s (%s)", record.ID, record.Timestamp.Local().Format("2006-01-02 15:04")), nil
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportFormats, "format", "", "comma-separated report formats (default output.formats); format=path sets a destination, \"-\" for stdout")
+	reportCmd.Flags().StringVar(&reportFailOn, "fail-on", "", "severity at which JUnit reports findings as failures (as with ward scan --fail-on)")
+	rootCmd.AddCommand(reportCmd)
+}
@@ -43,6 +43,8 @@ var rootCmd = &cobra.Command{
 		// Wait for the check to finish (≤2s due to HTTP timeout)
 		<-updateDone
 		if updateNotice != "" {
+			// stderr, so the notice never mixes into a --stream event stream
+			w := cmd.ErrOrStderr()
 			updateStyle := lipgloss.NewStyle().
 				Foreground(lipgloss.AdaptiveColor{Light: "#E65100", Dark: "#FFB74D"}).
 				Bold(true)
@@ -50,9 +52,9 @@ var rootCmd = &cobra.Command{
 				Border(lipgloss.RoundedBorder()).
 				BorderForeground(lipgloss.AdaptiveColor{Light: "#E65100", Dark: "#FFB74D"}).
 				Padding(0, 1)
-			fmt.Println()
-			fmt.Println(borderStyle.Render(updateStyle.Render("⬆ Update Available") + "\n" + updateNotice))
-			fmt.Println()
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, borderStyle.Render(updateStyle.Render("⬆ Update Available")+"\n"+updateNotice))
+			fmt.Fprintln(w)
 		}
 	},
 	Run: func(cmd *cobra.Command, args []string) {
@@ -82,5 +84,5 @@ func Execute() error {
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable color output")
-	rootCmd.PersistentFlags().StringVarP(&outputFmt, "output", "o", "tui", "output mode: tui (interactive), or comma-separated formats (json,sarif,html,markdown)")
+	rootCmd.PersistentFlags().StringVarP(&outputFmt, "output", "o", "tui", "output mode: tui (interactive), or comma-separated formats (json,sarif,html,markdown,junit,gitlab-sast,gitlab-codequality,csv,jsonl,compliance,ndjson); format=path sets a destination, \"-\" for stdout")
 }
@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,8 +19,11 @@ import (
 	"github.com/eljakani/ward/internal/eventbus"
 	"github.com/eljakani/ward/internal/models"
 	"github.com/eljakani/ward/internal/orchestrator"
+	"github.com/eljakani/ward/internal/provider"
+	"github.com/eljakani/ward/internal/reporter"
 	"github.com/eljakani/ward/internal/tui"
 	"github.com/eljakani/ward/internal/tui/banner"
+	"github.com/eljakani/ward/internal/tui/views"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 )
@@ -23,6 +32,8 @@ var (
 	failOn         string
 	baselinePath   string
 	updateBaseline string
+	stream         bool
+	noReportFiles  bool
 )
 
 var scanCmd = &cobra.Command{
@@ -32,36 +43,101 @@ var scanCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		targetPath := args[0]
 
-		cfg, err := config.Load()
+		cfg, origins, err := loadConfig(targetPath)
 		if err != nil {
-			return fmt.Errorf("loading config: %w", err)
+			return err
 		}
 
-		// Load baseline if specified
+		// Load the baseline: --baseline, else the project's if it exists
 		var bl *baseline.Baseline
-		if baselinePath != "" {
-			bl, err = baseline.Load(baselinePath)
+		if path := projectBaseline(cfg, targetPath); path != "" {
+			bl, err = baseline.Load(path)
+			if errors.Is(err, fs.ErrNotExist) && baselinePath == "" {
+				bl, err = nil, nil
+			}
 			if err != nil {
 				return fmt.Errorf("loading baseline: %w", err)
 			}
 		}
 
-		// If --output specifies formats (not "tui"), override config and run headless
+		// Streaming events or a report to stdout leaves no room for the TUI
+		toStdout, err := stdoutReport(cfg)
+		if err != nil {
+			return err
+		}
+		if streaming(cfg) || toStdout != "" {
+			return runHeadless(cfg, origins, targetPath, bl)
+		}
+
+		// If --output specifies formats (not "tui"), run headless
 		if outputFmt != "tui" {
-			cfg.Output.Formats = parseOutputFormats(outputFmt)
-			return runHeadless(cfg, targetPath, bl)
+			return runHeadless(cfg, origins, targetPath, bl)
 		}
 
 		// If no TTY available, fall back to headless
 		if !term.IsTerminal(int(os.Stdin.Fd())) {
-			return runHeadless(cfg, targetPath, bl)
+			return runHeadless(cfg, origins, targetPath, bl)
 		}
 
-		return runWithTUI(cfg, targetPath, bl)
+		return runWithTUI(cfg, origins, targetPath, bl)
 	},
 }
 
-func configureOrch(orch *orchestrator.Orchestrator, bl *baseline.Baseline) {
+// loadConfig merges the config layers for targetPath — defaults, user
+// config, the project's .ward.yaml — and applies CLI flags on top.
+// Remote targets aren't cloned yet; the orchestrator applies their
+// project config once they are.
+func loadConfig(targetPath string) (*config.WardConfig, config.Origins, error) {
+	root := targetPath
+	if provider.IsGitURL(targetPath) {
+		root = ""
+	}
+
+	cfg, origins, err := config.LoadProject(root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	if outputFmt != "tui" {
+		formats, paths := parseOutputFormats(outputFmt)
+		cfg.Output.Formats = formats
+		origins.Set("output.formats", "flag --output")
+		for f, p := range paths {
+			if cfg.Output.Paths == nil {
+				cfg.Output.Paths = make(map[string]string)
+			}
+			cfg.Output.Paths[f] = p
+			origins.Set("output.paths."+f, "flag --output")
+		}
+		if err := cfg.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("--output: %w", err)
+		}
+	}
+
+	return cfg, origins, nil
+}
+
+// projectBaseline returns the baseline the scan loads and TUI triage
+// writes to: --baseline, or triage.baseline in a local project. Remote
+// clones are discarded after the scan, so they have none.
+func projectBaseline(cfg *config.WardConfig, targetPath string) string {
+	if baselinePath != "" {
+		return baselinePath
+	}
+	if provider.IsGitURL(targetPath) || cfg.Triage.Baseline == "" {
+		return ""
+	}
+	if filepath.IsAbs(cfg.Triage.Baseline) {
+		return cfg.Triage.Baseline
+	}
+	return filepath.Join(targetPath, cfg.Triage.Baseline)
+}
+
+func configureOrch(orch *orchestrator.Orchestrator, origins config.Origins, bl *baseline.Baseline) {
+	orch.SetOrigins(origins)
+	orch.SetVerbose(verbose)
+	orch.SetFailOn(failOn)
+	orch.SetNoReportFiles(noReportFiles)
 	if bl != nil {
 		orch.SetBaseline(bl)
 	}
@@ -70,9 +146,18 @@ func configureOrch(orch *orchestrator.Orchestrator, bl *baseline.Baseline) {
 	}
 }
 
-func runWithTUI(cfg *config.WardConfig, targetPath string, bl *baseline.Baseline) error {
+func runWithTUI(cfg *config.WardConfig, origins config.Origins, targetPath string, bl *baseline.Baseline) error {
 	bus := eventbus.New()
 	model := tui.NewApp(bus, targetPath, Version)
+	model.SetTriage(views.TriageOptions{
+		Baseline: projectBaseline(cfg, targetPath),
+		Editor:   cfg.Triage.Editor,
+	})
+	rules := views.RulesOptions{ProjectRoot: targetPath}
+	if provider.IsGitURL(targetPath) {
+		rules.ProjectRoot = ""
+	}
+	model.SetRules(rules)
 
 	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
@@ -89,7 +174,7 @@ func runWithTUI(cfg *config.WardConfig, targetPath string, bl *baseline.Baseline
 
 	go func() {
 		orch := orchestrator.New(bus, cfg, targetPath, Version)
-		configureOrch(orch, bl)
+		configureOrch(orch, origins, bl)
 		if err := orch.Run(context.Background()); err != nil {
 			bus.Publish(eventbus.NewEvent(eventbus.EventScanFailed, eventbus.ScanFailedData{
 				Error: err,
@@ -106,45 +191,90 @@ func runWithTUI(cfg *config.WardConfig, targetPath string, bl *baseline.Baseline
 	return checkFailOn(finalReport)
 }
 
-func runHeadless(cfg *config.WardConfig, targetPath string, bl *baseline.Baseline) error {
-	fmt.Println(banner.Render(Version))
+// stdoutReport returns the format whose report goes to stdout ("-" in
+// output.paths or -o format=-), if any. Only one thing can own stdout.
+func stdoutReport(cfg *config.WardConfig) (string, error) {
+	var found []string
+	for f, p := range cfg.Output.Paths {
+		active := slices.Contains(cfg.Output.Formats, f) ||
+			(f == "md" && slices.Contains(cfg.Output.Formats, "markdown")) ||
+			(f == "markdown" && slices.Contains(cfg.Output.Formats, "md"))
+		if p == reporter.Stdout && active {
+			found = append(found, f)
+		}
+	}
+	sort.Strings(found)
+
+	switch {
+	case len(found) > 1:
+		return "", fmt.Errorf("only one report can be written to stdout, got %s", strings.Join(found, ", "))
+	case len(found) == 1 && streaming(cfg):
+		return "", fmt.Errorf("--stream and %s=- both write to stdout", found[0])
+	case len(found) == 1:
+		return found[0], nil
+	}
+	return "", nil
+}
+
+// streaming reports whether scan events should be written to stdout as
+// NDJSON, requested with --stream or an "ndjson" output format.
+func streaming(cfg *config.WardConfig) bool {
+	return stream || slices.Contains(cfg.Output.Formats, "ndjson")
+}
+
+// runHeadless scans without the TUI. When stdout carries NDJSON events or
+// a report, the banner, progress and summary go to stderr instead.
+func runHeadless(cfg *config.WardConfig, origins config.Origins, targetPath string, bl *baseline.Baseline) error {
+	stream := streaming(cfg)
+	toStdout, err := stdoutReport(cfg)
+	if err != nil {
+		return err
+	}
+
+	out := io.Writer(os.Stdout)
+	if stream || toStdout != "" {
+		out = os.Stderr
+	}
+	fmt.Fprintln(out, banner.Render(Version))
 
 	bus := eventbus.New()
 
+	var events *eventbus.NDJSONWriter
+	if stream {
+		events = eventbus.NewNDJSONWriter(os.Stdout)
+		bus.SubscribeAll(events.Handle)
+	}
+
 	dim := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#757575", Dark: "#9E9E9E"})
 	accent := lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#5E35B1", Dark: "#B388FF"}).Bold(true)
-	sevStyles := map[models.Severity]*lipgloss.Style{
-		models.SeverityCritical: ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5252")).Bold(true)),
-		models.SeverityHigh:     ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB74D")).Bold(true)),
-		models.SeverityMedium:   ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD54F"))),
-		models.SeverityLow:      ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#81C784"))),
-		models.SeverityInfo:     ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#64B5F6"))),
-	}
 
 	// Capture report for fail-on
 	var finalReport *models.ScanReport
 
-	// Print events as they happen
-	bus.Subscribe(eventbus.EventStageStarted, func(e eventbus.Event) {
-		data := e.Data.(eventbus.StageStartedData)
-		fmt.Printf("  %s %s\n", accent.Render("●"), data.Stage)
-	})
-
-	bus.Subscribe(eventbus.EventFindingDiscovered, func(e eventbus.Event) {
-		data := e.Data.(eventbus.FindingDiscoveredData)
-		f := data.Finding
-		style := sevStyles[f.Severity]
-		fmt.Printf("    %s %s\n", style.Render(fmt.Sprintf("[%s]", f.Severity)), f.Title)
-	})
-
-	bus.Subscribe(eventbus.EventScannerCompleted, func(e eventbus.Event) {
-		data := e.Data.(eventbus.ScannerCompletedData)
-		fmt.Printf("  %s %s — %d findings\n", dim.Render("✓"), data.Name, data.FindingCount)
-	})
+	// Print events as they happen; when streaming, the NDJSON already
+	// carries stages, scanners and findings.
+	if !stream {
+		bus.Subscribe(eventbus.EventStageStarted, func(e eventbus.Event) {
+			data := e.Data.(eventbus.StageStartedData)
+			fmt.Fprintf(out, "  %s %s\n", accent.Render("●"), data.Stage)
+		})
+
+		bus.Subscribe(eventbus.EventFindingDiscovered, func(e eventbus.Event) {
+			data := e.Data.(eventbus.FindingDiscoveredData)
+			f := data.Finding
+			style := sevStyles[f.Severity]
+			fmt.Fprintf(out, "    %s %s\n", style.Render(fmt.Sprintf("[%s]", f.Severity)), f.Title)
+		})
+
+		bus.Subscribe(eventbus.EventScannerCompleted, func(e eventbus.Event) {
+			data := e.Data.(eventbus.ScannerCompletedData)
+			fmt.Fprintf(out, "  %s %s — %d findings\n", dim.Render("✓"), data.Name, data.FindingCount)
+		})
+	}
 
 	bus.Subscribe(eventbus.EventLogMessage, func(e eventbus.Event) {
 		data := e.Data.(eventbus.LogMessageData)
-		fmt.Printf("  %s %s\n", dim.Render("["+data.Level+"]"), data.Message)
+		fmt.Fprintf(out, "  %s %s\n", dim.Render("["+data.Level+"]"), data.Message)
 	})
 
 	bus.Subscribe(eventbus.EventScanCompleted, func(e eventbus.Event) {
@@ -152,22 +282,27 @@ func runHeadless(cfg *config.WardConfig, targetPath string, bl *baseline.Baselin
 		r := data.Report
 		finalReport = r
 		counts := r.CountBySeverity()
-		fmt.Println()
-		fmt.Printf("  %s %d findings in %s\n", accent.Render("Done."), len(r.Findings), r.Duration.Round(1e6))
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "  %s %d findings in %s\n", accent.Render("Done."), len(r.Findings), r.Duration.Round(1e6))
 		for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
 			if c := counts[sev]; c > 0 {
 				style := sevStyles[sev]
-				fmt.Printf("    %s %d\n", style.Render(fmt.Sprintf("%-10s", sev)), c)
+				fmt.Fprintf(out, "    %s %d\n", style.Render(fmt.Sprintf("%-10s", sev)), c)
 			}
 		}
-		fmt.Println()
+		fmt.Fprintln(out)
 	})
 
 	orch := orchestrator.New(bus, cfg, targetPath, Version)
-	configureOrch(orch, bl)
+	configureOrch(orch, origins, bl)
 	if err := orch.Run(context.Background()); err != nil {
 		return err
 	}
+	if events != nil {
+		if err := events.Err(); err != nil {
+			return fmt.Errorf("writing event stream: %w", err)
+		}
+	}
 
 	return checkFailOn(finalReport)
 }
@@ -201,22 +336,39 @@ func checkFailOn(report *models.ScanReport) error {
 
 func ptr(s lipgloss.Style) *lipgloss.Style { return &s }
 
-// parseOutputFormats splits a comma-separated format string into a list.
-// e.g. "json" → ["json"], "json,sarif" → ["json", "sarif"]
-func parseOutputFormats(s string) []string {
+var sevStyles = map[models.Severity]*lipgloss.Style{
+	models.SeverityCritical: ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5252")).Bold(true)),
+	models.SeverityHigh:     ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB74D")).Bold(true)),
+	models.SeverityMedium:   ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD54F"))),
+	models.SeverityLow:      ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#81C784"))),
+	models.SeverityInfo:     ptr(lipgloss.NewStyle().Foreground(lipgloss.Color("#64B5F6"))),
+}
+
+// parseOutputFormats splits a comma-separated format string into a list,
+// with optional per-format paths.
+// e.g. "json" → ["json"], "json,sarif=-" → ["json", "sarif"], {sarif: "-"}
+func parseOutputFormats(s string) ([]string, map[string]string) {
 	var formats []string
+	paths := make(map[string]string)
 	for _, f := range strings.Split(s, ",") {
+		f, path, hasPath := strings.Cut(f, "=")
 		f = strings.TrimSpace(f)
-		if f != "" {
-			formats = append(formats, f)
+		if f == "" {
+			continue
+		}
+		formats = append(formats, f)
+		if hasPath {
+			paths[f] = strings.TrimSpace(path)
 		}
 	}
-	return formats
+	return formats, paths
 }
 
 func init() {
 	scanCmd.Flags().StringVar(&failOn, "fail-on", "", "exit code 1 if findings at or above this severity (info, low, medium, high, critical)")
 	scanCmd.Flags().StringVar(&baselinePath, "baseline", "", "path to baseline file — suppress known findings")
 	scanCmd.Flags().StringVar(&updateBaseline, "update-baseline", "", "save current findings as a new baseline file at this path")
+	scanCmd.Flags().BoolVar(&noReportFiles, "no-report-files", false, "don't write report files; reports sent to stdout (format=-) are still written")
+	scanCmd.Flags().BoolVar(&stream, "stream", false, "write scan events to stdout as newline-delimited JSON (same as -o ndjson)")
 	rootCmd.AddCommand(scanCmd)
 }
@@ -2,21 +2,42 @@ package baseline
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/eljakani/ward/internal/models"
 )
 
+// Status is how a finding was triaged. Entries saved with
+// --update-baseline have none.
+type Status string
+
+const (
+	StatusFalsePositive Status = "false_positive"
+	StatusAcceptedRisk  Status = "accepted_risk"
+	StatusToFix         Status = "to_fix" // kept in reports, flagged for fixing
+)
+
 // Entry represents a single baselined finding.
 type Entry struct {
-	Fingerprint string `json:"fingerprint"`
-	ID          string `json:"id"`
-	File        string `json:"file"`
-	Line        int    `json:"line"`
-	Title       string `json:"title"`
-	Severity    string `json:"severity"`
+	Fingerprint string    `json:"fingerprint"`
+	ID          string    `json:"id"`
+	File        string    `json:"file"`
+	Line        int       `json:"line"`
+	Title       string    `json:"title"`
+	Severity    string    `json:"severity"`
+	Status      Status    `json:"status,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	TriagedAt   time.Time `json:"triaged_at,omitzero"`
+}
+
+// Suppresses reports whether the entry hides its finding from reports.
+func (e Entry) Suppresses() bool {
+	return e.Status != StatusToFix
 }
 
 // Baseline is the on-disk format for suppressed findings.
@@ -27,7 +48,7 @@ type Baseline struct {
 	Entries   []Entry   `json:"entries"`
 
 	// In-memory lookup
-	fingerprints map[string]bool
+	fingerprints map[string]int // index into Entries
 }
 
 // Load reads a baseline file from disk.
@@ -42,35 +63,101 @@ func Load(path string) (*Baseline, error) {
 		return nil, fmt.Errorf("parsing baseline %s: %w", path, err)
 	}
 
-	b.fingerprints = make(map[string]bool, len(b.Entries))
-	for _, e := range b.Entries {
-		b.fingerprints[e.Fingerprint] = true
-	}
-
+	b.index()
 	return &b, nil
 }
 
-// Save writes a baseline file to disk from the given findings.
+func (b *Baseline) index() {
+	b.fingerprints = make(map[string]int, len(b.Entries))
+	for i, e := range b.Entries {
+		b.fingerprints[e.Fingerprint] = i
+	}
+}
+
+// Save writes a baseline file to disk from the given findings. Findings
+// already triaged in the file at path keep their status, reason and date.
 func Save(path string, findings []models.Finding) error {
+	prev, err := loadOrNew(path)
+	if err != nil {
+		return err
+	}
+
 	entries := make([]Entry, 0, len(findings))
 	for _, f := range findings {
-		entries = append(entries, Entry{
-			Fingerprint: f.Fingerprint(),
-			ID:          f.ID,
-			File:        f.File,
-			Line:        f.Line,
-			Title:       f.Title,
-			Severity:    f.Severity.String(),
-		})
+		e := newEntry(f)
+		if old, ok := prev.Lookup(f); ok {
+			e.Status, e.Reason, e.TriagedAt = old.Status, old.Reason, old.TriagedAt
+		}
+		entries = append(entries, e)
 	}
 
 	b := Baseline{
 		Version:   "1.0",
-		CreatedAt: time.Now().UTC(),
+		CreatedAt: prev.CreatedAt,
 		UpdatedAt: time.Now().UTC(),
 		Entries:   entries,
 	}
+	return b.write(path)
+}
 
+func newEntry(f models.Finding) Entry {
+	return Entry{
+		Fingerprint: f.Fingerprint(),
+		ID:          f.ID,
+		File:        f.File,
+		Line:        f.Line,
+		Title:       f.Title,
+		Severity:    f.Severity.String(),
+	}
+}
+
+// Triage records how finding f was triaged in the baseline at path,
+// creating the file if needed. False positives and accepted risks are
+// suppressed from later scans; findings to fix stay in reports.
+func Triage(path string, f models.Finding, status Status, reason string) error {
+	b, err := loadOrNew(path)
+	if err != nil {
+		return err
+	}
+
+	e := newEntry(f)
+	e.Status, e.Reason, e.TriagedAt = status, reason, time.Now().UTC()
+	if i, ok := b.fingerprints[e.Fingerprint]; ok {
+		b.Entries[i] = e
+	} else {
+		b.Entries = append(b.Entries, e)
+	}
+	b.UpdatedAt = e.TriagedAt
+	return b.write(path)
+}
+
+// Untriage removes finding f from the baseline at path.
+func Untriage(path string, f models.Finding) error {
+	b, err := loadOrNew(path)
+	if err != nil {
+		return err
+	}
+
+	fp := f.Fingerprint()
+	b.Entries = slices.DeleteFunc(b.Entries, func(e Entry) bool { return e.Fingerprint == fp })
+	b.UpdatedAt = time.Now().UTC()
+	return b.write(path)
+}
+
+// loadOrNew loads the baseline at path, or returns an empty one if the
+// file doesn't exist yet.
+func loadOrNew(path string) (*Baseline, error) {
+	b, err := Load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		now := time.Now().UTC()
+		b = &Baseline{Version: "1.0", CreatedAt: now, UpdatedAt: now}
+		b.index()
+		return b, nil
+	}
+	return b, err
+}
+
+func (b *Baseline) write(path string) error {
 	data, err := json.MarshalIndent(b, "", "  ")
 	if err != nil {
 		return fmt.Errorf("encoding baseline: %w", err)
@@ -83,12 +170,22 @@ func Save(path string, findings []models.Finding) error {
 	return nil
 }
 
-// IsBaselined returns true if the finding is suppressed by this baseline.
-func (b *Baseline) IsBaselined(f models.Finding) bool {
+// Lookup returns the baseline entry for the finding, if any.
+func (b *Baseline) Lookup(f models.Finding) (Entry, bool) {
 	if b == nil || b.fingerprints == nil {
-		return false
+		return Entry{}, false
+	}
+	i, ok := b.fingerprints[f.Fingerprint()]
+	if !ok {
+		return Entry{}, false
 	}
-	return b.fingerprints[f.Fingerprint()]
+	return b.Entries[i], true
+}
+
+// IsBaselined returns true if the finding is suppressed by this baseline.
+func (b *Baseline) IsBaselined(f models.Finding) bool {
+	e, ok := b.Lookup(f)
+	return ok && e.Suppresses()
 }
 
 // Filter removes baselined findings from the list and returns:
@@ -109,3 +206,12 @@ func (b *Baseline) Filter(findings []models.Finding) (filtered []models.Finding,
 	}
 	return filtered, suppressed
 }
+
+// Annotate sets the Triage status of the findings flagged to fix.
+func (b *Baseline) Annotate(findings []models.Finding) {
+	for i, f := range findings {
+		if e, ok := b.Lookup(f); ok && e.Status == StatusToFix {
+			findings[i].Triage = string(e.Status)
+		}
+	}
+}
@@ -105,3 +105,56 @@ func TestLoadNonExistent(t *testing.T) {
 		t.Error("Expected error loading non-existent file")
 	}
 }
+
+func TestTriage(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".ward-baseline.json")
+	findings := sampleFindings()
+
+	// Triage creates the file
+	if err := Triage(path, findings[0], StatusFalsePositive, ""); err != nil {
+		t.Fatalf("Triage failed: %v", err)
+	}
+	if err := Triage(path, findings[1], StatusAcceptedRisk, "internal tool"); err != nil {
+		t.Fatalf("Triage failed: %v", err)
+	}
+	if err := Triage(path, findings[2], StatusToFix, ""); err != nil {
+		t.Fatalf("Triage failed: %v", err)
+	}
+	// Triaging again replaces the entry
+	if err := Triage(path, findings[0], StatusFalsePositive, "dev only"); err != nil {
+		t.Fatalf("Triage failed: %v", err)
+	}
+
+	bl, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(bl.Entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(bl.Entries))
+	}
+	if e, _ := bl.Lookup(findings[0]); e.Reason != "dev only" || e.TriagedAt.IsZero() {
+		t.Errorf("Expected reason and triage time on the replaced entry, got %+v", e)
+	}
+
+	// Findings to fix stay in reports, flagged
+	filtered, suppressed := bl.Filter(findings)
+	if suppressed != 2 || len(filtered) != 1 || filtered[0].ID != "SEC-001" {
+		t.Fatalf("Expected SEC-001 kept and 2 suppressed, got %d kept, %d suppressed", len(filtered), suppressed)
+	}
+	bl.Annotate(filtered)
+	if filtered[0].Triage != string(StatusToFix) {
+		t.Errorf("Expected triage %q, got %q", StatusToFix, filtered[0].Triage)
+	}
+
+	if err := Untriage(path, findings[1]); err != nil {
+		t.Fatalf("Untriage failed: %v", err)
+	}
+	bl, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if bl.IsBaselined(findings[1]) || !bl.IsBaselined(findings[0]) {
+		t.Error("Expected only the untriaged finding to be removed")
+	}
+}
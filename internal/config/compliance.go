@@ -0,0 +1,161 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed defaults/compliance/*.yaml
+var defaultComplianceFS embed.FS
+
+// ComplianceFramework maps the controls of a standard, such as the OWASP
+// Top 10, to the rules and checks that cover them.
+type ComplianceFramework struct {
+	ID       string              `yaml:"id"`
+	Name     string              `yaml:"name"`
+	Controls []ComplianceControl `yaml:"controls"`
+}
+
+// ComplianceControl is one requirement of a framework. A rule, check or
+// finding covers it when it matches any of the selectors; a control
+// without selectors has no coverage.
+type ComplianceControl struct {
+	ID         string   `yaml:"id"`
+	Title      string   `yaml:"title"`
+	Rules      []string `yaml:"rules,omitempty"`      // rule or check IDs; a trailing * matches a prefix
+	Tags       []string `yaml:"tags,omitempty"`       // rule tags, e.g. owasp-a03
+	CWEs       []int    `yaml:"cwes,omitempty"`       // from cwe-N tags and CWE reference links
+	Categories []string `yaml:"categories,omitempty"` // finding categories
+	Scanners   []string `yaml:"scanners,omitempty"`   // scanners that cover the control whenever they run
+}
+
+// ComplianceFile is the top-level structure of a compliance mapping file.
+type ComplianceFile struct {
+	Frameworks []ComplianceFramework `yaml:"frameworks"`
+}
+
+// LoadCompliance returns the built-in compliance mappings merged with
+// the .yaml files in ~/.ward/compliance. A user file can add frameworks,
+// or extend a built-in one by using its id: controls with a known id
+// replace the built-in control, others are appended.
+func LoadCompliance() ([]ComplianceFramework, error) {
+	var frameworks []ComplianceFramework
+
+	entries, err := defaultComplianceFS.ReadDir("defaults/compliance")
+	if err != nil {
+		return nil, fmt.Errorf("reading embedded compliance mappings: %w", err)
+	}
+	for _, entry := range entries {
+		data, err := defaultComplianceFS.ReadFile("defaults/compliance/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("reading embedded compliance mapping %s: %w", entry.Name(), err)
+		}
+		fws, err := parseCompliance(entry.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		frameworks = mergeFrameworks(frameworks, fws)
+	}
+
+	dir, err := ComplianceDir()
+	if err != nil {
+		return nil, err
+	}
+	user, err := LoadComplianceFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return mergeFrameworks(frameworks, user), nil
+}
+
+// LoadComplianceFromDir loads all .yaml and .yml compliance mapping files
+// from a directory, in file name order.
+func LoadComplianceFromDir(dir string) ([]ComplianceFramework, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading compliance dir %s: %w", dir, err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var all []ComplianceFramework
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading compliance file %s: %w", path, err)
+		}
+		fws, err := parseCompliance(path, data)
+		if err != nil {
+			return nil, err
+		}
+		all = mergeFrameworks(all, fws)
+	}
+	return all, nil
+}
+
+func parseCompliance(name string, data []byte) ([]ComplianceFramework, error) {
+	var cf ComplianceFile
+	if err := yaml.Unmarshal(data, &cf); err != nil {
+		return nil, fmt.Errorf("parsing compliance file %s: %w", name, err)
+	}
+	for _, fw := range cf.Frameworks {
+		if fw.ID == "" {
+			return nil, fmt.Errorf("compliance file %s: framework without an id", name)
+		}
+		for _, c := range fw.Controls {
+			if c.ID == "" {
+				return nil, fmt.Errorf("compliance file %s: control without an id in framework %s", name, fw.ID)
+			}
+		}
+	}
+	return cf.Frameworks, nil
+}
+
+// mergeFrameworks applies extra on top of base; see LoadCompliance.
+func mergeFrameworks(base, extra []ComplianceFramework) []ComplianceFramework {
+	for _, fw := range extra {
+		i := indexFramework(base, fw.ID)
+		if i < 0 {
+			base = append(base, fw)
+			continue
+		}
+		if fw.Name != "" {
+			base[i].Name = fw.Name
+		}
+		for _, c := range fw.Controls {
+			replaced := false
+			for j := range base[i].Controls {
+				if base[i].Controls[j].ID == c.ID {
+					base[i].Controls[j] = c
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				base[i].Controls = append(base[i].Controls, c)
+			}
+		}
+	}
+	return base
+}
+
+func indexFramework(fws []ComplianceFramework, id string) int {
+	for i, fw := range fws {
+		if fw.ID == id {
+			return i
+		}
+	}
+	return -1
+}
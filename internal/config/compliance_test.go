@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadCompliance_Defaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	frameworks, err := LoadCompliance()
+	if err != nil {
+		t.Fatalf("LoadCompliance() error: %v", err)
+	}
+
+	want := map[string]int{"owasp-top10-2021": 10, "cwe-top25-2024": 25, "owasp-asvs-4": 0, "pci-dss-4": 0}
+	for _, fw := range frameworks {
+		n, ok := want[fw.ID]
+		if !ok {
+			t.Errorf("unexpected framework %q", fw.ID)
+			continue
+		}
+		delete(want, fw.ID)
+		if n > 0 && len(fw.Controls) != n {
+			t.Errorf("%s has %d controls, want %d", fw.ID, len(fw.Controls), n)
+		}
+		seen := make(map[string]bool)
+		for _, c := range fw.Controls {
+			if seen[c.ID] {
+				t.Errorf("%s: duplicate control %s", fw.ID, c.ID)
+			}
+			seen[c.ID] = true
+		}
+	}
+	for id := range want {
+		t.Errorf("missing built-in framework %q", id)
+	}
+}
+
+func TestLoadCompliance_UserFiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".ward", "compliance")
+	os.MkdirAll(dir, 0755)
+
+	os.WriteFile(filepath.Join(dir, "custom.yaml"), []byte(`frameworks:
+  - id: acme
+    name: ACME Standard
+    controls:
+      - {id: SC-1, title: No raw SQL, cwes: [89]}
+  - id: pci-dss-4
+    controls:
+      - {id: "6.2.3", title: Code is reviewed, rules: [REVIEW-*]}
+      - {id: "6.9.9", title: Extra control}
+`), 0644)
+	os.WriteFile(filepath.Join(dir, "custom-example.yaml"), []byte(complianceExampleYAML), 0644)
+
+	frameworks, err := LoadCompliance()
+	if err != nil {
+		t.Fatalf("LoadCompliance() error: %v", err)
+	}
+
+	acme := frameworks[indexFramework(frameworks, "acme")]
+	if acme.Name != "ACME Standard" || len(acme.Controls) != 1 {
+		t.Errorf("acme = %+v", acme)
+	}
+
+	pci := frameworks[indexFramework(frameworks, "pci-dss-4")]
+	if pci.Name != "PCI DSS 4.0 Requirement 6" {
+		t.Errorf("name = %q, a user file without a name should keep the built-in one", pci.Name)
+	}
+	var replaced, appended bool
+	for _, c := range pci.Controls {
+		if c.ID == "6.2.3" {
+			replaced = c.Title == "Code is reviewed" && len(c.Rules) == 1
+		}
+		appended = appended || c.ID == "6.9.9"
+	}
+	if !replaced || !appended {
+		t.Errorf("controls = %+v, want 6.2.3 replaced and 6.9.9 appended", pci.Controls)
+	}
+}
+
+func TestLoadComplianceFromDir_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "bad.yaml"), []byte("frameworks:\n  - name: No ID\n"), 0644)
+
+	if _, err := LoadComplianceFromDir(dir); err == nil {
+		t.Error("expected an error for a framework without an id")
+	}
+}
@@ -15,12 +15,19 @@ type WardConfig struct {
 	Rules     RulesConfig     `yaml:"rules"`
 	AI        AIConfig        `yaml:"ai"`
 	Providers ProvidersConfig `yaml:"providers"`
+	Store     StoreConfig     `yaml:"store"`
+	Project   ProjectConfig   `yaml:"project"`
+	SLA       map[string]int  `yaml:"sla"` // severity → days to remediate, for ward history mttr
+	Triage    TriageConfig    `yaml:"triage"`
 }
 
 // OutputConfig controls report formats and destinations.
 type OutputConfig struct {
-	Formats []string `yaml:"formats"` // terminal, json, sarif, html, markdown
-	Dir     string   `yaml:"dir"`     // output directory for file reports
+	Formats      []string          `yaml:"formats"`       // terminal, json, sarif, html, markdown, junit, gitlab-sast, gitlab-codequality, csv, jsonl, compliance, ndjson
+	Dir          string            `yaml:"dir"`           // output directory for file reports
+	Name         string            `yaml:"name"`          // file name template without extension, e.g. "{project}-{date}-{commit}"
+	Paths        map[string]string `yaml:"paths"`         // format → path template, relative to dir; "-" writes to stdout
+	ContextLines int               `yaml:"context_lines"` // source lines shown on each side of a finding; 0 shows only its line
 }
 
 // ScannersConfig controls which scanners are enabled.
@@ -56,13 +63,31 @@ type ProvidersConfig struct {
 	GitDepth int `yaml:"git_depth"` // shallow clone depth, 0 = full
 }
 
+// StoreConfig controls how long scans are kept in ~/.ward/store.
+type StoreConfig struct {
+	KeepLast int `yaml:"keep_last"` // scans kept per project, 0 = all
+	KeepDays int `yaml:"keep_days"` // scans older than this many days are deleted, 0 = never
+}
+
+// ProjectConfig describes the project itself; it belongs in .ward.yaml.
+type ProjectConfig struct {
+	ID string `yaml:"id"` // identity in the scan history; defaults to the git remote, composer name or path
+}
+
+// TriageConfig controls the triage actions of the results view.
+type TriageConfig struct {
+	Baseline string `yaml:"baseline"` // project baseline triage writes to and scans load, relative to the project root
+	Editor   string `yaml:"editor"`   // "vscode", "phpstorm", or a URL or command template with {file} and {line}; empty uses $EDITOR
+}
+
 // Default returns the default configuration.
 func Default() *WardConfig {
 	return &WardConfig{
 		Severity: "info",
 		Output: OutputConfig{
-			Formats: []string{"json", "sarif", "html", "markdown"},
-			Dir:     ".",
+			Formats:      []string{"json", "sarif", "html", "markdown"},
+			Dir:          ".",
+			ContextLines: 3,
 		},
 		Scanners: ScannersConfig{},
 		Rules:    RulesConfig{},
@@ -74,32 +99,18 @@ func Default() *WardConfig {
 		Providers: ProvidersConfig{
 			GitDepth: 1,
 		},
+		Triage: TriageConfig{
+			Baseline: ".ward-baseline.json",
+		},
 	}
 }
 
-// Load reads the config from ~/.ward/config.yaml.
-// If the file doesn't exist it returns the defaults.
+// Load reads the config from ~/.ward/config.yaml merged over the defaults.
+// If the file doesn't exist it returns the defaults. Use LoadProject to
+// also apply a project's .ward.yaml.
 func Load() (*WardConfig, error) {
-	cfg := Default()
-
-	path, err := FilePath("config.yaml")
-	if err != nil {
-		return cfg, nil
-	}
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return cfg, nil
-		}
-		return nil, fmt.Errorf("reading config: %w", err)
-	}
-
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return nil, fmt.Errorf("parsing config %s: %w", path, err)
-	}
-
-	return cfg, nil
+	cfg, _, err := loadUser()
+	return cfg, err
 }
 
 // Save writes the config to ~/.ward/config.yaml.
@@ -50,6 +50,7 @@ func EnsureDir() error {
 	dirs := []string{
 		dir,
 		filepath.Join(dir, "rules"),
+		filepath.Join(dir, "compliance"),
 		filepath.Join(dir, "reports"),
 		filepath.Join(dir, "store"),
 	}
@@ -68,6 +69,11 @@ func RulesDir() (string, error) {
 	return SubDir("rules")
 }
 
+// ComplianceDir returns the path to ~/.ward/compliance.
+func ComplianceDir() (string, error) {
+	return SubDir("compliance")
+}
+
 // ReportsDir returns the path to ~/.ward/reports.
 func ReportsDir() (string, error) {
 	return SubDir("reports")
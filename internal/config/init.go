@@ -10,7 +10,14 @@ import (
 //go:embed defaults/rules/*.yaml
 var defaultRulesFS embed.FS
 
-const defaultConfigYAML = `# Ward configuration
+// Schema is the JSON Schema for config.yaml and .ward.yaml, for editor
+// completion and validation.
+//
+//go:embed schema.json
+var Schema []byte
+
+const defaultConfigYAML = `# yaml-language-server: $schema=config.schema.json
+# Ward configuration
 # https://github.com/eljakani/ward
 
 # Minimum severity to report: info, low, medium, high, critical
@@ -23,6 +30,10 @@ output:
     - html
     - markdown
   dir: .
+  # name: "{project}-{date}-{commit}"   # file name template (no extension)
+  # paths:                              # per-format destinations; "-" is stdout
+  #   sarif: reports/{project}.sarif
+  context_lines: 3                      # source lines shown around each finding
 
 scanners:
   # enable: []   # if empty, all scanners run
@@ -43,6 +54,60 @@ ai:
 
 providers:
   git_depth: 1
+
+store:
+  keep_last: 0   # scans kept per project, 0 = all
+  keep_days: 0   # delete scans older than this many days, 0 = never
+
+triage:
+  baseline: .ward-baseline.json   # where TUI triage is saved; loaded by every scan
+  # editor: vscode                # or phpstorm, or a template with {file} and {line}
+
+# Days to remediate findings by severity; ward history mttr lists breaches
+# sla:
+#   critical: 7
+#   high: 30
+#   medium: 90
+`
+
+const complianceExampleYAML = `# Custom Compliance Mappings — Example Template
+#
+# Ward maps findings to OWASP Top 10 2021, OWASP ASVS 4.0.3, CWE Top 25
+# 2024 and PCI DSS 4.0 Requirement 6 out of the box (-o compliance). All
+# .yaml files in ~/.ward/compliance/ are merged over those mappings:
+#
+#   - a framework with a new id is added to the report
+#   - a framework with a built-in id (owasp-top10-2021, owasp-asvs-4,
+#     cwe-top25-2024, pci-dss-4) is extended: controls with a known id
+#     replace the built-in control, others are appended
+#
+# A rule, check or finding covers a control when it matches any of:
+#   rules:      rule or check IDs, a trailing * matches a prefix (ENV-*)
+#   tags:       rule tags (owasp-a03, secrets)
+#   cwes:       CWE numbers, from cwe-N tags and CWE reference links
+#   categories: finding categories (Injection)
+#   scanners:   scanners that cover the control whenever they run
+#
+# A control is "passed" when a matching check ran without findings,
+# "failed" when a matching finding was reported, and "not covered" when
+# nothing Ward ran matches it.
+#
+# frameworks:
+#   - id: acme-secure-coding
+#     name: ACME Secure Coding Standard
+#     controls:
+#       - id: SC-1
+#         title: No raw SQL built from request input
+#         cwes: [89]
+#       - id: SC-2
+#         title: Production config never enables debug mode
+#         rules: [ENV-002, CFG-001, DEBUG-*]
+#
+#   - id: pci-dss-4
+#     controls:
+#       - id: "6.5.6"
+#         title: Test data and test accounts are removed before production
+#         rules: [MY-001]
 `
 
 // Init creates the ~/.ward directory structure with default files.
@@ -66,6 +131,15 @@ func Init(force bool) (string, error) {
 		return "", fmt.Errorf("writing config.yaml: %w", err)
 	}
 
+	// The schema tracks the binary, so it's refreshed on every init.
+	schemaPath, err := FilePath("config.schema.json")
+	if err != nil {
+		return "", err
+	}
+	if err := writeIfMissing(schemaPath, string(Schema), true); err != nil {
+		return "", fmt.Errorf("writing config.schema.json: %w", err)
+	}
+
 	// Copy all embedded default rules to ~/.ward/rules/
 	rulesDir, err := RulesDir()
 	if err != nil {
@@ -93,6 +167,16 @@ func Init(force bool) (string, error) {
 		}
 	}
 
+	// The built-in compliance mappings are embedded; only a template for
+	// custom ones is written.
+	complianceDir, err := ComplianceDir()
+	if err != nil {
+		return "", err
+	}
+	if err := writeIfMissing(filepath.Join(complianceDir, "custom-example.yaml"), complianceExampleYAML, force); err != nil {
+		return "", fmt.Errorf("writing compliance example: %w", err)
+	}
+
 	return dir, nil
 }
 
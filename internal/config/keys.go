@@ -0,0 +1,297 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// resolveKey returns the Go type at dotted key in WardConfig, e.g.
+// "output.formats" or "rules.override.AUTH-001.severity".
+func resolveKey(key string) (reflect.Type, error) {
+	if key == "" {
+		return nil, fmt.Errorf("empty config key")
+	}
+	t := reflect.TypeOf(WardConfig{})
+	var path string
+	for _, part := range strings.Split(key, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			ft, ok := yamlFields(t)[part]
+			if !ok {
+				msg := fmt.Sprintf("unknown config key %q", join(path, part))
+				if s := Suggest(part, fieldNames(t)); s != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", join(path, s))
+				}
+				return nil, fmt.Errorf("%s", msg)
+			}
+			t = ft
+		case reflect.Map:
+			t = t.Elem()
+		default:
+			return nil, fmt.Errorf("unknown config key %q: %s is not a section", key, path)
+		}
+		path = join(path, part)
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t, nil
+}
+
+// Get returns the value of the dotted key in cfg. Scalars are returned
+// as-is, lists comma-separated (the form Set accepts) and sections as
+// YAML. Keys that are valid but unset return "".
+func Get(cfg *WardConfig, key string) (string, error) {
+	if _, err := resolveKey(key); err != nil {
+		return "", err
+	}
+
+	var n yaml.Node
+	if err := n.Encode(cfg); err != nil {
+		return "", fmt.Errorf("encoding config: %w", err)
+	}
+
+	cur := &n
+	for _, part := range strings.Split(key, ".") {
+		next := mappingValue(cur, part)
+		if next == nil {
+			return "", nil
+		}
+		cur = next
+	}
+
+	switch cur.Kind {
+	case yaml.ScalarNode:
+		return cur.Value, nil
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(cur.Content))
+		for _, item := range cur.Content {
+			if item.Kind != yaml.ScalarNode {
+				return marshalNode(cur)
+			}
+			items = append(items, item.Value)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return marshalNode(cur)
+	}
+}
+
+// SetInFile sets the dotted key to value in the YAML config at path,
+// creating the file and any missing sections. Comments and the order of
+// existing keys are kept. Lists take a comma-separated value. The result
+// is validated before it's written.
+func SetInFile(path, key, value string) error {
+	t, err := resolveKey(key)
+	if err != nil {
+		return err
+	}
+	valNode, err := scalarFor(t, key, value)
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("parsing config %s: %w", path, err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	cur, curKey := doc.Content[0], (*yaml.Node)(nil)
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		if cur.Kind != yaml.MappingNode {
+			return fmt.Errorf("cannot set %s: %s is not a mapping in %s", key, strings.Join(parts[:i], "."), path)
+		}
+		// Appending to "{}" would otherwise render as a flow mapping, and
+		// its trailing comment would drift below the new block.
+		if cur.Style == yaml.FlowStyle {
+			cur.Style = 0
+			if curKey != nil && curKey.LineComment == "" {
+				curKey.LineComment, cur.LineComment = cur.LineComment, ""
+			}
+		}
+
+		last := i == len(parts)-1
+		k, next := mappingEntry(cur, part)
+		switch {
+		case next == nil && last:
+			cur.Content = append(cur.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: part}, valNode)
+		case next == nil:
+			k, next = &yaml.Node{Kind: yaml.ScalarNode, Value: part}, &yaml.Node{Kind: yaml.MappingNode}
+			cur.Content = append(cur.Content, k, next)
+		case last:
+			valNode.LineComment = next.LineComment
+			*next = *valNode
+		}
+		cur, curKey = next, k
+	}
+
+	if err := validateRoot(doc.Content[0], path); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+// UnsetInFile removes the dotted key from the YAML config at path, so the
+// value falls back to the layer below. Comments on other keys are kept.
+// A key the file doesn't set, or a missing file, is not an error.
+func UnsetInFile(path, key string) error {
+	if _, err := resolveKey(key); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 || !unsetKey(doc.Content[0], strings.Split(key, "."), 0) {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	return nil
+}
+
+// unsetKey removes the key at parts from mapping n, then the entries it
+// leaves empty below the second level: a rule ID under rules.override
+// goes, while rules.override itself stays with its comments. It reports
+// whether anything was removed.
+func unsetKey(n *yaml.Node, parts []string, depth int) bool {
+	if n.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != parts[0] {
+			continue
+		}
+		value := n.Content[i+1]
+		if len(parts) > 1 {
+			if !unsetKey(value, parts[1:], depth+1) {
+				return false
+			}
+			if len(value.Content) > 0 {
+				return true
+			}
+			if depth < 2 {
+				// Render as "{}", keeping the section's comment beside it.
+				value.Style = yaml.FlowStyle
+				value.LineComment, n.Content[i].LineComment = n.Content[i].LineComment, ""
+				return true
+			}
+		}
+		n.Content = append(n.Content[:i], n.Content[i+2:]...)
+		return true
+	}
+	return false
+}
+
+// scalarFor builds the YAML node for value given the key's Go type.
+func scalarFor(t reflect.Type, key, value string) (*yaml.Node, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: expected true or false, got %q", key, value)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(b)}, nil
+	case reflect.Int:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: expected an integer, got %q", key, value)
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(i)}, nil
+	case reflect.Slice:
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item})
+			}
+		}
+		return seq, nil
+	default:
+		return nil, fmt.Errorf("cannot set %s: it is a section, set one of its keys instead", key)
+	}
+}
+
+// mappingValue returns the value node for key in mapping n, or nil.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	_, v := mappingEntry(n, key)
+	return v
+}
+
+// mappingEntry returns the key and value nodes for key in mapping n.
+func mappingEntry(n *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if n.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i], n.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func marshalNode(n *yaml.Node) (string, error) {
+	out, err := yaml.Marshal(n)
+	if err != nil {
+		return "", fmt.Errorf("encoding config: %w", err)
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
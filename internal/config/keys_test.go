@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	cfg := Default()
+	cfg.Rules.Override = map[string]RuleOverride{"AUTH-001": {Severity: "low"}}
+
+	tests := []struct {
+		key, want string
+	}{
+		{"severity", "info"},
+		{"output.formats", "json,sarif,html,markdown"},
+		{"providers.git_depth", "1"},
+		{"rules.override.AUTH-001.severity", "low"},
+		{"rules.override.XSS-001.severity", ""},
+		{"ai.endpoint", ""},
+	}
+	for _, tt := range tests {
+		got, err := Get(cfg, tt.key)
+		if err != nil {
+			t.Errorf("Get(%q) error = %v", tt.key, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Get(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+
+	if _, err := Get(cfg, "output.formts"); err == nil || !strings.Contains(err.Error(), `did you mean "output.formats"`) {
+		t.Errorf("Get(unknown key) error = %v, want suggestion", err)
+	}
+}
+
+func TestSetInFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte(`# Ward configuration
+severity: info # minimum severity
+
+rules:
+  disable: []
+  override: {}   # rule ID -> {severity, enabled}
+`), 0644)
+
+	sets := [][2]string{
+		{"severity", "high"},
+		{"output.formats", "json, sarif"},
+		{"rules.override.AUTH-001.enabled", "false"},
+		{"providers.git_depth", "0"},
+	}
+	for _, s := range sets {
+		if err := SetInFile(path, s[0], s[1]); err != nil {
+			t.Fatalf("SetInFile(%s) error = %v", s[0], err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	got := string(data)
+	for _, want := range []string{
+		"# Ward configuration\n",
+		"severity: high # minimum severity\n",
+		"  override: # rule ID -> {severity, enabled}\n    AUTH-001:\n      enabled: false\n",
+		"output:\n  formats: [json, sarif]\n",
+		"providers:\n  git_depth: 0\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config missing %q:\n%s", want, got)
+		}
+	}
+	if err := ValidateFile(path, data); err != nil {
+		t.Errorf("written config does not validate: %v", err)
+	}
+}
+
+func TestUnsetInFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte(`severity: high # minimum severity
+rules:
+  override: # rule ID -> {severity, enabled}
+    AUTH-001:
+      severity: low
+      enabled: false
+    XSS-001:
+      severity: info
+`), 0644)
+
+	for _, key := range []string{"rules.override.AUTH-001.enabled", "rules.override.XSS-001.severity", "severity", "rules.override.NONE-001.severity"} {
+		if err := UnsetInFile(path, key); err != nil {
+			t.Fatalf("UnsetInFile(%s) error = %v", key, err)
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	want := "rules:\n  override: # rule ID -> {severity, enabled}\n    AUTH-001:\n      severity: low\n"
+	if string(data) != want {
+		t.Errorf("config =\n%s\nwant\n%s", data, want)
+	}
+
+	if err := UnsetInFile(path, "rules.override.AUTH-001.severity"); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "override: {} # rule ID") {
+		t.Errorf("emptied override section should stay with its comment:\n%s", data)
+	}
+
+	if err := UnsetInFile(filepath.Join(t.TempDir(), "missing.yaml"), "severity"); err != nil {
+		t.Errorf("UnsetInFile(missing file) error = %v", err)
+	}
+	if err := UnsetInFile(path, "serverity"); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+}
+
+func TestSetInFile_Rejects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	tests := []struct {
+		key, value, want string
+	}{
+		{"serverity", "high", `did you mean "severity"`},
+		{"severity", "hgh", `unknown value "hgh" (did you mean "high"?)`},
+		{"providers.git_depth", "deep", "expected an integer"},
+		{"rules.override", "x", "it is a section"},
+	}
+	for _, tt := range tests {
+		err := SetInFile(path, tt.key, tt.value)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("SetInFile(%s, %s) error = %v, want %q", tt.key, tt.value, err, tt.want)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("rejected values should not create the file")
+	}
+}
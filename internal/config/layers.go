@@ -0,0 +1,269 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SourceDefault is the origin of values nobody overrode.
+const SourceDefault = "default"
+
+// ProjectConfigNames are the file names looked up in a project root,
+// in order of preference.
+var ProjectConfigNames = []string{".ward.yaml", "ward.yaml"}
+
+// Origins records which layer set each config value, keyed by dotted
+// YAML path (e.g. "output.formats", "rules.override.AUTH-001.severity").
+type Origins map[string]string
+
+// Set records that path was set by source.
+func (o Origins) Set(path, source string) {
+	o[path] = source
+}
+
+// Lookup returns the source of path, falling back to its nearest
+// ancestor and finally to SourceDefault.
+func (o Origins) Lookup(path string) string {
+	for p := path; p != ""; {
+		if src, ok := o[p]; ok {
+			return src
+		}
+		i := strings.LastIndex(p, ".")
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+	return SourceDefault
+}
+
+// ProjectFile returns the path of the project config in root, or "" if
+// the project has none.
+func ProjectFile(root string) string {
+	for _, name := range ProjectConfigNames {
+		path := filepath.Join(root, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// LoadProject merges the configuration layers for a project: defaults,
+// then ~/.ward/config.yaml, then .ward.yaml (or ward.yaml) in root.
+// Scalars and lists from later layers replace earlier ones; maps are
+// merged key by key. Relative custom_dirs in the project file resolve
+// against root. CLI flags are applied by the caller on top, recording
+// their origin with Origins.Set.
+func LoadProject(root string) (*WardConfig, Origins, error) {
+	cfg, origins, err := loadUser()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if root == "" {
+		return cfg, origins, nil
+	}
+
+	path := ProjectFile(root)
+	if path == "" {
+		return cfg, origins, nil
+	}
+
+	set, err := mergeFile(cfg, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range set {
+		origins.Set(p, path)
+	}
+	resolveCustomDirs(cfg, origins, root, path)
+
+	return cfg, origins, nil
+}
+
+// cloneIgnored are the keys a cloned repository's config can't set: they
+// write to, or send data from, the machine running the scan, or would let
+// the repository hide its own findings.
+var cloneIgnored = []string{
+	"output.dir", "output.name", "output.paths", "store", "triage", "ai",
+	"severity", "rules.disable", "rules.override", "scanners.enable", "scanners.disable",
+}
+
+// MergeClone applies the project config of a remote repository cloned
+// into root on top of cfg, the way LoadProject applies a local one.
+// Values origins attributes to a CLI flag are kept, and so are the keys
+// in cloneIgnored; the ones the file set are returned. path is the
+// project file, or "" if the clone has none.
+func MergeClone(cfg *WardConfig, origins Origins, root string) (path string, ignored []string, err error) {
+	path = ProjectFile(root)
+	if path == "" {
+		return "", nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading config: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return path, nil, nil
+	}
+	if err := validateRoot(doc.Content[0], path); err != nil {
+		return "", nil, err
+	}
+
+	for _, key := range cloneIgnored {
+		if unsetKey(doc.Content[0], strings.Split(key, "."), 0) {
+			ignored = append(ignored, key)
+		}
+	}
+	for key, src := range origins {
+		if strings.HasPrefix(src, "flag ") {
+			unsetKey(doc.Content[0], strings.Split(key, "."), 0)
+		}
+	}
+
+	if err := doc.Decode(cfg); err != nil {
+		return "", nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	var set []string
+	collectPaths(doc.Content[0], "", &set)
+	for _, p := range set {
+		origins.Set(p, path)
+	}
+	resolveCustomDirs(cfg, origins, root, path)
+
+	return path, ignored, nil
+}
+
+// resolveCustomDirs resolves relative rules.custom_dirs against root when
+// the project file at path set them.
+func resolveCustomDirs(cfg *WardConfig, origins Origins, root, path string) {
+	if origins.Lookup("rules.custom_dirs") != path {
+		return
+	}
+	for i, dir := range cfg.Rules.CustomDirs {
+		if !filepath.IsAbs(dir) {
+			cfg.Rules.CustomDirs[i] = filepath.Join(root, dir)
+		}
+	}
+}
+
+// loadUser merges the defaults with ~/.ward/config.yaml.
+func loadUser() (*WardConfig, Origins, error) {
+	cfg := Default()
+	origins := make(Origins)
+
+	path, err := FilePath("config.yaml")
+	if err != nil {
+		return cfg, origins, nil
+	}
+
+	set, err := mergeFile(cfg, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range set {
+		origins.Set(p, path)
+	}
+
+	return cfg, origins, nil
+}
+
+// mergeFile validates the YAML file at path, decodes it on top of cfg and
+// returns the dotted paths of every value it set. A missing file sets
+// nothing.
+func mergeFile(cfg *WardConfig, path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	if err := validateRoot(doc.Content[0], path); err != nil {
+		return nil, err
+	}
+	if err := doc.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	var paths []string
+	collectPaths(doc.Content[0], "", &paths)
+	return paths, nil
+}
+
+// collectPaths appends the dotted path of every leaf under n. Lists
+// count as leaves because they replace rather than merge.
+func collectPaths(n *yaml.Node, prefix string, paths *[]string) {
+	if n.Kind != yaml.MappingNode {
+		if prefix != "" {
+			*paths = append(*paths, prefix)
+		}
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key := n.Content[i].Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		collectPaths(n.Content[i+1], key, paths)
+	}
+}
+
+// Annotate renders cfg as YAML with each value's origin as a trailing comment.
+func Annotate(cfg *WardConfig, origins Origins) (string, error) {
+	var root yaml.Node
+	if err := root.Encode(cfg); err != nil {
+		return "", fmt.Errorf("encoding config: %w", err)
+	}
+	annotate(&root, "", origins)
+
+	out, err := yaml.Marshal(&root)
+	if err != nil {
+		return "", fmt.Errorf("encoding config: %w", err)
+	}
+	return string(out), nil
+}
+
+func annotate(n *yaml.Node, prefix string, origins Origins) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		keyNode, val := n.Content[i], n.Content[i+1]
+		path := keyNode.Value
+		if prefix != "" {
+			path = prefix + "." + keyNode.Value
+		}
+
+		if val.Kind == yaml.MappingNode && len(val.Content) > 0 {
+			annotate(val, path, origins)
+			continue
+		}
+
+		comment := "# " + origins.Lookup(path)
+		if val.Kind == yaml.SequenceNode && len(val.Content) > 0 {
+			keyNode.LineComment = comment
+		} else {
+			val.LineComment = comment
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadProject_MergeOrder(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	os.MkdirAll(filepath.Join(home, ".ward"), 0755)
+	userPath := filepath.Join(home, ".ward", "config.yaml")
+	os.WriteFile(userPath, []byte(`severity: low
+output:
+  formats: [json, html]
+rules:
+  disable: [DEBUG-001]
+  override:
+    AUTH-001:
+      severity: medium
+`), 0644)
+
+	root := t.TempDir()
+	projectPath := filepath.Join(root, ".ward.yaml")
+	os.WriteFile(projectPath, []byte(`severity: high
+rules:
+  disable: [CRYPTO-001]
+  override:
+    XSS-001:
+      severity: low
+  custom_dirs: [ward-rules, /abs/rules]
+`), 0644)
+
+	cfg, origins, err := LoadProject(root)
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+
+	if cfg.Severity != "high" {
+		t.Errorf("severity = %q, want project value %q", cfg.Severity, "high")
+	}
+	if len(cfg.Output.Formats) != 2 || cfg.Output.Formats[1] != "html" {
+		t.Errorf("output.formats = %v, want user value [json html]", cfg.Output.Formats)
+	}
+	if len(cfg.Rules.Disable) != 1 || cfg.Rules.Disable[0] != "CRYPTO-001" {
+		t.Errorf("rules.disable = %v, want project list to replace user list", cfg.Rules.Disable)
+	}
+	if cfg.Rules.Override["AUTH-001"].Severity != "medium" || cfg.Rules.Override["XSS-001"].Severity != "low" {
+		t.Errorf("rules.override = %v, want user and project entries merged", cfg.Rules.Override)
+	}
+	if cfg.Rules.CustomDirs[0] != filepath.Join(root, "ward-rules") || cfg.Rules.CustomDirs[1] != "/abs/rules" {
+		t.Errorf("custom_dirs = %v, want relative dirs resolved against the project root", cfg.Rules.CustomDirs)
+	}
+
+	tests := map[string]string{
+		"severity":                         projectPath,
+		"output.formats":                   userPath,
+		"output.dir":                       SourceDefault,
+		"rules.override.AUTH-001.severity": userPath,
+		"rules.override.XSS-001.severity":  projectPath,
+	}
+	for path, want := range tests {
+		if got := origins.Lookup(path); got != want {
+			t.Errorf("origin of %s = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestLoadProject_NoProjectFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, origins, err := LoadProject(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadProject() error = %v", err)
+	}
+	if cfg.Severity != Default().Severity {
+		t.Errorf("severity = %q, want default", cfg.Severity)
+	}
+	if got := origins.Lookup("severity"); got != SourceDefault {
+		t.Errorf("origin = %q, want %q", got, SourceDefault)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	cfg := Default()
+	cfg.Severity = "high"
+	origins := Origins{"severity": "/p/.ward.yaml", "output.formats": "flag --output"}
+
+	out, err := Annotate(cfg, origins)
+	if err != nil {
+		t.Fatalf("Annotate() error = %v", err)
+	}
+	for _, want := range []string{
+		"severity: high # /p/.ward.yaml",
+		"formats: # flag --output",
+		"dir: . # default",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("annotated config missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMergeClone(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg, origins, err := LoadProject("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Output.Formats = []string{"sarif"}
+	origins.Set("output.formats", "flag --output")
+
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, ".ward.yaml"), []byte(`severity: high
+project:
+  id: example/app
+output:
+  formats: [html]
+  dir: /etc
+  paths:
+    json: ~/.bashrc
+store:
+  keep_days: 1
+rules:
+  custom_dirs: [ward-rules]
+  disable: [ENV-001]
+  override:
+    CFG-001: {enabled: false}
+scanners:
+  disable: [env-scanner]
+`), 0644)
+
+	path, ignored, err := MergeClone(cfg, origins, root)
+	if err != nil {
+		t.Fatalf("MergeClone() error = %v", err)
+	}
+	if path != filepath.Join(root, ".ward.yaml") {
+		t.Errorf("path = %q", path)
+	}
+	if cfg.Project.ID != "example/app" {
+		t.Errorf("project.id = %q, want the clone's value", cfg.Project.ID)
+	}
+	if cfg.Rules.CustomDirs[0] != filepath.Join(root, "ward-rules") {
+		t.Errorf("custom_dirs = %v, want them resolved against the clone", cfg.Rules.CustomDirs)
+	}
+	if len(cfg.Output.Formats) != 1 || cfg.Output.Formats[0] != "sarif" {
+		t.Errorf("output.formats = %v, want the flag value kept", cfg.Output.Formats)
+	}
+	if cfg.Output.Dir == "/etc" || cfg.Output.Paths["json"] != "" || cfg.Store.KeepDays == 1 {
+		t.Errorf("clone set local paths: output = %+v, store = %+v", cfg.Output, cfg.Store)
+	}
+	if cfg.Severity == "high" || len(cfg.Rules.Disable) > 0 || len(cfg.Rules.Override) > 0 || len(cfg.Scanners.Disable) > 0 {
+		t.Errorf("clone hid findings: severity = %q, rules = %+v, scanners = %+v", cfg.Severity, cfg.Rules, cfg.Scanners)
+	}
+	if strings.Join(ignored, ",") != "output.dir,output.paths,store,severity,rules.disable,rules.override,scanners.disable" {
+		t.Errorf("ignored = %v", ignored)
+	}
+	if origins.Lookup("project.id") != path || origins.Lookup("output.formats") != "flag --output" {
+		t.Errorf("origins = %v", origins)
+	}
+
+	if path, _, err := MergeClone(cfg, origins, t.TempDir()); path != "" || err != nil {
+		t.Errorf("clone without a config: path = %q, err = %v", path, err)
+	}
+}
@@ -4,32 +4,80 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
 
 // RuleDefinition is a single rule as written in a YAML file.
 type RuleDefinition struct {
-	ID          string       `yaml:"id"`
-	Title       string       `yaml:"title"`
-	Description string       `yaml:"description"`
-	Severity    string       `yaml:"severity"` // critical, high, medium, low, info
-	Category    string       `yaml:"category"`
-	Enabled     bool         `yaml:"enabled"`
-	Tags        []string     `yaml:"tags,omitempty"`
-	Patterns    []PatternDef `yaml:"patterns,omitempty"`
-	Remediation string       `yaml:"remediation,omitempty"`
-	References  []string     `yaml:"references,omitempty"`
+	ID          string         `yaml:"id"`
+	Title       string         `yaml:"title"`
+	Description string         `yaml:"description"`
+	Severity    string         `yaml:"severity"` // critical, high, medium, low, info
+	Category    string         `yaml:"category"`
+	Enabled     bool           `yaml:"enabled"`
+	Tags        []string       `yaml:"tags,omitempty"`
+	When        *RuleCondition `yaml:"when,omitempty"` // project conditions; rule is skipped if they don't hold
+	Patterns    []PatternDef   `yaml:"patterns,omitempty"`
+	Match       *MatchExpr     `yaml:"match,omitempty"` // boolean composition of patterns
+	Remediation string         `yaml:"remediation,omitempty"`
+	Fix         *FixDef        `yaml:"fix,omitempty"` // mechanical replacement offered by `ward fix`
+	References  []string       `yaml:"references,omitempty"`
+
+	Source string `yaml:"-"` // file the rule was loaded from
+}
+
+// RuleCondition restricts a rule to projects whose context matches.
+// Every field that is set must hold for the rule to run.
+type RuleCondition struct {
+	Laravel     string             `yaml:"laravel,omitempty"`      // version constraint, e.g. ">=10 <11", "^11.0"
+	PHP         string             `yaml:"php,omitempty"`          // version constraint, e.g. ">=8.1"
+	Packages    []PackageCondition `yaml:"packages,omitempty"`     // composer packages that must be present or absent
+	Env         map[string]string  `yaml:"env,omitempty"`          // .env key → expected value ("*" = any non-empty, "!x" = not x)
+	FileExists  []string           `yaml:"file_exists,omitempty"`  // globs (relative to project root) that must match
+	FileMissing []string           `yaml:"file_missing,omitempty"` // globs that must not match
+	Any         []RuleCondition    `yaml:"any,omitempty"`          // alternatives; at least one must hold
+}
+
+// PackageCondition checks an installed composer package.
+type PackageCondition struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version,omitempty"` // constraint against the installed version
+	Absent  bool   `yaml:"absent,omitempty"`  // true = condition holds only if the package is NOT installed
 }
 
 // PatternDef describes a single pattern check within a rule.
 type PatternDef struct {
-	Type           string `yaml:"type"`   // regex, contains, file-exists, regex-scoped
-	Target         string `yaml:"target"` // php-files, blade-files, config-files, env-files
-	Pattern        string `yaml:"pattern"`
-	Negative       bool   `yaml:"negative"`        // true = finding if pattern is ABSENT
-	ExcludePattern string `yaml:"exclude_pattern"` // if line also matches this, skip it (reduce false positives)
-	ScopeExclude   string `yaml:"scope_exclude"`   // regex-scoped: lines matching this open a protected brace scope
+	Type           string `yaml:"type,omitempty"`   // regex, contains, file-exists, regex-scoped
+	Target         string `yaml:"target,omitempty"` // php-files, blade-files, config-files, env-files
+	Pattern        string `yaml:"pattern,omitempty"`
+	Negative       bool   `yaml:"negative,omitempty"`        // true = finding if pattern is ABSENT
+	ExcludePattern string `yaml:"exclude_pattern,omitempty"` // if line also matches this, skip it (reduce false positives)
+	ScopeExclude   string `yaml:"scope_exclude,omitempty"`   // regex-scoped: lines matching this open a protected brace scope
+}
+
+// FixDef describes a replacement applied to the line of each finding.
+// Lines that don't match Find get no fix.
+type FixDef struct {
+	Find        string `yaml:"find"`    // regex matched against the finding's line
+	Replace     string `yaml:"replace"` // replacement; may reference capture groups ($1)
+	Description string `yaml:"description,omitempty"`
+}
+
+// MatchExpr is a node in a composite rule. A node is either a leaf pattern
+// (Type is set) or an all/any/not combinator; Inside narrows the node's
+// matches to those falling within the regions matched by another expression.
+type MatchExpr struct {
+	PatternDef `yaml:",inline"`
+
+	All    []MatchExpr `yaml:"all,omitempty"`
+	Any    []MatchExpr `yaml:"any,omitempty"`
+	Not    *MatchExpr  `yaml:"not,omitempty"`
+	Inside *MatchExpr  `yaml:"inside,omitempty"`
+
+	Within int    `yaml:"within,omitempty"` // as an inside: region, N lines around each match instead of its brace scope
+	Scope  string `yaml:"scope,omitempty"`  // root only: file (default) evaluates per file, project across all files
 }
 
 // RuleFile is the top-level structure of a rules YAML file.
@@ -48,6 +96,9 @@ func LoadRulesFromFile(path string) ([]RuleDefinition, error) {
 	if err := yaml.Unmarshal(data, &rf); err != nil {
 		return nil, fmt.Errorf("parsing rules file %s: %w", path, err)
 	}
+	for i := range rf.Rules {
+		rf.Rules[i].Source = path
+	}
 
 	return rf.Rules, nil
 }
@@ -83,8 +134,19 @@ func LoadRulesFromDir(dir string) ([]RuleDefinition, error) {
 }
 
 // LoadAllRules loads rules from ~/.ward/rules plus any extra directories
-// specified in the config.
+// specified in the config, with the config's overrides applied. Rules the
+// config disables are left out.
 func LoadAllRules(cfg *WardConfig) ([]RuleDefinition, error) {
+	all, err := LoadRuleCatalog(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return applyOverrides(all, cfg.Rules), nil
+}
+
+// LoadRuleCatalog loads every rule from ~/.ward/rules and the config's
+// custom_dirs as written in its file, without the config's overrides.
+func LoadRuleCatalog(cfg *WardConfig) ([]RuleDefinition, error) {
 	var all []RuleDefinition
 
 	// Load from ~/.ward/rules
@@ -107,34 +169,36 @@ func LoadAllRules(cfg *WardConfig) ([]RuleDefinition, error) {
 		all = append(all, rules...)
 	}
 
-	// Apply overrides from config
-	all = applyOverrides(all, cfg.Rules)
-
 	return all, nil
 }
 
-func applyOverrides(rules []RuleDefinition, rc RulesConfig) []RuleDefinition {
-	disabled := make(map[string]bool, len(rc.Disable))
-	for _, id := range rc.Disable {
-		disabled[id] = true
+// Effective returns r as the config runs it: with an overridden severity,
+// and enabled as set by rules.disable, else rules.override.<id>.enabled,
+// else the rule's own file.
+func (rc RulesConfig) Effective(r RuleDefinition) RuleDefinition {
+	ov := rc.Override[r.ID]
+	if ov.Severity != "" {
+		r.Severity = ov.Severity
 	}
+	switch {
+	case slices.Contains(rc.Disable, r.ID):
+		r.Enabled = false
+	case ov.Enabled != nil:
+		r.Enabled = *ov.Enabled
+	}
+	return r
+}
 
+func applyOverrides(rules []RuleDefinition, rc RulesConfig) []RuleDefinition {
 	result := make([]RuleDefinition, 0, len(rules))
 	for _, r := range rules {
-		if disabled[r.ID] {
+		eff := rc.Effective(r)
+		// Rules disabled in their own file are kept for the scanner to
+		// skip; those the config turns off are dropped.
+		if r.Enabled && !eff.Enabled {
 			continue
 		}
-
-		if ov, ok := rc.Override[r.ID]; ok {
-			if ov.Severity != "" {
-				r.Severity = ov.Severity
-			}
-			if ov.Enabled != nil && !*ov.Enabled {
-				continue
-			}
-		}
-
-		result = append(result, r)
+		result = append(result, eff)
 	}
 
 	return result
@@ -48,6 +48,9 @@ func TestLoadRulesFromFile(t *testing.T) {
 	if rules[1].Enabled {
 		t.Error("rule[1] should be disabled")
 	}
+	if rules[0].Source != path {
+		t.Errorf("rule[0].Source = %q, want %q", rules[0].Source, path)
+	}
 }
 
 func TestLoadRulesFromDir(t *testing.T) {
@@ -118,3 +121,18 @@ func TestApplyOverrides(t *testing.T) {
 		t.Errorf("severity should be overridden to critical, got %q", result[0].Severity)
 	}
 }
+
+func TestEffective_EnablesRuleOffInItsFile(t *testing.T) {
+	trueVal := true
+	rc := RulesConfig{Override: map[string]RuleOverride{"A-001": {Enabled: &trueVal}}}
+
+	rules := applyOverrides([]RuleDefinition{{ID: "A-001"}, {ID: "A-002"}}, rc)
+	if len(rules) != 2 || !rules[0].Enabled || rules[1].Enabled {
+		t.Errorf("rules = %+v, want A-001 enabled by the override and A-002 kept disabled", rules)
+	}
+
+	rc.Disable = []string{"A-001"}
+	if rc.Effective(RuleDefinition{ID: "A-001"}).Enabled {
+		t.Error("rules.disable should win over an override")
+	}
+}
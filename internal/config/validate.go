@@ -0,0 +1,362 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Allowed values for enumerated config fields.
+var (
+	Severities    = []string{"info", "low", "medium", "high", "critical"}
+	OutputFormats = []string{"terminal", "json", "sarif", "html", "markdown", "md", "junit", "gitlab-sast", "gitlab-codequality", "csv", "jsonl", "compliance", "ndjson"}
+	ScannerNames  = []string{"env-scanner", "config-scanner", "dependency-scanner", "rules-scanner"}
+	AIProviders   = []string{"openai", "anthropic", "ollama"}
+
+	// FileFormats are the output formats written by a reporter, which
+	// output.paths can redirect.
+	FileFormats = []string{"json", "sarif", "html", "markdown", "md", "junit", "gitlab-sast", "gitlab-codequality", "csv", "jsonl", "compliance"}
+
+	// PathPlaceholders are the {name} variables output.name and
+	// output.paths templates may use.
+	PathPlaceholders = []string{"project", "date", "time", "commit", "format", "ext"}
+)
+
+// enums maps a dotted key pattern to its allowed values. "*" matches a
+// map key; list items are checked against their list's pattern.
+var enums = map[string][]string{
+	"severity":                  Severities,
+	"output.formats":            OutputFormats,
+	"scanners.enable":           ScannerNames,
+	"scanners.disable":          ScannerNames,
+	"rules.override.*.severity": Severities,
+	"ai.provider":               AIProviders,
+}
+
+// mapKeys restricts the keys of a map, by pattern.
+var mapKeys = map[string][]string{
+	"output.paths": FileFormats,
+	"sla":          Severities,
+}
+
+// templates lists the patterns of path templates, whose placeholders
+// must be known.
+var templates = map[string]bool{
+	"output.name":    true,
+	"output.paths.*": true,
+}
+
+var placeholderRe = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// Problem is a single validation failure. Line and Column are 1-based
+// and zero when the value didn't come from a file.
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Key     string
+	Message string
+}
+
+func (p Problem) String() string {
+	var loc string
+	switch {
+	case p.File != "" && p.Line > 0:
+		loc = fmt.Sprintf("%s:%d:%d: ", p.File, p.Line, p.Column)
+	case p.File != "":
+		loc = p.File + ": "
+	}
+	if p.Key != "" {
+		return loc + p.Key + ": " + p.Message
+	}
+	return loc + p.Message
+}
+
+// ValidationError lists every problem found in a config.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = p.String()
+	}
+	if len(lines) == 1 {
+		return "invalid config: " + lines[0]
+	}
+	return fmt.Sprintf("invalid config (%d problems):\n  %s", len(lines), strings.Join(lines, "\n  "))
+}
+
+// ValidateFile checks YAML config data read from path against the
+// WardConfig schema: unknown keys, wrong types and unknown enum values
+// are reported with their line numbers. It returns a *ValidationError,
+// or a plain error if the data isn't YAML at all.
+func ValidateFile(path string, data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	return validateRoot(doc.Content[0], path)
+}
+
+// Validate checks a config that has already been loaded, e.g. after CLI
+// flags were applied on top of the files.
+func (c *WardConfig) Validate() error {
+	var root yaml.Node
+	if err := root.Encode(c); err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	// Encoded nodes carry synthetic positions; drop them.
+	clearPositions(&root)
+	return validateRoot(&root, "")
+}
+
+func validateRoot(root *yaml.Node, file string) error {
+	v := validator{file: file}
+	v.check(root, reflect.TypeOf(WardConfig{}), "", "")
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+type validator struct {
+	file     string
+	problems []Problem
+}
+
+func (v *validator) add(n *yaml.Node, key, format string, args ...any) {
+	v.problems = append(v.problems, Problem{
+		File:    v.file,
+		Line:    n.Line,
+		Column:  n.Column,
+		Key:     key,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// check validates node n against Go type t. key is the dotted path for
+// messages and pattern the same path with map keys replaced by "*".
+func (v *validator) check(n *yaml.Node, t reflect.Type, key, pattern string) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	// An explicit null leaves the default in place.
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.Kind != yaml.MappingNode {
+			v.add(n, key, "expected a mapping, got %s", describe(n))
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, val := n.Content[i], n.Content[i+1]
+			ft, ok := fields[k.Value]
+			if !ok {
+				msg := fmt.Sprintf("unknown key %q", k.Value)
+				if s := Suggest(k.Value, fieldNames(t)); s != "" {
+					msg += fmt.Sprintf(" (did you mean %q?)", s)
+				}
+				v.add(k, key, "%s", msg)
+				continue
+			}
+			v.check(val, ft, join(key, k.Value), join(pattern, k.Value))
+		}
+
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			v.add(n, key, "expected a mapping, got %s", describe(n))
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k := n.Content[i].Value
+			if allowed, ok := mapKeys[pattern]; ok {
+				v.checkAllowed(n.Content[i], key, "key", allowed)
+			}
+			v.check(n.Content[i+1], t.Elem(), join(key, k), join(pattern, "*"))
+		}
+
+	case reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			v.add(n, key, "expected a list, got %s", describe(n))
+			return
+		}
+		for _, item := range n.Content {
+			v.check(item, t.Elem(), key, pattern)
+		}
+
+	case reflect.String:
+		if n.Kind != yaml.ScalarNode {
+			v.add(n, key, "expected a string, got %s", describe(n))
+			return
+		}
+		v.checkEnum(n, key, pattern)
+		if templates[pattern] {
+			v.checkTemplate(n, key)
+		}
+
+	case reflect.Bool:
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!bool" {
+			v.add(n, key, "expected true or false, got %s", describe(n))
+		}
+
+	case reflect.Int:
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!int" {
+			v.add(n, key, "expected an integer, got %s", describe(n))
+		}
+	}
+}
+
+func (v *validator) checkEnum(n *yaml.Node, key, pattern string) {
+	if allowed, ok := enums[pattern]; ok {
+		v.checkAllowed(n, key, "value", allowed)
+	}
+}
+
+// checkAllowed reports n unless its value is one of allowed. what names
+// the kind of value in the message ("value", "key").
+func (v *validator) checkAllowed(n *yaml.Node, key, what string, allowed []string) {
+	for _, a := range allowed {
+		if strings.EqualFold(n.Value, a) {
+			return
+		}
+	}
+	msg := fmt.Sprintf("unknown %s %q", what, n.Value)
+	if s := Suggest(n.Value, allowed); s != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", s)
+	} else {
+		msg += " (expected one of " + strings.Join(allowed, ", ") + ")"
+	}
+	v.add(n, key, "%s", msg)
+}
+
+// checkTemplate reports placeholders in a path template that aren't in
+// PathPlaceholders.
+func (v *validator) checkTemplate(n *yaml.Node, key string) {
+	for _, m := range placeholderRe.FindAllStringSubmatch(n.Value, -1) {
+		if !slices.Contains(PathPlaceholders, m[1]) {
+			msg := fmt.Sprintf("unknown placeholder %q", m[0])
+			if s := Suggest(m[1], PathPlaceholders); s != "" {
+				msg += fmt.Sprintf(" (did you mean \"{%s}\"?)", s)
+			} else {
+				msg += " (expected one of {" + strings.Join(PathPlaceholders, "}, {") + "})"
+			}
+			v.add(n, key, "%s", msg)
+		}
+	}
+}
+
+// yamlFields maps the YAML keys of struct type t to their field types.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if name := yamlName(f); name != "" {
+			fields[name] = f.Type
+		}
+	}
+	return fields
+}
+
+// fieldNames returns the YAML keys of struct type t in declaration order.
+func fieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		if name := yamlName(t.Field(i)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func yamlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "-" || !f.IsExported() {
+		return ""
+	}
+	if name == "" {
+		name = strings.ToLower(f.Name)
+	}
+	return name
+}
+
+func describe(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return fmt.Sprintf("%q", n.Value)
+	}
+}
+
+func join(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func clearPositions(n *yaml.Node) {
+	n.Line, n.Column = 0, 0
+	for _, c := range n.Content {
+		clearPositions(c)
+	}
+}
+
+// Suggest returns the option closest to s by edit distance, or "" if
+// none is close enough to be a plausible typo.
+func Suggest(s string, options []string) string {
+	s = strings.ToLower(s)
+	best, bestDist := "", -1
+	for _, o := range options {
+		d := levenshtein(s, strings.ToLower(o))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = o, d
+		}
+	}
+	limit := len(s) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	if bestDist < 0 || bestDist > limit {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
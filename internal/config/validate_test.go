@@ -0,0 +1,172 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateFile(t *testing.T) {
+	data := []byte(`serverity: high
+output:
+  formats: [json, sarfi]
+rules:
+  override:
+    AUTH-001:
+      severity: urgent
+      enabled: maybe
+providers:
+  git_depth: one
+`)
+
+	err := ValidateFile(".ward.yaml", data)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("ValidateFile() error = %v, want *ValidationError", err)
+	}
+
+	want := []string{
+		`.ward.yaml:1:1: unknown key "serverity" (did you mean "severity"?)`,
+		`.ward.yaml:3:19: output.formats: unknown value "sarfi" (did you mean "sarif"?)`,
+		`.ward.yaml:7:17: rules.override.AUTH-001.severity: unknown value "urgent" (expected one of info, low, medium, high, critical)`,
+		`.ward.yaml:8:16: rules.override.AUTH-001.enabled: expected true or false, got "maybe"`,
+		`.ward.yaml:10:14: providers.git_depth: expected an integer, got "one"`,
+	}
+	if len(verr.Problems) != len(want) {
+		t.Fatalf("got %d problems, want %d:\n%v", len(verr.Problems), len(want), err)
+	}
+	for i, w := range want {
+		if got := verr.Problems[i].String(); got != w {
+			t.Errorf("problem %d = %q, want %q", i, got, w)
+		}
+	}
+}
+
+func TestValidateFile_Valid(t *testing.T) {
+	if err := ValidateFile("config.yaml", []byte(defaultConfigYAML)); err != nil {
+		t.Errorf("default config should validate, got %v", err)
+	}
+	if err := ValidateFile("empty.yaml", nil); err != nil {
+		t.Errorf("empty config should validate, got %v", err)
+	}
+}
+
+func TestWardConfig_Validate(t *testing.T) {
+	cfg := Default()
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Default().Validate() = %v", err)
+	}
+
+	cfg.Output.Formats = []string{"json", "htlm"}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `output.formats: unknown value "htlm" (did you mean "html"?)`) {
+		t.Errorf("Validate() = %v, want unknown format error", err)
+	}
+}
+
+func TestValidateFile_OutputPaths(t *testing.T) {
+	data := []byte(`output:
+  name: "{project}-{dat}"
+  paths:
+    sarif: "-"
+    xlsx: out.xlsx
+    html: "reports/{branch}.html"
+`)
+
+	err := ValidateFile(".ward.yaml", data)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("ValidateFile() error = %v, want *ValidationError", err)
+	}
+
+	want := []string{
+		`.ward.yaml:2:9: output.name: unknown placeholder "{dat}" (did you mean "{date}"?)`,
+		`.ward.yaml:5:5: output.paths: unknown key "xlsx" (expected one of json, sarif, html, markdown, md, junit, gitlab-sast, gitlab-codequality, csv, jsonl, compliance)`,
+		`.ward.yaml:6:11: output.paths.html: unknown placeholder "{branch}" (expected one of {project}, {date}, {time}, {commit}, {format}, {ext})`,
+	}
+	if len(verr.Problems) != len(want) {
+		t.Fatalf("got %d problems, want %d:\n%v", len(verr.Problems), len(want), err)
+	}
+	for i, w := range want {
+		if got := verr.Problems[i].String(); got != w {
+			t.Errorf("problem %d = %q, want %q", i, got, w)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"serverity", "severity"},
+		{"custom_dir", "custom_dirs"},
+		{"JSON", "json"},
+		{"xml", ""},
+	}
+	options := []string{"severity", "custom_dirs", "json", "sarif"}
+	for _, tt := range tests {
+		if got := Suggest(tt.in, options); got != tt.want {
+			t.Errorf("Suggest(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// The JSON Schema is hand-written; make sure it keeps up with WardConfig.
+func TestSchemaMatchesConfig(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatalf("schema.json is not valid JSON: %v", err)
+	}
+
+	var walk func(node map[string]any, typ reflect.Type, path string)
+	walk = func(node map[string]any, typ reflect.Type, path string) {
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		switch typ.Kind() {
+		case reflect.Struct:
+			props, _ := node["properties"].(map[string]any)
+			for _, name := range fieldNames(typ) {
+				child, ok := props[name].(map[string]any)
+				if !ok {
+					t.Errorf("schema is missing %s", join(path, name))
+					continue
+				}
+				walk(child, yamlFields(typ)[name], join(path, name))
+			}
+			if len(props) != len(fieldNames(typ)) {
+				t.Errorf("schema for %q has %d properties, config has %d", path, len(props), len(fieldNames(typ)))
+			}
+		case reflect.Map:
+			child, ok := node["additionalProperties"].(map[string]any)
+			if !ok {
+				t.Errorf("schema for %s should describe its entries", path)
+				return
+			}
+			walk(child, typ.Elem(), join(path, "*"))
+		}
+	}
+	walk(schema, reflect.TypeOf(WardConfig{}), "")
+
+	formats := schema["properties"].(map[string]any)["output"].(map[string]any)["properties"].(map[string]any)["formats"].(map[string]any)["items"].(map[string]any)["enum"].([]any)
+	if len(formats) != len(OutputFormats) {
+		t.Errorf("schema lists %d output formats, OutputFormats has %d", len(formats), len(OutputFormats))
+	}
+	for i, f := range formats {
+		if i < len(OutputFormats) && f != OutputFormats[i] {
+			t.Errorf("schema format %d = %v, want %s", i, f, OutputFormats[i])
+		}
+	}
+
+	paths := schema["properties"].(map[string]any)["output"].(map[string]any)["properties"].(map[string]any)["paths"].(map[string]any)["propertyNames"].(map[string]any)["enum"].([]any)
+	if len(paths) != len(FileFormats) {
+		t.Errorf("schema lists %d output.paths keys, FileFormats has %d", len(paths), len(FileFormats))
+	}
+	for i, f := range paths {
+		if i < len(FileFormats) && f != FileFormats[i] {
+			t.Errorf("schema output.paths key %d = %v, want %s", i, f, FileFormats[i])
+		}
+	}
+}
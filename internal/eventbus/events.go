@@ -128,6 +128,9 @@ type ScannerSkippedData struct {
 
 type FindingDiscoveredData struct {
 	Finding models.Finding
+	// Filtered says why post-processing leaves the finding out of the
+	// report: "duplicate", "severity" or "baseline". Empty if reported.
+	Filtered string
 }
 
 type ContextResolvedData struct {
@@ -0,0 +1,214 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// NDJSONWriter serializes events to w as newline-delimited JSON, one
+// object per event:
+//
+//	{"type":"finding.discovered","timestamp":"…","data":{…}}
+//
+// Register Handle with SubscribeAll to stream a scan as it runs.
+type NDJSONWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewNDJSONWriter creates a writer that encodes events to w.
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &NDJSONWriter{enc: enc}
+}
+
+// Handle writes one event. After the first write error further events
+// are dropped; the error is available from Err.
+func (s *NDJSONWriter) Handle(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return
+	}
+	s.err = s.enc.Encode(wireEvent{
+		Type:      e.Type.String(),
+		Timestamp: e.Timestamp,
+		Data:      wireData(e.Data),
+	})
+}
+
+// Err returns the first error encountered while writing, if any.
+func (s *NDJSONWriter) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+type wireEvent struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data,omitempty"`
+}
+
+type wireFinding struct {
+	Fingerprint string   `json:"fingerprint"`
+	RuleID      string   `json:"rule_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Severity    string   `json:"severity"`
+	Category    string   `json:"category"`
+	Scanner     string   `json:"scanner"`
+	File        string   `json:"file,omitempty"`
+	Line        int      `json:"line,omitempty"`
+	CodeSnippet string   `json:"code_snippet,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+	References  []string `json:"references,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Fix         *wireFix `json:"fix,omitempty"`
+	Filtered    string   `json:"filtered,omitempty"`
+}
+
+// wireFix has the shape of the fix in JSON reports.
+type wireFix struct {
+	Description string `json:"description"`
+	Line        int    `json:"line"`
+	StartColumn int    `json:"start_column"`
+	EndColumn   int    `json:"end_column"`
+	Original    string `json:"original"`
+	Replacement string `json:"replacement"`
+}
+
+// wireSummary stands in for the full report on scan.completed; the
+// findings themselves were already streamed as finding.discovered.
+type wireSummary struct {
+	ProjectName   string            `json:"project_name"`
+	ProjectPath   string            `json:"project_path"`
+	StartedAt     time.Time         `json:"started_at"`
+	CompletedAt   time.Time         `json:"completed_at"`
+	DurationMS    int64             `json:"duration_ms"`
+	FindingCount  int               `json:"finding_count"`
+	BySeverity    map[string]int    `json:"by_severity"`
+	ScannersRun   []string          `json:"scanners_run"`
+	ScannerErrors map[string]string `json:"scanner_errors,omitempty"`
+}
+
+// wireData converts a payload struct into its JSON shape. The payloads
+// themselves carry Go types (errors, enums) that don't marshal usefully.
+func wireData(data any) any {
+	switch d := data.(type) {
+	case ScanStartedData:
+		return map[string]any{
+			"project_path":  d.ProjectPath,
+			"project_name":  d.ProjectName,
+			"scanner_count": d.ScannerCount,
+		}
+	case ScanCompletedData:
+		if d.Report == nil {
+			return nil
+		}
+		return summarize(d.Report)
+	case ScanFailedData:
+		return map[string]any{"error": errString(d.Error)}
+	case StageStartedData:
+		return map[string]any{"stage": d.Stage.String()}
+	case StageCompletedData:
+		return map[string]any{"stage": d.Stage.String()}
+	case ScannerRegisteredData:
+		return map[string]any{"name": d.Name, "description": d.Description}
+	case ScannerStartedData:
+		return map[string]any{"name": d.Name}
+	case ScannerCompletedData:
+		return map[string]any{"name": d.Name, "finding_count": d.FindingCount}
+	case ScannerFailedData:
+		return map[string]any{"name": d.Name, "error": errString(d.Error)}
+	case ScannerSkippedData:
+		return map[string]any{"name": d.Name, "reason": d.Reason}
+	case FindingDiscoveredData:
+		wf := toWireFinding(d.Finding)
+		wf.Filtered = d.Filtered
+		return wf
+	case ContextResolvedData:
+		return map[string]any{
+			"project_name":    d.ProjectName,
+			"laravel_version": d.LaravelVersion,
+			"php_version":     d.PHPVersion,
+			"framework_type":  d.FrameworkType,
+			"package_count":   d.PackageCount,
+		}
+	case ProgressUpdateData:
+		return map[string]any{"scanner": d.ScannerName, "message": d.Message, "percent": d.Percent}
+	case LogMessageData:
+		return map[string]any{"level": d.Level, "message": d.Message}
+	default:
+		return data
+	}
+}
+
+func toWireFinding(f models.Finding) wireFinding {
+	return wireFinding{
+		Fingerprint: f.Fingerprint(),
+		RuleID:      f.ID,
+		Title:       f.Title,
+		Description: f.Description,
+		Severity:    f.Severity.String(),
+		Category:    f.Category,
+		Scanner:     f.Scanner,
+		File:        f.File,
+		Line:        f.Line,
+		CodeSnippet: f.CodeSnippet,
+		Remediation: f.Remediation,
+		References:  f.References,
+		Tags:        f.Tags,
+		Fix:         toWireFix(f.Fix),
+	}
+}
+
+func toWireFix(fix *models.Fix) *wireFix {
+	if fix == nil {
+		return nil
+	}
+	return &wireFix{
+		Description: fix.Description,
+		Line:        fix.Line,
+		StartColumn: fix.StartColumn,
+		EndColumn:   fix.EndColumn,
+		Original:    fix.Original,
+		Replacement: fix.Replacement,
+	}
+}
+
+func summarize(r *models.ScanReport) wireSummary {
+	bySeverity := make(map[string]int)
+	for sev, n := range r.CountBySeverity() {
+		bySeverity[sev.String()] = n
+	}
+	scanners := r.ScannersRun
+	if scanners == nil {
+		scanners = []string{}
+	}
+	return wireSummary{
+		ProjectName:   r.ProjectContext.ProjectName,
+		ProjectPath:   r.ProjectContext.RootPath,
+		StartedAt:     r.StartedAt,
+		CompletedAt:   r.CompletedAt,
+		DurationMS:    r.Duration.Milliseconds(),
+		FindingCount:  len(r.Findings),
+		BySeverity:    bySeverity,
+		ScannersRun:   scanners,
+		ScannerErrors: r.ScannerErrors,
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,115 @@
+package eventbus
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf)
+
+	bus := New()
+	defer bus.Close()
+	bus.SubscribeAll(w.Handle)
+
+	bus.Publish(NewEvent(EventScanStarted, ScanStartedData{ProjectPath: "/app", ProjectName: "app", ScannerCount: 2}))
+	bus.Publish(NewEvent(EventScannerStarted, ScannerStartedData{Name: "env-scanner"}))
+	bus.Publish(NewEvent(EventFindingDiscovered, FindingDiscoveredData{Finding: models.Finding{
+		ID:       "ENV-002",
+		Title:    "Debug mode enabled",
+		Severity: models.SeverityHigh,
+		Category: "Configuration",
+		Scanner:  "env-scanner",
+		File:     ".env",
+		Line:     4,
+		Fix:      &models.Fix{Description: "Disable debug mode", Line: 4, StartColumn: 11, EndColumn: 15, Original: "true", Replacement: "false"},
+	}, Filtered: "baseline"}))
+	bus.Publish(NewEvent(EventScannerFailed, ScannerFailedData{Name: "dependency-scanner", Error: errors.New("OSV.dev unreachable")}))
+	bus.Publish(NewEvent(EventScanCompleted, ScanCompletedData{Report: &models.ScanReport{
+		Findings:    []models.Finding{{ID: "ENV-002", Severity: models.SeverityHigh}},
+		Duration:    1500 * time.Millisecond,
+		ScannersRun: []string{"env-scanner"},
+	}}))
+
+	if err := w.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	var events []map[string]any
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var e map[string]any
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("line %d is not JSON: %v\n%s", len(events)+1, err, sc.Text())
+		}
+		events = append(events, e)
+	}
+
+	wantTypes := []string{"scan.started", "scanner.started", "finding.discovered", "scanner.failed", "scan.completed"}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("got %d events, want %d", len(events), len(wantTypes))
+	}
+	for i, want := range wantTypes {
+		if events[i]["type"] != want {
+			t.Errorf("event %d type = %v, want %s", i, events[i]["type"], want)
+		}
+		if _, ok := events[i]["timestamp"].(string); !ok {
+			t.Errorf("event %d has no timestamp", i)
+		}
+	}
+
+	finding := events[2]["data"].(map[string]any)
+	if finding["rule_id"] != "ENV-002" || finding["severity"] != "High" || finding["line"] != float64(4) {
+		t.Errorf("finding data = %v", finding)
+	}
+	if finding["filtered"] != "baseline" {
+		t.Errorf("filtered = %v, want baseline", finding["filtered"])
+	}
+	if fix, _ := finding["fix"].(map[string]any); fix["replacement"] != "false" || fix["start_column"] != float64(11) {
+		t.Errorf("fix = %v", finding["fix"])
+	}
+	if fp, _ := finding["fingerprint"].(string); len(fp) != 24 {
+		t.Errorf("fingerprint = %q, want 24 hex chars", fp)
+	}
+
+	if failed := events[3]["data"].(map[string]any); failed["error"] != "OSV.dev unreachable" {
+		t.Errorf("scanner.failed data = %v", failed)
+	}
+
+	summary := events[4]["data"].(map[string]any)
+	if summary["finding_count"] != float64(1) || summary["duration_ms"] != float64(1500) {
+		t.Errorf("scan.completed data = %v", summary)
+	}
+	if _, ok := summary["findings"]; ok {
+		t.Error("scan.completed should summarize, not repeat the findings")
+	}
+}
+
+type failingWriter struct{ writes int }
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	f.writes++
+	return 0, errors.New("broken pipe")
+}
+
+func TestNDJSONWriter_StopsAfterError(t *testing.T) {
+	fw := &failingWriter{}
+	w := NewNDJSONWriter(fw)
+
+	w.Handle(NewEvent(EventScanStarted, ScanStartedData{}))
+	w.Handle(NewEvent(EventScanCompleted, ScanCompletedData{}))
+
+	if w.Err() == nil {
+		t.Error("expected the write error to be kept")
+	}
+	if fw.writes != 1 {
+		t.Errorf("writes = %d, want 1", fw.writes)
+	}
+}
@@ -0,0 +1,188 @@
+package fixer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eljakani/ward/internal/fsutil"
+	"github.com/eljakani/ward/internal/models"
+)
+
+const diffContext = 3
+
+// Change holds the fixes planned for a single file.
+type Change struct {
+	File     string // relative to the project root
+	Original string
+	Updated  string
+	Applied  []models.Finding // findings whose fix is part of Updated
+	Skipped  []models.Finding // findings whose fix no longer matches the file or overlaps another fix
+}
+
+// Plan groups the fixable findings by file and computes the updated
+// contents. Files are returned in path order.
+func Plan(root string, findings []models.Finding) ([]*Change, error) {
+	byFile := make(map[string][]models.Finding)
+	for _, f := range findings {
+		if f.Fix != nil && f.File != "" {
+			byFile[f.File] = append(byFile[f.File], f)
+		}
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	changes := make([]*Change, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(root, file))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", file, err)
+		}
+
+		c := &Change{File: file, Original: string(data)}
+		c.Updated = c.apply(byFile[file])
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+// apply rewrites the original contents. Fixes on the same line are applied
+// right to left so earlier columns stay valid.
+func (c *Change) apply(findings []models.Finding) string {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i].Fix, findings[j].Fix
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.StartColumn > b.StartColumn
+	})
+
+	lines := strings.SplitAfter(c.Original, "\n")
+	lastStart := make(map[int]int) // line → start column of the last applied fix
+
+	for _, f := range findings {
+		fix := f.Fix
+		idx := fix.Line - 1
+		if idx < 0 || idx >= len(lines) {
+			c.Skipped = append(c.Skipped, f)
+			continue
+		}
+
+		line := lines[idx]
+		start, end := fix.StartColumn-1, fix.EndColumn-1
+		if start < 0 || end > len(line) || start > end || line[start:end] != fix.Original {
+			c.Skipped = append(c.Skipped, f)
+			continue
+		}
+		if prev, ok := lastStart[fix.Line]; ok && end > prev-1 {
+			c.Skipped = append(c.Skipped, f)
+			continue
+		}
+
+		lines[idx] = line[:start] + fix.Replacement + line[end:]
+		lastStart[fix.Line] = fix.StartColumn
+		c.Applied = append(c.Applied, f)
+	}
+
+	return strings.Join(lines, "")
+}
+
+// Write saves the updated contents, keeping the file's permissions. The
+// file is replaced atomically, so an interrupted write never leaves it
+// half fixed.
+func (c *Change) Write(root string) error {
+	path := filepath.Join(root, c.File)
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteAtomic(path, info.Mode().Perm(), func(w io.Writer) error {
+		_, err := io.WriteString(w, c.Updated)
+		return err
+	})
+}
+
+// Diff renders the change as a unified diff. Fixes never add or remove
+// lines, so old and new line numbers always line up.
+func (c *Change) Diff() string {
+	if c.Original == c.Updated {
+		return ""
+	}
+
+	var sb strings.Builder
+	oldLines := splitLines(c.Original)
+	newLines := splitLines(c.Updated)
+	last := len(oldLines) - 1
+	oldEOL := strings.HasSuffix(c.Original, "\n")
+	newEOL := strings.HasSuffix(c.Updated, "\n")
+
+	// A last line that gains or loses its newline has changed too.
+	same := func(k int) bool {
+		return oldLines[k] == newLines[k] && (k != last || oldEOL == newEOL)
+	}
+	// line writes a diff line, marking a last line without a newline the
+	// way diff and git apply expect.
+	line := func(prefix, text string, k int, eol bool) {
+		sb.WriteString(prefix + text + "\n")
+		if k == last && !eol {
+			sb.WriteString("\\ No newline at end of file\n")
+		}
+	}
+
+	var changed []int
+	for i := range oldLines {
+		if i < len(newLines) && !same(i) {
+			changed = append(changed, i)
+		}
+	}
+
+	fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", filepath.ToSlash(c.File), filepath.ToSlash(c.File))
+
+	for i := 0; i < len(changed); {
+		start := max(changed[i]-diffContext, 0)
+		end := min(changed[i]+diffContext+1, len(oldLines))
+
+		// Merge changes whose context windows touch.
+		j := i + 1
+		for j < len(changed) && changed[j]-diffContext <= end {
+			end = min(changed[j]+diffContext+1, len(oldLines))
+			j++
+		}
+
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1, end-start)
+		for k := start; k < end; {
+			if same(k) {
+				line(" ", oldLines[k], k, oldEOL)
+				k++
+				continue
+			}
+			run := k
+			for run < end && !same(run) {
+				run++
+			}
+			for m := k; m < run; m++ {
+				line("-", oldLines[m], m, oldEOL)
+			}
+			for m := k; m < run; m++ {
+				line("+", newLines[m], m, newEOL)
+			}
+			k = run
+		}
+
+		i = j
+	}
+
+	return sb.String()
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,141 @@
+package fixer
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+func TestPlanAndDiff(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "config"), 0755)
+	content := "<?php\n\nreturn [\n    'name' => 'Ward',\n    'debug' => true,\n    'url' => 'http://localhost',\n];\n"
+	os.WriteFile(filepath.Join(dir, "config", "app.php"), []byte(content), 0644)
+
+	re := regexp.MustCompile(`'debug'\s*=>\s*true`)
+	fix := models.LineFix("Read debug mode from APP_DEBUG", 5, "    'debug' => true,", re, "'debug' => env('APP_DEBUG', false)")
+	if fix == nil {
+		t.Fatal("LineFix returned nil")
+	}
+	if fix.StartColumn != 5 || fix.Original != "'debug' => true" {
+		t.Errorf("fix = %+v, want column 5 replacing 'debug' => true", fix)
+	}
+
+	findings := []models.Finding{
+		{ID: "CFG-001", File: filepath.Join("config", "app.php"), Line: 5, Fix: fix},
+		{ID: "CFG-002", File: filepath.Join("config", "app.php"), Line: 4},
+	}
+
+	changes, err := Plan(dir, findings)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+
+	c := changes[0]
+	if len(c.Applied) != 1 || len(c.Skipped) != 0 {
+		t.Errorf("applied = %d, skipped = %d; want 1, 0", len(c.Applied), len(c.Skipped))
+	}
+	if !strings.Contains(c.Updated, "'debug' => env('APP_DEBUG', false),") {
+		t.Errorf("updated content missing replacement:\n%s", c.Updated)
+	}
+
+	diff := c.Diff()
+	for _, want := range []string{
+		"--- a/config/app.php",
+		"@@ -2,6 +2,6 @@",
+		"-    'debug' => true,",
+		"+    'debug' => env('APP_DEBUG', false),",
+		"     'url' => 'http://localhost',",
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff missing %q:\n%s", want, diff)
+		}
+	}
+
+	if err := c.Write(dir); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "config", "app.php"))
+	if string(data) != c.Updated {
+		t.Error("written file does not match planned contents")
+	}
+}
+
+func TestPlan_SkipsStaleFix(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ".env"), []byte("APP_DEBUG=false\n"), 0644)
+
+	findings := []models.Finding{
+		{ID: "ENV-002", File: ".env", Line: 1, Fix: &models.Fix{
+			Line: 1, StartColumn: 11, EndColumn: 15, Original: "true", Replacement: "false",
+		}},
+	}
+
+	changes, err := Plan(dir, findings)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(changes[0].Skipped) != 1 {
+		t.Errorf("stale fix should be skipped, got %+v", changes[0])
+	}
+	if changes[0].Diff() != "" {
+		t.Error("no diff expected when every fix is skipped")
+	}
+}
+
+func TestWrite_KeepsModeAndLeavesNoTemp(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	if err := os.WriteFile(path, []byte("APP_DEBUG=true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Change{File: ".env", Original: "APP_DEBUG=true\n", Updated: "APP_DEBUG=false\n"}
+	if err := c.Write(dir); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only .env in %s, got %d entries", dir, len(entries))
+	}
+	if data, _ := os.ReadFile(path); string(data) != c.Updated {
+		t.Errorf("written %q, want %q", data, c.Updated)
+	}
+}
+
+func TestDiff_NoNewlineAtEOF(t *testing.T) {
+	c := &Change{
+		File:     ".env",
+		Original: "APP_ENV=production\nAPP_DEBUG=true\nAPP_URL=http://localhost",
+		Updated:  "APP_ENV=production\nAPP_DEBUG=false\nAPP_URL=http://localhost",
+	}
+	want := "--- a/.env\n+++ b/.env\n@@ -1,3 +1,3 @@\n" +
+		" APP_ENV=production\n" +
+		"-APP_DEBUG=true\n" +
+		"+APP_DEBUG=false\n" +
+		" APP_URL=http://localhost\n" +
+		"\\ No newline at end of file\n"
+	if got := c.Diff(); got != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", got, want)
+	}
+
+	c.Updated = "APP_ENV=production\nAPP_DEBUG=true\nAPP_URL=https://localhost"
+	if got := c.Diff(); !strings.HasSuffix(got, "-APP_URL=http://localhost\n\\ No newline at end of file\n+APP_URL=https://localhost\n\\ No newline at end of file\n") {
+		t.Errorf("Diff() of the last line =\n%s", got)
+	}
+}
@@ -6,9 +6,13 @@ type ProjectContext struct {
 	LaravelVersion    string
 	PHPVersion        string
 	ProjectName       string
+	ProjectID         string // stable identity the scan history is kept under, see resolver.IdentityResolver
 	FrameworkType     string
 	ComposerDeps      map[string]string // from composer.json require
 	InstalledPackages map[string]string // from composer.lock (resolved versions)
 	EnvVariables      map[string]string
 	ConfigFiles       []string
+	GitCommit         string // HEAD commit hash, empty outside a git repository
+	GitBranch         string // checked-out branch, empty when detached
+	GitRemote         string // origin remote URL, without credentials
 }
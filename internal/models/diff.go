@@ -0,0 +1,63 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// ScanChanges compares a scan with the previous stored scan of the same
+// project. Findings are matched on rule ID, file and line.
+type ScanChanges struct {
+	PreviousAt  time.Time // when the previous scan completed
+	TotalBefore int
+	New         []Finding // in this scan but not the previous one
+	Resolved    []Finding // in the previous scan but gone; may carry only ID, File and Line
+}
+
+// CompareFindings splits two finding sets into those only in after
+// (added), only in before (resolved), and in both (unchanged, taken from
+// after). Findings are matched by Fingerprint and each result is sorted
+// by severity, then file and line.
+func CompareFindings(before, after []Finding) (added, resolved, unchanged []Finding) {
+	inBefore := make(map[string]bool, len(before))
+	for _, f := range before {
+		inBefore[f.Fingerprint()] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, f := range after {
+		inAfter[f.Fingerprint()] = true
+		if inBefore[f.Fingerprint()] {
+			unchanged = append(unchanged, f)
+		} else {
+			added = append(added, f)
+		}
+	}
+	for _, f := range before {
+		if !inAfter[f.Fingerprint()] {
+			resolved = append(resolved, f)
+		}
+	}
+
+	SortFindings(added)
+	SortFindings(resolved)
+	SortFindings(unchanged)
+	return added, resolved, unchanged
+}
+
+// SortFindings orders findings by severity (most severe first), then by
+// file, line and rule ID.
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.ID < b.ID
+	})
+}
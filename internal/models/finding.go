@@ -18,6 +18,12 @@ type Finding struct {
 	CodeSnippet string
 	Remediation string
 	References  []string
+	Tags        []string       // rule tags, e.g. "cwe-89", "owasp-a03"
+	Fix         *Fix           // optional mechanical fix for the finding
+	Lifecycle   *Lifecycle     // across stored scans; nil when the scan history wasn't consulted
+	Triage      string         // triage status from the project baseline, e.g. "to_fix"
+	Related     []Location     // other lines behind the finding, e.g. the rest of a composite match
+	Context     *SourceContext // lines around Line, captured at scan time; nil when unreadable
 }
 
 // Fingerprint returns a stable hash identifying this finding across scans.
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf16"
+)
+
+// Fix is a mechanical single-line replacement that resolves a finding.
+// Columns are 1-based and refer to the raw line in the finding's file.
+type Fix struct {
+	Description string
+	Line        int
+	StartColumn int    // inclusive, in bytes
+	EndColumn   int    // exclusive, in bytes
+	Original    string // text being replaced
+	Replacement string
+
+	// The same columns in UTF-16 code units, as SARIF counts them. Zero
+	// for fixes read back from JSON reports.
+	StartColumnUTF16 int
+	EndColumnUTF16   int
+}
+
+// UTF16Columns returns the fix's columns in UTF-16 code units, falling
+// back to the byte columns when they weren't recorded.
+func (f Fix) UTF16Columns() (start, end int) {
+	if f.StartColumnUTF16 == 0 {
+		return f.StartColumn, f.EndColumn
+	}
+	return f.StartColumnUTF16, f.EndColumnUTF16
+}
+
+// LineFix builds a Fix replacing the first match of re in line with
+// template, which may reference capture groups ($1, ${name}). It returns
+// nil if re doesn't match or the replacement would change nothing.
+func LineFix(description string, lineNum int, line string, re *regexp.Regexp, template string) *Fix {
+	if re == nil || lineNum <= 0 {
+		return nil
+	}
+
+	loc := re.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return nil
+	}
+
+	replacement := string(re.ExpandString(nil, template, line, loc))
+	original := line[loc[0]:loc[1]]
+	if replacement == original || strings.Contains(replacement, "\n") {
+		return nil
+	}
+
+	start16 := utf16Len(line[:loc[0]]) + 1
+	return &Fix{
+		Description:      description,
+		Line:             lineNum,
+		StartColumn:      loc[0] + 1,
+		EndColumn:        loc[1] + 1,
+		Original:         original,
+		Replacement:      replacement,
+		StartColumnUTF16: start16,
+		EndColumnUTF16:   start16 + utf16Len(original),
+	}
+}
+
+// utf16Len returns the length of s in UTF-16 code units.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
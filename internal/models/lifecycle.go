@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Lifecycle is a finding's history across the stored scans of its
+// project, from the scan that reported it until the first scan without
+// it. A finding that was resolved and came back starts a new lifecycle.
+type Lifecycle struct {
+	FirstSeen   time.Time // completion of the first scan that reported it
+	FirstCommit string    // commit that scan ran at; empty outside git
+	LastSeen    time.Time // completion of the latest scan that reported it
+	ResolvedAt  time.Time // completion of the first scan without it; zero while open
+}
+
+// Open reports whether the finding was in the latest scan.
+func (l *Lifecycle) Open() bool {
+	return l.ResolvedAt.IsZero()
+}
+
+// Age is how long the finding has been open as of its last scan, or how
+// long it took to resolve.
+func (l *Lifecycle) Age() time.Duration {
+	if l.Open() {
+		return l.LastSeen.Sub(l.FirstSeen)
+	}
+	return l.ResolvedAt.Sub(l.FirstSeen)
+}
+
+// String describes the lifecycle for reports: "open 12d, first seen
+// 2026-10-06 at 3f2a1c9", or "new in this scan" for a finding its last
+// scan reported for the first time.
+func (l *Lifecycle) String() string {
+	if l.Open() && l.FirstSeen.Equal(l.LastSeen) {
+		return "new in this scan"
+	}
+	first := "first seen " + l.FirstSeen.Local().Format("2006-01-02")
+	if l.FirstCommit != "" {
+		commit := l.FirstCommit
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		first += " at " + commit
+	}
+	if l.Open() {
+		return "open " + FormatAge(l.Age()) + ", " + first
+	}
+	return "resolved in " + FormatAge(l.Age()) + ", " + first
+}
+
+// FormatAge renders d in hours below a day and in days above: "<1h",
+// "5h", "12d".
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return "<1h"
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLifecycle_String(t *testing.T) {
+	first := time.Date(2026, 10, 6, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		name string
+		l    Lifecycle
+		want string
+	}{
+		{"new", Lifecycle{FirstSeen: first, LastSeen: first}, "new in this scan"},
+		{"open", Lifecycle{FirstSeen: first, FirstCommit: "3f2a1c9e8b7d", LastSeen: first.Add(12*24*time.Hour + time.Hour)}, "open 12d, first seen 2026-10-06 at 3f2a1c9"},
+		{"resolved", Lifecycle{FirstSeen: first, LastSeen: first, ResolvedAt: first.Add(5 * time.Hour)}, "resolved in 5h, first seen 2026-10-06"},
+	}
+	for _, tt := range tests {
+		if got := tt.l.String(); got != tt.want {
+			t.Errorf("%s: String() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
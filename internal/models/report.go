@@ -11,6 +11,8 @@ type ScanReport struct {
 	Duration       time.Duration
 	ScannersRun    []string
 	ScannerErrors  map[string]string
+	Checks         map[string][]Check // scanner name → checks it ran, for scanners that list them
+	Changes        *ScanChanges       // against the previous stored scan; nil for a first scan
 }
 
 // CountBySeverity returns a map of severity to finding count.
@@ -42,3 +42,26 @@ func TestFindingsByCategory(t *testing.T) {
 		t.Errorf("Secrets count = %d, want 1", len(grouped["Secrets"]))
 	}
 }
+
+func TestCompareFindings(t *testing.T) {
+	before := []Finding{
+		{ID: "A", File: "a.php", Line: 1, Severity: SeverityLow},
+		{ID: "B", File: "b.php", Line: 2, Severity: SeverityHigh},
+	}
+	after := []Finding{
+		{ID: "B", File: "b.php", Line: 2, Severity: SeverityHigh, Title: "updated"},
+		{ID: "C", File: "c.php", Line: 3, Severity: SeverityMedium},
+		{ID: "D", File: "a.php", Line: 9, Severity: SeverityCritical},
+	}
+
+	added, resolved, unchanged := CompareFindings(before, after)
+	if len(added) != 2 || added[0].ID != "D" || added[1].ID != "C" {
+		t.Errorf("added = %v, want D then C", added)
+	}
+	if len(resolved) != 1 || resolved[0].ID != "A" {
+		t.Errorf("resolved = %v, want A", resolved)
+	}
+	if len(unchanged) != 1 || unchanged[0].Title != "updated" {
+		t.Errorf("unchanged = %v, want B as it is in after", unchanged)
+	}
+}
@@ -9,6 +9,20 @@ type Scanner interface {
 	Scan(ctx context.Context, project ProjectContext, emit func(Finding)) ([]Finding, error)
 }
 
+// Check describes a single rule or built-in check run by a scanner.
+type Check struct {
+	ID       string
+	Title    string
+	Category string
+	Tags     []string // e.g. "cwe-89", "owasp-a03"; used to map checks to compliance controls
+}
+
+// CheckLister is implemented by scanners that can enumerate the checks
+// they ran, so reports can show which ones passed. It is called after Scan.
+type CheckLister interface {
+	Checks() []Check
+}
+
 // ScannerStatus represents the current state of a scanner.
 type ScannerStatus int
 
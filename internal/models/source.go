@@ -0,0 +1,22 @@
+package models
+
+// Location is a line related to a finding, such as the enclosing scope of
+// an inside: match or another part of an all: match.
+type Location struct {
+	File    string
+	Line    int
+	Note    string         // how the line relates to the finding, e.g. "inside"
+	Snippet string         // the trimmed line
+	Context *SourceContext // lines around Line, captured at scan time
+}
+
+// SourceContext is a window of a file's lines around a line of interest.
+type SourceContext struct {
+	StartLine int // 1-based number of Lines[0]
+	Lines     []string
+}
+
+// EndLine returns the number of the last line of the window.
+func (c *SourceContext) EndLine() int {
+	return c.StartLine + len(c.Lines) - 1
+}
@@ -3,6 +3,7 @@ package orchestrator
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	depscanner "github.com/eljakani/ward/internal/scanner/dependency"
 	envscanner "github.com/eljakani/ward/internal/scanner/env"
 	rulesscanner "github.com/eljakani/ward/internal/scanner/rules"
+	"github.com/eljakani/ward/internal/snippet"
 	"github.com/eljakani/ward/internal/store"
 )
 
@@ -27,12 +29,17 @@ type Orchestrator struct {
 	target       string
 	version      string
 	baseline     *baseline.Baseline
+	origins      config.Origins
 	baselinePath string // if set, save baseline after scan
+	verbose      bool
+	skipOutputs  bool   // don't write reports, scan history or baselines
+	noFiles      bool   // don't write report files; stdout reports still run
+	failOn       string // --fail-on threshold, for reporters that mark failures
 }
 
 // New creates a new Orchestrator.
 func New(bus *eventbus.EventBus, cfg *config.WardConfig, target string, version string) *Orchestrator {
-	return &Orchestrator{bus: bus, cfg: cfg, target: target, version: version}
+	return &Orchestrator{bus: bus, cfg: cfg, origins: make(config.Origins), target: target, version: version}
 }
 
 // SetBaseline configures an existing baseline for filtering.
@@ -40,42 +47,43 @@ func (o *Orchestrator) SetBaseline(b *baseline.Baseline) {
 	o.baseline = b
 }
 
+// SetOrigins records where each config value came from, so the project
+// config of a remote clone doesn't override CLI flags.
+func (o *Orchestrator) SetOrigins(origins config.Origins) {
+	o.origins = origins
+}
+
 // SetBaselinePath configures a path to save a new baseline after scanning.
 func (o *Orchestrator) SetBaselinePath(path string) {
 	o.baselinePath = path
 }
 
-// Run executes the full scan pipeline.
-func (o *Orchestrator) Run(ctx context.Context) error {
-	startTime := time.Now()
+// SetVerbose enables detailed log messages, such as rules skipped by their conditions.
+func (o *Orchestrator) SetVerbose(v bool) {
+	o.verbose = v
+}
 
-	scanners := []models.Scanner{
-		envscanner.New(),
-		configscanner.New(),
-		depscanner.New(),
-	}
+// SetSkipOutputs disables report files, scan history and baseline writes,
+// for commands that only need the in-memory report.
+func (o *Orchestrator) SetSkipOutputs(skip bool) {
+	o.skipOutputs = skip
+}
 
-	// Load custom YAML rules and add rules scanner if any rules found
-	customRules, err := config.LoadAllRules(o.cfg)
-	if err != nil {
-		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
-			Level: "warn", Message: fmt.Sprintf("Failed to load custom rules: %v", err),
-		}))
-	} else if len(customRules) > 0 {
-		scanners = append(scanners, rulesscanner.New(customRules))
-		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
-			Level: "info", Message: fmt.Sprintf("Loaded %d custom rule(s)", len(customRules)),
-		}))
-	}
+// SetNoReportFiles skips reports that would be written to disk, leaving
+// only those sent to stdout. Scan history and baselines are unaffected.
+func (o *Orchestrator) SetNoReportFiles(skip bool) {
+	o.noFiles = skip
+}
 
-	// Filter scanners based on config enable/disable lists
-	scanners = o.filterScanners(scanners)
+// SetFailOn passes the --fail-on severity to reporters that distinguish
+// failing findings from the rest (JUnit).
+func (o *Orchestrator) SetFailOn(severity string) {
+	o.failOn = severity
+}
 
-	o.bus.Publish(eventbus.NewEvent(eventbus.EventScanStarted, eventbus.ScanStartedData{
-		ProjectPath:  o.target,
-		ProjectName:  o.target,
-		ScannerCount: len(scanners),
-	}))
+// Run executes the full scan pipeline.
+func (o *Orchestrator) Run(ctx context.Context) error {
+	startTime := time.Now()
 
 	// --- Stage 1: Provider ---
 	o.stageStart(models.StageProvider)
@@ -96,6 +104,25 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 	}
 	defer src.Cleanup()
 
+	// A clone's project config is only known now; apply it before the
+	// scanners and rules it may enable, disable or add are loaded.
+	if provider.IsGitURL(o.target) {
+		path, ignored, err := config.MergeClone(o.cfg, o.origins, result.RootPath)
+		if err != nil {
+			return o.fail(fmt.Errorf("loading project config: %w", err))
+		}
+		if path != "" {
+			o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+				Level: "info", Message: fmt.Sprintf("Applied project config %s", filepath.Base(path)),
+			}))
+			if len(ignored) > 0 {
+				o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+					Level: "warn", Message: fmt.Sprintf("Ignored %s from the cloned project config", strings.Join(ignored, ", ")),
+				}))
+			}
+		}
+	}
+
 	if !result.IsLaravel {
 		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
 			Level: "warn", Message: "Path does not appear to be a Laravel project",
@@ -104,6 +131,16 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 
 	o.stageComplete(models.StageProvider)
 
+	// Scanners are built once the config is final, so the count reported
+	// and the rules logged are those of the scan that runs.
+	scanners, rules := o.scanners()
+
+	o.bus.Publish(eventbus.NewEvent(eventbus.EventScanStarted, eventbus.ScanStartedData{
+		ProjectPath:  o.target,
+		ProjectName:  o.target,
+		ScannerCount: len(scanners),
+	}))
+
 	// --- Stage 2: Resolvers ---
 	o.stageStart(models.StageResolvers)
 
@@ -111,6 +148,8 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 	resolvers := []resolver.ContextResolver{
 		resolver.NewFrameworkResolver(),
 		resolver.NewPackageResolver(),
+		resolver.NewGitResolver(),
+		resolver.NewIdentityResolver(o.cfg.Project.ID),
 	}
 
 	for _, r := range resolvers {
@@ -146,6 +185,8 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 	var allFindings []models.Finding
 	scannersRun := make([]string, 0, len(scanners))
 	scannerErrors := make(map[string]string)
+	checks := make(map[string][]models.Check)
+	seen := make(map[string]bool)
 
 	for _, sc := range scanners {
 		if o.isScannerDisabled(sc.Name()) {
@@ -161,7 +202,8 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 
 		emit := func(f models.Finding) {
 			o.bus.Publish(eventbus.NewEvent(eventbus.EventFindingDiscovered, eventbus.FindingDiscoveredData{
-				Finding: f,
+				Finding:  f,
+				Filtered: o.filterReason(f, seen),
 			}))
 		}
 
@@ -176,6 +218,9 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 
 		allFindings = append(allFindings, findings...)
 		scannersRun = append(scannersRun, sc.Name())
+		if cl, ok := sc.(models.CheckLister); ok {
+			checks[sc.Name()] = cl.Checks()
+		}
 
 		o.bus.Publish(eventbus.NewEvent(eventbus.EventScannerCompleted, eventbus.ScannerCompletedData{
 			Name:         sc.Name(),
@@ -183,17 +228,31 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 		}))
 	}
 
+	if rules != nil && o.verbose {
+		for _, sk := range rules.Skipped() {
+			o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+				Level: "debug", Message: fmt.Sprintf("Skipped rule %s: %s", sk.ID, sk.Reason),
+			}))
+		}
+	}
+
 	o.stageComplete(models.StageScanners)
 
 	// --- Stage 4: Post-Process ---
 	o.stageStart(models.StagePostProcess)
 	allFindings = deduplicate(allFindings)
+	// Finding lifecycles follow every finding, so one the threshold or the
+	// baseline hides isn't taken as resolved.
+	tracked := allFindings
 	allFindings = filterBySeverity(allFindings, models.ParseSeverity(o.cfg.Severity))
 
-	// Apply baseline filtering
+	// Apply baseline filtering. An updated baseline is saved from every
+	// finding, including those the current one suppresses.
+	scanned := allFindings
 	if o.baseline != nil {
 		var suppressed int
 		allFindings, suppressed = o.baseline.Filter(allFindings)
+		o.baseline.Annotate(allFindings)
 		if suppressed > 0 {
 			o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
 				Level: "info", Message: fmt.Sprintf("%d findings suppressed by baseline", suppressed),
@@ -201,6 +260,10 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 		}
 	}
 
+	// Capture the source around each finding while the files are at hand;
+	// a remote clone is removed once the scan returns.
+	snippet.Attach(result.RootPath, allFindings, o.cfg.Output.ContextLines)
+
 	o.stageComplete(models.StagePostProcess)
 
 	// --- Stage 5: Report ---
@@ -215,49 +278,66 @@ func (o *Orchestrator) Run(ctx context.Context) error {
 		Duration:       endTime.Sub(startTime),
 		ScannersRun:    scannersRun,
 		ScannerErrors:  scannerErrors,
+		Checks:         checks,
 	}
 
-	reporters := o.buildReporters()
-	for _, rep := range reporters {
-		if err := rep.Generate(ctx, report); err != nil {
-			o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
-				Level: "error", Message: fmt.Sprintf("%s reporter failed: %v", rep.Name(), err),
-			}))
-		} else {
-			o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
-				Level: "info", Message: fmt.Sprintf("Report written to ward-report.%s", rep.Format()),
-			}))
-		}
+	if o.skipOutputs {
+		o.stageComplete(models.StageReport)
+		o.bus.Publish(eventbus.NewEvent(eventbus.EventScanCompleted, eventbus.ScanCompletedData{
+			Report: report,
+		}))
+		return nil
 	}
 
-	// Compare with last scan and save to store
+	// Compare with the last stored scan before reporting, so reports can
+	// show what changed and how long each finding has been open.
 	diff, _ := store.CompareLast(report)
 	if diff != nil {
-		if len(diff.NewFindings) > 0 || len(diff.ResolvedFindings) > 0 {
+		report.Changes = diff.Changes()
+	}
+	if err := store.Annotate(report); err != nil {
+		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+			Level: "warn", Message: fmt.Sprintf("Failed to read finding history: %v", err),
+		}))
+	}
+
+	o.WriteReports(ctx, report) // failures are logged; the scan still completes
+
+	if diff != nil {
+		if len(diff.New) > 0 || len(diff.Resolved) > 0 {
 			o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
 				Level: "info", Message: fmt.Sprintf("vs last scan: %d new, %d resolved (%d→%d)",
-					len(diff.NewFindings), len(diff.ResolvedFindings), diff.TotalBefore, diff.TotalAfter),
+					len(diff.New), len(diff.Resolved), diff.TotalBefore, diff.TotalAfter),
 			}))
 		}
 	}
 
-	if _, err := store.Save(report); err != nil {
+	// Save to store
+	if _, err := store.Save(report, tracked); err != nil {
 		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
 			Level: "warn", Message: fmt.Sprintf("Failed to save scan history: %v", err),
 		}))
+	} else if n, err := store.Prune(o.cfg.Store); err != nil {
+		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+			Level: "warn", Message: fmt.Sprintf("Failed to prune scan history: %v", err),
+		}))
+	} else if n > 0 {
+		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+			Level: "info", Message: fmt.Sprintf("Pruned %d old scans from history", n),
+		}))
 	}
 
 	o.stageComplete(models.StageReport)
 
 	// Save baseline if requested
 	if o.baselinePath != "" {
-		if err := baseline.Save(o.baselinePath, allFindings); err != nil {
+		if err := baseline.Save(o.baselinePath, scanned); err != nil {
 			o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
 				Level: "warn", Message: fmt.Sprintf("Failed to save baseline: %v", err),
 			}))
 		} else {
 			o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
-				Level: "info", Message: fmt.Sprintf("Baseline saved to %s (%d findings)", o.baselinePath, len(allFindings)),
+				Level: "info", Message: fmt.Sprintf("Baseline saved to %s (%d findings)", o.baselinePath, len(scanned)),
 			}))
 		}
 	}
@@ -305,19 +385,61 @@ func filterBySeverity(findings []models.Finding, minSeverity models.Severity) []
 	return result
 }
 
-func (o *Orchestrator) buildReporters() []reporter.Reporter {
-	outDir := o.cfg.Output.Dir
-	formats := o.cfg.Output.Formats
+// WriteReports renders report in every configured output format, as at
+// the end of a scan. Failures are logged and the rest still run; the
+// error only says how many failed.
+func (o *Orchestrator) WriteReports(ctx context.Context, report *models.ScanReport) error {
+	failed := 0
+	reporters := o.buildReporters()
+	written := make(map[string]string) // path → reporter that wrote it
+	for _, rep := range reporters {
+		path, err := o.reportPath(rep, report)
+		if err == nil && o.noFiles && path != reporter.Stdout {
+			continue
+		}
+		if err == nil {
+			if prev, dup := written[path]; dup {
+				err = fmt.Errorf("%s already writes to %s; set output.paths or add {format} to output.name", prev, path)
+			}
+		}
+		if err == nil {
+			err = reporter.Write(ctx, rep, path, report)
+		}
+		if err != nil {
+			o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+				Level: "error", Message: fmt.Sprintf("%s reporter failed: %v", rep.Name(), err),
+			}))
+			failed++
+			continue
+		}
+		written[path] = rep.Name()
 
-	// Default to JSON if no formats specified
-	if len(formats) == 0 {
-		formats = []string{"json"}
+		msg := fmt.Sprintf("Report written to %s", path)
+		if path == reporter.Stdout {
+			msg = fmt.Sprintf("%s report written to stdout", rep.Name())
+		}
+		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+			Level: "info", Message: msg,
+		}))
 	}
+	if failed > 0 {
+		return fmt.Errorf("%d report(s) failed", failed)
+	}
+	return nil
+}
+
+// buildReporters returns a reporter for each requested format, and
+// nothing else: history and baselines work from the in-memory report.
+func (o *Orchestrator) buildReporters() []reporter.Reporter {
+	outDir := o.cfg.Output.Dir
 
 	var reporters []reporter.Reporter
 	seen := make(map[string]bool)
 
-	for _, f := range formats {
+	for _, f := range o.cfg.Output.Formats {
+		if f == "md" {
+			f = "markdown"
+		}
 		if seen[f] {
 			continue
 		}
@@ -330,27 +452,97 @@ func (o *Orchestrator) buildReporters() []reporter.Reporter {
 			reporters = append(reporters, reporter.NewSARIFReporter(outDir, o.version))
 		case "html":
 			reporters = append(reporters, reporter.NewHTMLReporter(outDir))
-		case "markdown", "md":
+		case "markdown":
 			reporters = append(reporters, reporter.NewMarkdownReporter(outDir, o.version))
-		case "terminal":
-			// terminal output is handled by the headless/TUI path, not a file reporter
+		case "junit":
+			reporters = append(reporters, reporter.NewJUnitReporter(outDir, o.failOn))
+		case "gitlab-sast":
+			reporters = append(reporters, reporter.NewGitLabSASTReporter(outDir, o.version))
+		case "gitlab-codequality":
+			reporters = append(reporters, reporter.NewGitLabCodeQualityReporter(outDir))
+		case "csv":
+			reporters = append(reporters, reporter.NewCSVReporter(outDir))
+		case "jsonl":
+			reporters = append(reporters, reporter.NewJSONLReporter(outDir, o.version))
+		case "compliance":
+			frameworks, err := config.LoadCompliance()
+			if err != nil {
+				o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+					Level: "error", Message: fmt.Sprintf("compliance reporter failed: %v", err),
+				}))
+				continue
+			}
+			reporters = append(reporters, reporter.NewComplianceReporter(outDir, o.version, frameworks))
+		case "terminal", "ndjson":
+			// terminal output and the ndjson event stream are handled by the
+			// headless/TUI path, not a file reporter
 			continue
 		}
 	}
 
-	// Always generate JSON as baseline
-	if !seen["json"] {
-		reporters = append(reporters, reporter.NewJSONReporter(outDir))
+	return reporters
+}
+
+// reportPath resolves where rep writes: its output.paths entry if set,
+// otherwise output.name plus the format's extension, otherwise the
+// reporter's default file name. Relative paths are joined to output.dir.
+// Formats with a name a CI system expects (gitlab-sast) keep it unless
+// output.paths says otherwise.
+func (o *Orchestrator) reportPath(rep reporter.Reporter, report *models.ScanReport) (string, error) {
+	out := o.cfg.Output
+
+	tmpl, ok := out.Paths[rep.Name()]
+	if !ok && rep.Name() == "markdown" {
+		tmpl, ok = out.Paths["md"]
+	}
+	if ok && tmpl == reporter.Stdout {
+		return reporter.Stdout, nil
+	}
+	if !ok {
+		tmpl = reporter.FileName(rep)
+		if _, fixed := rep.(reporter.FileNamer); out.Name != "" && !fixed {
+			tmpl = out.Name + "." + rep.Format()
+		}
+	}
+
+	path, err := reporter.ExpandPath(tmpl, reporter.PathVars(report, rep.Name(), rep))
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(out.Dir, path)
 	}
+	return path, nil
+}
 
-	return reporters
+// filterReason tells why post-processing will drop f, checking in the
+// same order: a duplicate of a finding in seen, below the severity
+// threshold, or suppressed by the baseline. It is "" for a reported
+// finding, and f is added to seen.
+func (o *Orchestrator) filterReason(f models.Finding, seen map[string]bool) string {
+	key := dedupKey(f)
+	if seen[key] {
+		return "duplicate"
+	}
+	seen[key] = true
+	if f.Severity < models.ParseSeverity(o.cfg.Severity) {
+		return "severity"
+	}
+	if o.baseline != nil && o.baseline.IsBaselined(f) {
+		return "baseline"
+	}
+	return ""
+}
+
+func dedupKey(f models.Finding) string {
+	return f.ID + "|" + f.File + "|" + fmt.Sprintf("%d", f.Line)
 }
 
 func deduplicate(findings []models.Finding) []models.Finding {
 	seen := make(map[string]bool)
 	result := make([]models.Finding, 0, len(findings))
 	for _, f := range findings {
-		key := f.ID + "|" + f.File + "|" + fmt.Sprintf("%d", f.Line)
+		key := dedupKey(f)
 		if seen[key] {
 			continue
 		}
@@ -360,7 +552,34 @@ func deduplicate(findings []models.Finding) []models.Finding {
 	return result
 }
 
-// filterScanners applies the config enable/disable lists.
+// scanners returns the scanners the config enables, with the rules
+// scanner when any rules are loaded.
+func (o *Orchestrator) scanners() ([]models.Scanner, *rulesscanner.Scanner) {
+	scanners := []models.Scanner{
+		envscanner.New(),
+		configscanner.New(),
+		depscanner.New(),
+	}
+
+	// Load custom YAML rules and add rules scanner if any rules found
+	var rules *rulesscanner.Scanner
+	customRules, err := config.LoadAllRules(o.cfg)
+	if err != nil {
+		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+			Level: "warn", Message: fmt.Sprintf("Failed to load custom rules: %v", err),
+		}))
+	} else if len(customRules) > 0 {
+		rules = rulesscanner.New(customRules)
+		scanners = append(scanners, rules)
+		o.bus.Publish(eventbus.NewEvent(eventbus.EventLogMessage, eventbus.LogMessageData{
+			Level: "info", Message: fmt.Sprintf("Loaded %d custom rule(s)", len(customRules)),
+		}))
+	}
+
+	// Filter scanners based on config enable/disable lists
+	return o.filterScanners(scanners), rules
+}
+
 func (o *Orchestrator) filterScanners(scanners []models.Scanner) []models.Scanner {
 	enable := o.cfg.Scanners.Enable
 	disable := o.cfg.Scanners.Disable
@@ -0,0 +1,110 @@
+package orchestrator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eljakani/ward/internal/baseline"
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/eventbus"
+	"github.com/eljakani/ward/internal/models"
+)
+
+func TestUpdateBaselineTwice(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".env"), []byte("APP_ENV=production\nAPP_DEBUG=true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, ".ward-baseline.json")
+
+	cfg := config.Default()
+	cfg.Scanners.Enable = []string{"env-scanner"}
+
+	// As `ward scan --update-baseline` does: load the project baseline
+	// if there is one, scan, and save.
+	scan := func() {
+		t.Helper()
+		o := New(eventbus.New(), cfg, root, "test")
+		o.SetNoReportFiles(true)
+		o.SetBaselinePath(path)
+		if bl, err := baseline.Load(path); err == nil {
+			o.SetBaseline(bl)
+		}
+		if err := o.Run(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	load := func() *baseline.Baseline {
+		t.Helper()
+		bl, err := baseline.Load(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bl
+	}
+
+	scan()
+	first := load()
+	if len(first.Entries) == 0 {
+		t.Fatal("first scan saved no findings")
+	}
+	triaged := first.Entries[0]
+	if err := baseline.Triage(path, findingOf(triaged), baseline.StatusAcceptedRisk, "internal only"); err != nil {
+		t.Fatal(err)
+	}
+
+	scan()
+	second := load()
+	if len(second.Entries) != len(first.Entries) {
+		t.Fatalf("second scan saved %d entries, want %d", len(second.Entries), len(first.Entries))
+	}
+	e, ok := second.Lookup(findingOf(triaged))
+	if !ok || e.Status != baseline.StatusAcceptedRisk || e.Reason != "internal only" || e.TriagedAt.IsZero() {
+		t.Errorf("triage lost on update: %+v", e)
+	}
+}
+
+func TestFindingEventsFlagFiltered(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ".env"), []byte("APP_ENV=production\nAPP_DEBUG=true\nAPP_KEY=\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Scanners.Enable = []string{"env-scanner"}
+	cfg.Severity = "critical"
+
+	bus := eventbus.New()
+	reasons := make(map[string]int)
+	var report *models.ScanReport
+	bus.Subscribe(eventbus.EventFindingDiscovered, func(e eventbus.Event) {
+		reasons[e.Data.(eventbus.FindingDiscoveredData).Filtered]++
+	})
+	bus.Subscribe(eventbus.EventScanCompleted, func(e eventbus.Event) {
+		report = e.Data.(eventbus.ScanCompletedData).Report
+	})
+
+	o := New(bus, cfg, root, "test")
+	o.SetSkipOutputs(true)
+	if err := o.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Findings) == 0 {
+		t.Fatal("no critical findings reported")
+	}
+	if reasons["severity"] == 0 {
+		t.Errorf("no finding flagged as below the threshold: %v", reasons)
+	}
+	if reasons[""] != len(report.Findings) {
+		t.Errorf("%d findings streamed unfiltered, report has %d", reasons[""], len(report.Findings))
+	}
+}
+
+func findingOf(e baseline.Entry) models.Finding {
+	return models.Finding{ID: e.ID, File: e.File, Line: e.Line}
+}
@@ -0,0 +1,258 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+)
+
+// ComplianceReporter writes a Markdown coverage matrix that maps the scan
+// to compliance frameworks such as the OWASP Top 10 and PCI DSS.
+type ComplianceReporter struct {
+	OutputDir  string
+	Version    string
+	Frameworks []config.ComplianceFramework
+}
+
+func NewComplianceReporter(outputDir, version string, frameworks []config.ComplianceFramework) *ComplianceReporter {
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if version == "" {
+		version = "dev"
+	}
+	return &ComplianceReporter{OutputDir: outputDir, Version: version, Frameworks: frameworks}
+}
+
+func (r *ComplianceReporter) Name() string   { return "compliance" }
+func (r *ComplianceReporter) Format() string { return "compliance.md" }
+
+// Generate writes ward-report.compliance.md to OutputDir.
+func (r *ComplianceReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *ComplianceReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
+	results := evaluateCompliance(report, r.Frameworks)
+
+	var sb strings.Builder
+
+	sb.WriteString("# Ward Compliance Report\n\n")
+	sb.WriteString(fmt.Sprintf("**Project:** %s  \n", report.ProjectContext.ProjectName))
+	if c := report.ProjectContext.GitCommit; c != "" {
+		sb.WriteString(fmt.Sprintf("**Commit:** %s  \n", c))
+	}
+	if !report.StartedAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("**Scanned:** %s  \n", report.StartedAt.UTC().Format("2006-01-02 15:04 MST")))
+	}
+	sb.WriteString(fmt.Sprintf("**Scanners:** %s  \n\n", strings.Join(report.ScannersRun, ", ")))
+
+	sb.WriteString("A control **passed** when the rules and checks mapped to it ran without findings, " +
+		"**failed** when one of them reported a finding, and has **no coverage** when nothing Ward ran maps to it. " +
+		"A pass means Ward found no violation in the code and configuration it can see; it is not an attestation that the control is met.\n\n")
+
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("| Framework | Controls | ✅ Passed | ❌ Failed | ➖ No coverage |\n")
+	sb.WriteString("|-----------|----------|-----------|-----------|----------------|\n")
+	for _, fr := range results {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d |\n",
+			mdCell(fr.Framework.Name), len(fr.Controls), fr.count(controlPassed), fr.count(controlFailed), fr.count(controlNotCovered)))
+	}
+	sb.WriteString("\n")
+
+	for _, fr := range results {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", fr.Framework.Name))
+		sb.WriteString("| Control | Title | Status | Covered by | Findings |\n")
+		sb.WriteString("|---------|-------|--------|------------|----------|\n")
+		for _, cr := range fr.Controls {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %d |\n",
+				mdCell(cr.Control.ID), mdCell(cr.Control.Title), cr.Status, mdCell(abbreviate(cr.CoveredBy, 5)), len(cr.Findings)))
+		}
+		sb.WriteString("\n")
+
+		if fr.count(controlFailed) == 0 {
+			continue
+		}
+		sb.WriteString("### Failed controls\n\n")
+		for _, cr := range fr.Controls {
+			if cr.Status != controlFailed {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("#### %s — %s\n\n", cr.Control.ID, cr.Control.Title))
+			for _, f := range cr.Findings {
+				loc := f.File
+				if f.Line > 0 {
+					loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+				}
+				sb.WriteString(fmt.Sprintf("- %s **%s** `%s` %s — `%s`\n", severityEmoji(f.Severity), f.Severity, f.ID, f.Title, loc))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("*Generated by [Ward](https://github.com/Eljakani/ward) %s*\n", r.Version))
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("writing compliance report: %w", err)
+	}
+	return nil
+}
+
+type controlStatus int
+
+const (
+	controlNotCovered controlStatus = iota
+	controlPassed
+	controlFailed
+)
+
+func (s controlStatus) String() string {
+	switch s {
+	case controlPassed:
+		return "✅ Passed"
+	case controlFailed:
+		return "❌ Failed"
+	default:
+		return "➖ No coverage"
+	}
+}
+
+type controlResult struct {
+	Control   config.ComplianceControl
+	Status    controlStatus
+	CoveredBy []string // checks and scanners that ran and map to the control
+	Findings  []models.Finding
+}
+
+type frameworkResult struct {
+	Framework config.ComplianceFramework
+	Controls  []controlResult
+}
+
+func (fr frameworkResult) count(s controlStatus) int {
+	n := 0
+	for _, c := range fr.Controls {
+		if c.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+// complianceSubject is a check or finding as the control selectors see it.
+type complianceSubject struct {
+	id, category, scanner string
+	tags                  []string
+	cwes                  []string
+}
+
+// evaluateCompliance works out each control's status from the checks
+// that ran and the findings they reported. Findings inherit the tags of
+// the check with their ID, since built-in scanners tag checks rather
+// than each finding.
+func evaluateCompliance(report *models.ScanReport, frameworks []config.ComplianceFramework) []frameworkResult {
+	var checks []complianceSubject
+	checkTags := make(map[string][]string)
+	for scanner, list := range report.Checks {
+		for _, c := range list {
+			checkTags[c.ID] = c.Tags
+			checks = append(checks, complianceSubject{
+				id: c.ID, category: c.Category, scanner: scanner, tags: c.Tags,
+				cwes: findingTaxonomy(models.Finding{Tags: c.Tags}).CWEs,
+			})
+		}
+	}
+	slices.SortFunc(checks, func(a, b complianceSubject) int { return strings.Compare(a.id, b.id) })
+
+	findings := make([]complianceSubject, len(report.Findings))
+	for i, f := range report.Findings {
+		f.Tags = append(slices.Clone(f.Tags), checkTags[f.ID]...)
+		findings[i] = complianceSubject{
+			id: f.ID, category: f.Category, scanner: f.Scanner, tags: f.Tags,
+			cwes: findingTaxonomy(f).CWEs,
+		}
+	}
+
+	results := make([]frameworkResult, 0, len(frameworks))
+	for _, fw := range frameworks {
+		fr := frameworkResult{Framework: fw}
+		for _, ctl := range fw.Controls {
+			cr := controlResult{Control: ctl}
+			for _, s := range ctl.Scanners {
+				if slices.Contains(report.ScannersRun, s) {
+					cr.CoveredBy = append(cr.CoveredBy, s)
+				}
+			}
+			for _, c := range checks {
+				if controlMatches(ctl, c) && !slices.Contains(cr.CoveredBy, c.id) {
+					cr.CoveredBy = append(cr.CoveredBy, c.id)
+				}
+			}
+			for i, f := range findings {
+				if controlMatches(ctl, f) {
+					cr.Findings = append(cr.Findings, report.Findings[i])
+				}
+			}
+
+			switch {
+			case len(cr.Findings) > 0:
+				cr.Status = controlFailed
+			case len(cr.CoveredBy) > 0:
+				cr.Status = controlPassed
+			}
+			models.SortFindings(cr.Findings)
+			fr.Controls = append(fr.Controls, cr)
+		}
+		results = append(results, fr)
+	}
+	return results
+}
+
+func controlMatches(ctl config.ComplianceControl, s complianceSubject) bool {
+	for _, r := range ctl.Rules {
+		if prefix, ok := strings.CutSuffix(r, "*"); ok {
+			if strings.HasPrefix(strings.ToUpper(s.id), strings.ToUpper(prefix)) {
+				return true
+			}
+		} else if strings.EqualFold(r, s.id) {
+			return true
+		}
+	}
+	for _, t := range ctl.Tags {
+		if slices.ContainsFunc(s.tags, func(tag string) bool { return strings.EqualFold(tag, t) }) {
+			return true
+		}
+	}
+	for _, n := range ctl.CWEs {
+		if slices.Contains(s.cwes, strconv.Itoa(n)) {
+			return true
+		}
+	}
+	for _, c := range ctl.Categories {
+		if strings.EqualFold(c, s.category) {
+			return true
+		}
+	}
+	return slices.Contains(ctl.Scanners, s.scanner)
+}
+
+// abbreviate joins the first n items and counts the rest.
+func abbreviate(items []string, n int) string {
+	if len(items) <= n {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s, +%d more", strings.Join(items[:n], ", "), len(items)-n)
+}
+
+// mdCell escapes a value for a Markdown table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
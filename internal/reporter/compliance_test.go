@@ -0,0 +1,95 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+)
+
+func complianceReport() *models.ScanReport {
+	report := testReport()
+	report.ScannersRun = []string{"rules-scanner", "env-scanner"}
+	report.Checks = map[string][]models.Check{
+		"rules-scanner": {
+			{ID: "INJECT-001", Title: "Raw SQL", Tags: []string{"sqli", "owasp-a03", "cwe-89"}},
+			{ID: "SECRET-001", Title: "Hardcoded key", Tags: []string{"secrets", "cwe-798"}},
+		},
+		"env-scanner": {
+			{ID: "ENV-002", Title: "APP_DEBUG is enabled", Tags: []string{"cwe-215"}},
+		},
+	}
+	// Built-in checks tag the check, not the finding.
+	report.Findings = []models.Finding{
+		{ID: "ENV-002", Title: "APP_DEBUG is enabled", Severity: models.SeverityHigh, Scanner: "env-scanner", File: ".env", Line: 2},
+	}
+	return report
+}
+
+func TestEvaluateCompliance(t *testing.T) {
+	frameworks := []config.ComplianceFramework{{
+		ID: "test", Name: "Test",
+		Controls: []config.ComplianceControl{
+			{ID: "INJ", Tags: []string{"owasp-a03"}},
+			{ID: "DEBUG", CWEs: []int{215}},
+			{ID: "SECRETS", Rules: []string{"secret-*"}},
+			{ID: "DEPS", Scanners: []string{"dependency-scanner"}},
+			{ID: "MANUAL"},
+		},
+	}}
+
+	results := evaluateCompliance(complianceReport(), frameworks)
+	want := map[string]controlStatus{
+		"INJ":     controlPassed,
+		"DEBUG":   controlFailed,
+		"SECRETS": controlPassed,
+		"DEPS":    controlNotCovered,
+		"MANUAL":  controlNotCovered,
+	}
+	for _, cr := range results[0].Controls {
+		if cr.Status != want[cr.Control.ID] {
+			t.Errorf("%s = %v, want %v", cr.Control.ID, cr.Status, want[cr.Control.ID])
+		}
+	}
+	if debug := results[0].Controls[1]; len(debug.Findings) != 1 || debug.CoveredBy[0] != "ENV-002" {
+		t.Errorf("DEBUG = %+v", debug)
+	}
+
+	report := complianceReport()
+	report.ScannersRun = append(report.ScannersRun, "dependency-scanner")
+	if cr := evaluateCompliance(report, frameworks)[0].Controls[3]; cr.Status != controlPassed {
+		t.Errorf("DEPS = %v, want passed once dependency-scanner ran", cr.Status)
+	}
+}
+
+func TestComplianceReporter(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	frameworks, err := config.LoadCompliance()
+	if err != nil {
+		t.Fatalf("LoadCompliance() error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	r := NewComplianceReporter("", "1.0.0", frameworks)
+	if err := r.Render(context.Background(), &buf, complianceReport()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	content := buf.String()
+
+	for _, want := range []string{
+		"| OWASP Top 10 2021 | 10 | 2 | 1 | 7 |",
+		"| A03:2021 | Injection | ✅ Passed | INJECT-001 | 0 |",
+		"| A05:2021 | Security Misconfiguration | ❌ Failed | ENV-002 | 1 |",
+		"| A10:2021 | Server-Side Request Forgery | ➖ No coverage |  | 0 |",
+		"#### A05:2021 — Security Misconfiguration",
+		"**High** `ENV-002` APP_DEBUG is enabled — `.env:2`",
+		"## PCI DSS 4.0 Requirement 6",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("compliance report missing %q", want)
+		}
+	}
+}
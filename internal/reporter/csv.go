@@ -0,0 +1,98 @@
+package reporter
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// csvHeader lists the columns of ward-report.csv.
+var csvHeader = []string{
+	"fingerprint", "rule", "severity", "category", "scanner",
+	"file", "line", "title", "cwe", "remediation",
+}
+
+// CSVReporter writes ward-report.csv with one finding per row, for
+// triage in a spreadsheet.
+type CSVReporter struct {
+	OutputDir string
+}
+
+func NewCSVReporter(outputDir string) *CSVReporter {
+	if outputDir == "" {
+		outputDir = "."
+	}
+	return &CSVReporter{OutputDir: outputDir}
+}
+
+func (r *CSVReporter) Name() string   { return "csv" }
+func (r *CSVReporter) Format() string { return "csv" }
+
+// Generate writes ward-report.csv to OutputDir.
+func (r *CSVReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *CSVReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("writing CSV report: %w", err)
+	}
+	for _, f := range report.Findings {
+		if err := cw.Write(csvRow(f)); err != nil {
+			return fmt.Errorf("writing CSV report: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("writing CSV report: %w", err)
+	}
+	return nil
+}
+
+func csvRow(f models.Finding) []string {
+	var cwes []string
+	for _, n := range findingTaxonomy(f).CWEs {
+		cwes = append(cwes, "CWE-"+n)
+	}
+
+	line := ""
+	if f.Line > 0 {
+		line = strconv.Itoa(f.Line)
+	}
+
+	row := []string{
+		f.Fingerprint(), f.ID, f.Severity.String(), f.Category, f.Scanner,
+		f.File, line, f.Title, strings.Join(cwes, ";"), f.Remediation,
+	}
+	for i := range row {
+		row[i] = csvSafe(row[i])
+	}
+	return row
+}
+
+// csvSafe neutralises values a spreadsheet would evaluate as a formula
+// (CSV injection) by prefixing them with a single quote. Spreadsheets skip
+// leading whitespace, so the first other character is checked too.
+func csvSafe(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '\t', '\r', '\n':
+		return "'" + s
+	}
+	if t := strings.TrimLeft(s, " \t\r\n"); t != "" {
+		switch t[0] {
+		case '=', '+', '-', '@':
+			return "'" + s
+		}
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package reporter
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCSVReporter(t *testing.T) {
+	dir := t.TempDir()
+	r := NewCSVReporter(dir)
+
+	report := testReport()
+	report.Findings[0].Tags = []string{"cwe-798"}
+	report.Findings[1].Title = "=HYPERLINK(\"https://evil.example\",\"click\")"
+	report.Findings[1].Remediation = "@SUM(A1:A2)"
+	report.Findings[1].File = "  =cmd|' /C calc'!A0"
+
+	if err := r.Generate(context.Background(), report); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "ward-report.csv"))
+	if err != nil {
+		t.Fatalf("opening CSV report: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header + 2 rows, got %d", len(rows))
+	}
+	if rows[0][0] != "fingerprint" || rows[0][8] != "cwe" {
+		t.Errorf("header = %v", rows[0])
+	}
+
+	first := rows[1]
+	want := []string{
+		report.Findings[0].Fingerprint(), "TEST-001", "Critical", "Test", "test-scanner",
+		"app/Test.php", "42", "Test Critical Finding", "CWE-798", "Fix it.",
+	}
+	for i := range want {
+		if first[i] != want[i] {
+			t.Errorf("column %s = %q, want %q", rows[0][i], first[i], want[i])
+		}
+	}
+
+	if got := rows[2][7]; got != "'=HYPERLINK(\"https://evil.example\",\"click\")" {
+		t.Errorf("formula title not escaped: %q", got)
+	}
+	if got := rows[2][9]; got != "'@SUM(A1:A2)" {
+		t.Errorf("formula remediation not escaped: %q", got)
+	}
+	if got := rows[2][5]; got != "'  =cmd|' /C calc'!A0" {
+		t.Errorf("formula after leading spaces not escaped: %q", got)
+	}
+}
@@ -0,0 +1,174 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// Diff compares two scans of a project. Findings are matched across the
+// scans by rule ID, file and line.
+type Diff struct {
+	Before, After           *models.ScanReport
+	BeforeLabel, AfterLabel string // how to name each side, such as a file name or scan ID
+}
+
+// Compare returns the findings only in After (added), only in Before
+// (resolved) and in both (unchanged, as reported in After), each sorted
+// by severity.
+func (d Diff) Compare() (added, resolved, unchanged []models.Finding) {
+	return models.CompareFindings(d.Before.Findings, d.After.Findings)
+}
+
+// RenderMarkdown writes the diff as Markdown for a pull request comment.
+// Unchanged findings are folded into a <details> block.
+func (d Diff) RenderMarkdown(w io.Writer) error {
+	added, resolved, unchanged := d.Compare()
+
+	var sb strings.Builder
+
+	sb.WriteString("## Ward Security Diff\n\n")
+	if project := d.project(); project != "" {
+		sb.WriteString(fmt.Sprintf("**Project:** %s  \n", project))
+	}
+	sb.WriteString(fmt.Sprintf("**Compared:** %s → %s\n\n", mdCell(d.BeforeLabel), mdCell(d.AfterLabel)))
+
+	sb.WriteString(fmt.Sprintf("**%d new**, %d resolved, %d unchanged\n\n", len(added), len(resolved), len(unchanged)))
+	sb.WriteString("| Severity | Before | After | Change |\n")
+	sb.WriteString("|----------|--------|-------|--------|\n")
+	beforeCounts, afterCounts := d.Before.CountBySeverity(), d.After.CountBySeverity()
+	for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+		b, a := beforeCounts[sev], afterCounts[sev]
+		if b == 0 && a == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("| %s %s | %d | %d | %s |\n", severityEmoji(sev), sev, b, a, signed(a-b)))
+	}
+	b, a := len(d.Before.Findings), len(d.After.Findings)
+	sb.WriteString(fmt.Sprintf("| **Total** | %d | %d | %s |\n\n", b, a, signed(a-b)))
+
+	writeMarkdownDiffGroup(&sb, "### New findings", added)
+	writeMarkdownDiffGroup(&sb, "### Resolved findings", resolved)
+	if len(unchanged) > 0 {
+		sb.WriteString(fmt.Sprintf("<details>\n<summary>Unchanged findings (%d)</summary>\n\n", len(unchanged)))
+		writeMarkdownDiffGroup(&sb, "", unchanged)
+		sb.WriteString("</details>\n\n")
+	}
+
+	sb.WriteString("*Generated by [Ward](https://github.com/Eljakani/ward)*\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("writing Markdown diff: %w", err)
+	}
+	return nil
+}
+
+// writeMarkdownDiffGroup writes findings under heading, with a
+// sub-heading per severity. An empty heading writes the findings only.
+func writeMarkdownDiffGroup(sb *strings.Builder, heading string, findings []models.Finding) {
+	if heading != "" {
+		sb.WriteString(fmt.Sprintf("%s (%d)\n\n", heading, len(findings)))
+		if len(findings) == 0 {
+			sb.WriteString("None.\n\n")
+			return
+		}
+	}
+	for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+		sevFindings := filterFindings(findings, sev)
+		if len(sevFindings) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("**%s %s (%d)**\n\n", severityEmoji(sev), sev, len(sevFindings)))
+		for _, f := range sevFindings {
+			loc := f.File
+			if f.Line > 0 {
+				loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+			}
+			lines := []string{fmt.Sprintf("`%s` %s — `%s`", f.ID, f.Title, loc)}
+			if f.Description != "" {
+				lines = append(lines, strings.ReplaceAll(f.Description, "\n", " "))
+			}
+			if f.Remediation != "" {
+				lines = append(lines, "**Remediation:** "+strings.ReplaceAll(f.Remediation, "\n", " "))
+			}
+			// Trailing double spaces keep the lines apart within the item.
+			sb.WriteString("- " + strings.Join(lines, "  \n  ") + "\n")
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// jsonDiff is the JSON output structure of a diff.
+type jsonDiff struct {
+	Before    jsonDiffSide  `json:"before"`
+	After     jsonDiffSide  `json:"after"`
+	Summary   jsonDiffStats `json:"summary"`
+	New       []jsonFinding `json:"new"`
+	Resolved  []jsonFinding `json:"resolved"`
+	Unchanged []jsonFinding `json:"unchanged"`
+}
+
+type jsonDiffSide struct {
+	Label         string         `json:"label"`
+	Project       string         `json:"project"`
+	ScannedAt     string         `json:"scanned_at,omitempty"`
+	TotalFindings int            `json:"total_findings"`
+	BySeverity    map[string]int `json:"by_severity"`
+}
+
+type jsonDiffStats struct {
+	New       int `json:"new"`
+	Resolved  int `json:"resolved"`
+	Unchanged int `json:"unchanged"`
+}
+
+// RenderJSON writes the diff as JSON, with findings in the format of the
+// JSON report.
+func (d Diff) RenderJSON(w io.Writer) error {
+	added, resolved, unchanged := d.Compare()
+	jd := jsonDiff{
+		Before:    toJSONDiffSide(d.BeforeLabel, d.Before),
+		After:     toJSONDiffSide(d.AfterLabel, d.After),
+		Summary:   jsonDiffStats{New: len(added), Resolved: len(resolved), Unchanged: len(unchanged)},
+		New:       toJSONFindings(added),
+		Resolved:  toJSONFindings(resolved),
+		Unchanged: toJSONFindings(unchanged),
+	}
+
+	data, err := json.MarshalIndent(jd, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling diff: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing diff: %w", err)
+	}
+	return nil
+}
+
+func toJSONDiffSide(label string, report *models.ScanReport) jsonDiffSide {
+	side := jsonDiffSide{
+		Label:         label,
+		Project:       report.ProjectContext.ProjectName,
+		TotalFindings: len(report.Findings),
+		BySeverity:    make(map[string]int),
+	}
+	if !report.CompletedAt.IsZero() {
+		side.ScannedAt = report.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	for sev, count := range report.CountBySeverity() {
+		side.BySeverity[sev.String()] = count
+	}
+	return side
+}
+
+// project names the project the diff is about.
+func (d Diff) project() string {
+	if name := d.After.ProjectContext.ProjectName; name != "" {
+		return name
+	}
+	return d.Before.ProjectContext.ProjectName
+}
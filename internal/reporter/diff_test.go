@@ -0,0 +1,65 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+func testDiff() Diff {
+	before := testReport()
+	after := testReport()
+	after.Findings = []models.Finding{
+		before.Findings[0],
+		{ID: "TEST-003", Title: "Test New Finding", Description: "Added in v2.", Remediation: "Remove it.", Severity: models.SeverityHigh, Category: "Test", Scanner: "test-scanner", File: "routes/web.php", Line: 3},
+	}
+	return Diff{Before: before, After: after, BeforeLabel: "v1.json", AfterLabel: "v2.json"}
+}
+
+func TestDiff_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testDiff().RenderMarkdown(&buf); err != nil {
+		t.Fatalf("RenderMarkdown() error = %v", err)
+	}
+	content := buf.String()
+
+	for _, want := range []string{
+		"**Compared:** v1.json → v2.json",
+		"**1 new**, 1 resolved, 1 unchanged",
+		"| 🟠 High | 0 | 1 | +1 |",
+		"### New findings (1)\n\n**🟠 High (1)**\n\n- `TEST-003` Test New Finding — `routes/web.php:3`  \n  Added in v2.  \n  **Remediation:** Remove it.\n",
+		"### Resolved findings (1)",
+		"<summary>Unchanged findings (1)</summary>",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Markdown diff missing %q", want)
+		}
+	}
+	if strings.Index(content, "Test New Finding") > strings.Index(content, "Resolved findings") {
+		t.Error("new findings should come before resolved ones")
+	}
+}
+
+func TestDiff_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testDiff().RenderJSON(&buf); err != nil {
+		t.Fatalf("RenderJSON() error = %v", err)
+	}
+
+	var got jsonDiff
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Summary != (jsonDiffStats{New: 1, Resolved: 1, Unchanged: 1}) {
+		t.Errorf("summary = %+v", got.Summary)
+	}
+	if got.Before.Label != "v1.json" || got.After.TotalFindings != 2 {
+		t.Errorf("sides = %+v, %+v", got.Before, got.After)
+	}
+	if len(got.New) != 1 || got.New[0].ID != "TEST-003" || got.New[0].Severity != "High" {
+		t.Errorf("new = %+v", got.New)
+	}
+}
@@ -0,0 +1,318 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// gitlabSASTVersion is the security report schema version the SAST
+// report declares.
+const gitlabSASTVersion = "15.0.7"
+
+// GitLabSASTReporter generates gl-sast-report.json in GitLab's security
+// report format, shown in the merge request security widget.
+type GitLabSASTReporter struct {
+	OutputDir string
+	Version   string
+}
+
+func NewGitLabSASTReporter(outputDir string, version string) *GitLabSASTReporter {
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if version == "" {
+		version = "dev"
+	}
+	return &GitLabSASTReporter{OutputDir: outputDir, Version: version}
+}
+
+func (r *GitLabSASTReporter) Name() string     { return "gitlab-sast" }
+func (r *GitLabSASTReporter) Format() string   { return "json" }
+func (r *GitLabSASTReporter) FileName() string { return "gl-sast-report.json" }
+
+// Generate writes gl-sast-report.json to OutputDir.
+func (r *GitLabSASTReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *GitLabSASTReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
+	return writeJSON(w, r.build(report))
+}
+
+func (r *GitLabSASTReporter) build(report *models.ScanReport) gitlabSASTReport {
+	tool := gitlabTool{
+		ID:      "ward",
+		Name:    "Ward",
+		Version: r.Version,
+		URL:     "https://github.com/eljakani/ward",
+		Vendor:  gitlabVendor{Name: "Ward"},
+	}
+
+	scan := gitlabScan{
+		Analyzer:  tool,
+		Scanner:   tool,
+		Type:      "sast",
+		StartTime: report.StartedAt.UTC().Format(gitlabTimeLayout),
+		EndTime:   report.CompletedAt.UTC().Format(gitlabTimeLayout),
+		Status:    "success",
+	}
+
+	// A scanner failing doesn't invalidate the others' results; GitLab
+	// shows these messages alongside the report.
+	var failed []string
+	for name := range report.ScannerErrors {
+		failed = append(failed, name)
+	}
+	sort.Strings(failed)
+	for _, name := range failed {
+		scan.Messages = append(scan.Messages, gitlabMessage{
+			Level: "warn",
+			Value: fmt.Sprintf("%s failed: %s", name, report.ScannerErrors[name]),
+		})
+	}
+	if len(report.ScannersRun) == 0 && len(failed) > 0 {
+		scan.Status = "failure"
+	}
+
+	vulns := make([]gitlabVulnerability, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		v := gitlabVulnerability{
+			ID:          f.Fingerprint(),
+			Name:        gitlabName(f.Title),
+			Description: f.Description,
+			Severity:    gitlabSeverity(f.Severity),
+			Solution:    f.Remediation,
+			Location:    gitlabLocation{File: gitlabPath(f.File)},
+			Identifiers: gitlabIdentifiers(f),
+		}
+		if f.Line > 0 {
+			v.Location.StartLine = f.Line
+			v.Location.EndLine = f.Line
+		}
+		for _, ref := range f.References {
+			v.Links = append(v.Links, gitlabLink{URL: ref})
+		}
+		vulns = append(vulns, v)
+	}
+
+	return gitlabSASTReport{
+		Version:         gitlabSASTVersion,
+		Scan:            scan,
+		Vulnerabilities: vulns,
+	}
+}
+
+// gitlabIdentifiers lists the rule ID first (GitLab uses the first
+// identifier as the primary one), then any CWE and OWASP entries.
+func gitlabIdentifiers(f models.Finding) []gitlabIdentifier {
+	ids := []gitlabIdentifier{{
+		Type:  "ward_rule_id",
+		Name:  "Ward " + f.ID,
+		Value: f.ID,
+	}}
+	t := findingTaxonomy(f)
+	for _, n := range t.CWEs {
+		ids = append(ids, gitlabIdentifier{Type: "cwe", Name: "CWE-" + n, Value: n, URL: cweURL(n)})
+	}
+	for _, id := range t.OWASP {
+		ids = append(ids, gitlabIdentifier{Type: "owasp", Name: owaspName(id), Value: id})
+	}
+	return ids
+}
+
+// gitlabName caps a title at the schema's 255-character limit.
+func gitlabName(title string) string {
+	if r := []rune(title); len(r) > 255 {
+		return string(r[:254]) + "…"
+	}
+	return title
+}
+
+func gitlabSeverity(s models.Severity) string {
+	switch s {
+	case models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo:
+		return s.String()
+	default:
+		return "Unknown"
+	}
+}
+
+// GitLabCodeQualityReporter generates gl-code-quality-report.json, the
+// Code Climate issue format GitLab uses for the code quality widget.
+type GitLabCodeQualityReporter struct {
+	OutputDir string
+}
+
+func NewGitLabCodeQualityReporter(outputDir string) *GitLabCodeQualityReporter {
+	if outputDir == "" {
+		outputDir = "."
+	}
+	return &GitLabCodeQualityReporter{OutputDir: outputDir}
+}
+
+func (r *GitLabCodeQualityReporter) Name() string     { return "gitlab-codequality" }
+func (r *GitLabCodeQualityReporter) Format() string   { return "json" }
+func (r *GitLabCodeQualityReporter) FileName() string { return "gl-code-quality-report.json" }
+
+// Generate writes gl-code-quality-report.json to OutputDir.
+func (r *GitLabCodeQualityReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *GitLabCodeQualityReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
+	return writeJSON(w, r.build(report))
+}
+
+func (r *GitLabCodeQualityReporter) build(report *models.ScanReport) []codeQualityIssue {
+	issues := make([]codeQualityIssue, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		line := f.Line
+		if line < 1 {
+			line = 1
+		}
+		issue := codeQualityIssue{
+			Type:        "issue",
+			CheckName:   f.ID,
+			Description: f.ID + ": " + f.Title,
+			Categories:  []string{"Security"},
+			Fingerprint: f.Fingerprint(),
+			Severity:    codeQualitySeverity(f.Severity),
+			Location: codeQualityLocation{
+				Path:  gitlabPath(f.File),
+				Lines: codeQualityLines{Begin: line},
+			},
+		}
+		if f.Remediation != "" {
+			issue.Content = &codeQualityContent{Body: f.Remediation}
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+func codeQualitySeverity(s models.Severity) string {
+	switch s {
+	case models.SeverityCritical:
+		return "blocker"
+	case models.SeverityHigh:
+		return "critical"
+	case models.SeverityMedium:
+		return "major"
+	case models.SeverityLow:
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// gitlabPath returns a repository-relative, slash-separated path. Findings
+// without a file point at the project root.
+func gitlabPath(file string) string {
+	if file == "" {
+		return "."
+	}
+	return filepath.ToSlash(file)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+const gitlabTimeLayout = "2006-01-02T15:04:05"
+
+// GitLab security report structures (security-report-schemas, SAST).
+
+type gitlabSASTReport struct {
+	Version         string                `json:"version"`
+	Scan            gitlabScan            `json:"scan"`
+	Vulnerabilities []gitlabVulnerability `json:"vulnerabilities"`
+}
+
+type gitlabScan struct {
+	Analyzer  gitlabTool      `json:"analyzer"`
+	Scanner   gitlabTool      `json:"scanner"`
+	Type      string          `json:"type"`
+	StartTime string          `json:"start_time"`
+	EndTime   string          `json:"end_time"`
+	Status    string          `json:"status"`
+	Messages  []gitlabMessage `json:"messages,omitempty"`
+}
+
+type gitlabTool struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	Version string       `json:"version"`
+	URL     string       `json:"url,omitempty"`
+	Vendor  gitlabVendor `json:"vendor"`
+}
+
+type gitlabVendor struct {
+	Name string `json:"name"`
+}
+
+type gitlabMessage struct {
+	Level string `json:"level"`
+	Value string `json:"value"`
+}
+
+type gitlabVulnerability struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Severity    string             `json:"severity"`
+	Solution    string             `json:"solution,omitempty"`
+	Location    gitlabLocation     `json:"location"`
+	Identifiers []gitlabIdentifier `json:"identifiers"`
+	Links       []gitlabLink       `json:"links,omitempty"`
+}
+
+type gitlabLocation struct {
+	File      string `json:"file"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+type gitlabIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type gitlabLink struct {
+	URL string `json:"url"`
+}
+
+// Code Climate issue structures, as consumed by GitLab Code Quality.
+
+type codeQualityIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Content     *codeQualityContent `json:"content,omitempty"`
+	Categories  []string            `json:"categories"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeQualityLocation `json:"location"`
+}
+
+type codeQualityContent struct {
+	Body string `json:"body"`
+}
+
+type codeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines codeQualityLines `json:"lines"`
+}
+
+type codeQualityLines struct {
+	Begin int `json:"begin"`
+}
@@ -0,0 +1,168 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eljakani/ward/internal/models"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// validateAgainstPublished checks the JSON file at path against GitLab's
+// published schema, vendored unmodified into testdata/gitlab by
+// `make gitlab-schemas`.
+func validateAgainstPublished(t *testing.T, schemaFile, path string) {
+	t.Helper()
+
+	schema := filepath.Join("testdata", "gitlab", schemaFile)
+	if _, err := os.Stat(schema); err != nil {
+		t.Fatalf("%s is not vendored, run make gitlab-schemas: %v", schemaFile, err)
+	}
+	c := jsonschema.NewCompiler()
+	sch, err := c.Compile(schema)
+	if err != nil {
+		t.Fatalf("compiling %s: %v", schemaFile, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading report: %v", err)
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("report is not valid JSON: %v", err)
+	}
+	if err := sch.Validate(doc); err != nil {
+		t.Errorf("report does not match %s:\n%v", schemaFile, err)
+	}
+}
+
+func gitlabTestReport() *models.ScanReport {
+	report := testReport()
+	report.Findings[0].Tags = []string{"secrets", "owasp-a07", "cwe-798"}
+	report.Findings[1].References = []string{"https://cwe.mitre.org/data/definitions/614.html"}
+	report.Findings = append(report.Findings, models.Finding{
+		ID:       "ENV-001",
+		Title:    "No .env file found",
+		Severity: models.SeverityInfo,
+		Category: "Configuration",
+		Scanner:  "env-scanner",
+	})
+	report.ScannerErrors = map[string]string{"dependency-scanner": "OSV.dev unreachable"}
+	return report
+}
+
+func TestGitLabSASTReporter(t *testing.T) {
+	dir := t.TempDir()
+	r := NewGitLabSASTReporter(dir, "1.0.0")
+
+	if FileName(r) != "gl-sast-report.json" {
+		t.Errorf("FileName() = %q", FileName(r))
+	}
+
+	report := gitlabTestReport()
+	if err := r.Generate(context.Background(), report); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	path := filepath.Join(dir, "gl-sast-report.json")
+	validateAgainstPublished(t, "sast-report-format.json", path)
+
+	data, _ := os.ReadFile(path)
+	var out gitlabSASTReport
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(out.Vulnerabilities) != 3 {
+		t.Fatalf("expected 3 vulnerabilities, got %d", len(out.Vulnerabilities))
+	}
+	if len(out.Scan.Messages) != 1 || out.Scan.Status != "success" {
+		t.Errorf("scan = %+v, want success with one warning", out.Scan)
+	}
+
+	v := out.Vulnerabilities[0]
+	if v.ID != report.Findings[0].Fingerprint() {
+		t.Errorf("id = %q, want the finding fingerprint", v.ID)
+	}
+	if v.Severity != "Critical" || v.Location.File != "app/Test.php" || v.Location.StartLine != 42 {
+		t.Errorf("vulnerability = %+v", v)
+	}
+
+	want := []gitlabIdentifier{
+		{Type: "ward_rule_id", Name: "Ward TEST-001", Value: "TEST-001"},
+		{Type: "cwe", Name: "CWE-798", Value: "798", URL: "https://cwe.mitre.org/data/definitions/798.html"},
+		{Type: "owasp", Name: "A07:2021 - Identification and Authentication Failures", Value: "A07:2021"},
+	}
+	if len(v.Identifiers) != len(want) {
+		t.Fatalf("identifiers = %+v, want %+v", v.Identifiers, want)
+	}
+	for i := range want {
+		if v.Identifiers[i] != want[i] {
+			t.Errorf("identifier %d = %+v, want %+v", i, v.Identifiers[i], want[i])
+		}
+	}
+
+	if ids := out.Vulnerabilities[1].Identifiers; len(ids) != 2 || ids[1].Value != "614" {
+		t.Errorf("CWE from reference URL missing: %+v", ids)
+	}
+	if loc := out.Vulnerabilities[2].Location; loc.File != "." || loc.StartLine != 0 {
+		t.Errorf("finding without a file should point at the root, got %+v", loc)
+	}
+}
+
+func TestGitLabCodeQualityReporter(t *testing.T) {
+	dir := t.TempDir()
+	r := NewGitLabCodeQualityReporter(dir)
+
+	report := gitlabTestReport()
+	if err := r.Generate(context.Background(), report); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	path := filepath.Join(dir, "gl-code-quality-report.json")
+	validateAgainstPublished(t, "code-quality-report-format.json", path)
+
+	data, _ := os.ReadFile(path)
+	var issues []codeQualityIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+	wantSeverity := []string{"blocker", "minor", "info"}
+	for i, issue := range issues {
+		if issue.Severity != wantSeverity[i] {
+			t.Errorf("issue %d severity = %q, want %q", i, issue.Severity, wantSeverity[i])
+		}
+		if issue.Fingerprint != report.Findings[i].Fingerprint() {
+			t.Errorf("issue %d fingerprint = %q, want the finding fingerprint", i, issue.Fingerprint)
+		}
+	}
+	if issues[2].Location.Lines.Begin != 1 {
+		t.Errorf("file-level finding should start at line 1, got %d", issues[2].Location.Lines.Begin)
+	}
+}
+
+func TestFindingTaxonomy(t *testing.T) {
+	f := models.Finding{
+		Tags: []string{"sqli", "OWASP-A3", "cwe-89", "cwe-089"},
+		References: []string{
+			"https://owasp.org/Top10/A05_2021-Security_Misconfiguration/",
+			"https://cwe.mitre.org/data/definitions/89.html",
+		},
+	}
+	tx := findingTaxonomy(f)
+	if len(tx.CWEs) != 1 || tx.CWEs[0] != "89" {
+		t.Errorf("CWEs = %v, want [89]", tx.CWEs)
+	}
+	if len(tx.OWASP) != 2 || tx.OWASP[0] != "A03:2021" || tx.OWASP[1] != "A05:2021" {
+		t.Errorf("OWASP = %v, want [A03:2021 A05:2021]", tx.OWASP)
+	}
+}
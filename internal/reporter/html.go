@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"html"
-	"os"
+	"io"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/eljakani/ward/internal/models"
@@ -27,7 +29,12 @@ func NewHTMLReporter(outputDir string) *HTMLReporter {
 func (r *HTMLReporter) Name() string   { return "html" }
 func (r *HTMLReporter) Format() string { return "html" }
 
-func (r *HTMLReporter) Generate(_ context.Context, report *models.ScanReport) error {
+// Generate writes ward-report.html to OutputDir.
+func (r *HTMLReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *HTMLReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
 	counts := report.CountBySeverity()
 	byCategory := report.FindingsByCategory()
 
@@ -72,12 +79,17 @@ func (r *HTMLReporter) Generate(_ context.Context, report *models.ScanReport) er
     <div class="toc-title">Report</div>
     <a href="#overview" class="toc-link">Overview</a>
     <a href="#project" class="toc-link">Project Info</a>
-    <div class="toc-title" style="margin-top:20px;">Findings</div>
+`)
+	if report.Changes != nil {
+		sb.WriteString(fmt.Sprintf(`    <a href="#changes" class="toc-link" data-tab="changes"><span>Changes</span><span class="toc-count">+%d / −%d</span></a>
+`, len(report.Changes.New), len(report.Changes.Resolved)))
+	}
+	sb.WriteString(`    <div class="toc-title" style="margin-top:20px;">Findings</div>
 `)
 	for _, cat := range categories {
 		slug := categorySlug(cat)
 		count := len(byCategory[cat])
-		sb.WriteString(fmt.Sprintf(`    <a href="#cat-%s" class="toc-link"><span>%s</span><span class="toc-count">%d</span></a>
+		sb.WriteString(fmt.Sprintf(`    <a href="#cat-%s" class="toc-link" data-tab="findings"><span>%s</span><span class="toc-count">%d</span></a>
 `, slug, esc(cat), count))
 	}
 	sb.WriteString(`  </nav>
@@ -163,7 +175,19 @@ func (r *HTMLReporter) Generate(_ context.Context, report *models.ScanReport) er
 </section>
 `)
 
+	// ── Filters and tabs ──
+	writeFilterBar(&sb, report.Findings, report.Changes)
+	if report.Changes != nil {
+		sb.WriteString(fmt.Sprintf(`<nav class="tabs" hidden>
+  <button type="button" class="tab active" data-tab="findings">All findings <span class="toc-count">%d</span></button>
+  <button type="button" class="tab" data-tab="changes">Changes since last scan <span class="toc-count">+%d / −%d</span></button>
+</nav>
+`, total, len(report.Changes.New), len(report.Changes.Resolved)))
+	}
+
 	// ── Findings by category ──
+	sb.WriteString(`<div class="tab-panel" data-panel="findings">
+`)
 	for _, cat := range categories {
 		slug := categorySlug(cat)
 		findings := byCategory[cat]
@@ -173,7 +197,7 @@ func (r *HTMLReporter) Generate(_ context.Context, report *models.ScanReport) er
 			return findings[i].Severity.Weight() > findings[j].Severity.Weight()
 		})
 
-		sb.WriteString(fmt.Sprintf(`<section id="cat-%s" class="section">
+		sb.WriteString(fmt.Sprintf(`<section id="cat-%s" class="section" data-group>
   <div class="cat-header">
     <h2 class="section-title">%s</h2>
     <span class="cat-count">%d finding%s</span>
@@ -181,74 +205,241 @@ func (r *HTMLReporter) Generate(_ context.Context, report *models.ScanReport) er
 `, slug, esc(cat), len(findings), plural(len(findings))))
 
 		for idx, f := range findings {
-			sevClass := strings.ToLower(f.Severity.String())
-			findingID := fmt.Sprintf("%s-%d", slug, idx)
+			writeFinding(&sb, f, fmt.Sprintf("%s-%d", slug, idx), true)
+		}
+
+		sb.WriteString(`</section>
+`)
+	}
+	sb.WriteString(`</div>
+`)
+
+	if report.Changes != nil {
+		writeChanges(&sb, report.Changes)
+	}
+	sb.WriteString(`<p class="no-match" hidden>No findings match the current filters.</p>
+`)
+
+	// ── Footer ──
+	sb.WriteString(`<footer class="report-footer">
+  <p>Generated by <a href="https://github.com/Eljakani/ward" target="_blank" rel="noopener">Ward</a> v0.2.0</p>
+</footer>
+</main>
+<script>
+`)
+	sb.WriteString(htmlJS)
+	sb.WriteString(`</script>
+</body>
+</html>`)
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("writing HTML report: %w", err)
+	}
 
-			sb.WriteString(fmt.Sprintf(`  <details class="finding" id="%s">
+	return nil
+}
+
+func esc(s string) string {
+	return html.EscapeString(s)
+}
+
+// writeFinding writes f as a collapsible card. filterable cards carry
+// the data attributes the filter bar matches on; side-by-side diff rows
+// carry them on the row instead.
+func writeFinding(sb *strings.Builder, f models.Finding, id string, filterable bool) {
+	sevClass := strings.ToLower(f.Severity.String())
+	attrs := ""
+	if filterable {
+		attrs = " " + filterAttrs(f)
+	}
+	loc := f.File
+	if f.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+
+	sb.WriteString(fmt.Sprintf(`  <details class="finding" id="%s"%s>
     <summary class="finding-summary">
       <span class="badge %s">%s</span>
       <span class="finding-title">%s</span>
       <span class="finding-id">%s</span>
-      <span class="finding-loc">%s:%d</span>
+      <span class="finding-loc">%s</span>
       <svg class="chevron" width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2"><polyline points="6 9 12 15 18 9"/></svg>
     </summary>
     <div class="finding-body">
-`, findingID, sevClass, f.Severity.String(), esc(f.Title), esc(f.ID), esc(f.File), f.Line))
+`, id, attrs, sevClass, f.Severity.String(), esc(f.Title), esc(f.ID), esc(loc)))
 
-			sb.WriteString(fmt.Sprintf(`      <p class="finding-desc">%s</p>
+	if f.Description != "" {
+		sb.WriteString(fmt.Sprintf(`      <p class="finding-desc">%s</p>
 `, esc(f.Description)))
+	}
+
+	if f.Lifecycle != nil {
+		sb.WriteString(fmt.Sprintf(`      <p class="finding-age">Age: %s</p>
+`, esc(f.Lifecycle.String())))
+	}
 
-			if f.CodeSnippet != "" {
-				sb.WriteString(fmt.Sprintf(`      <pre class="finding-code">%s</pre>
+	if f.Context != nil {
+		sb.WriteString(`      <pre class="finding-code">` + htmlContext(f.Line, f.Context) + "</pre>\n")
+	} else if f.CodeSnippet != "" {
+		sb.WriteString(fmt.Sprintf(`      <pre class="finding-code">%s</pre>
 `, esc(f.CodeSnippet)))
+	}
+
+	if len(f.Related) > 0 {
+		sb.WriteString(`      <div class="finding-related">
+        <div class="fix-label">Related locations</div>
+`)
+		for _, r := range f.Related {
+			note := ""
+			if r.Note != "" {
+				note = fmt.Sprintf(` <span class="related-note">%s</span>`, esc(r.Note))
 			}
+			sb.WriteString(fmt.Sprintf(`        <div><span class="finding-loc">%s:%d</span>%s <code>%s</code></div>
+`, esc(r.File), r.Line, note, esc(r.Snippet)))
+		}
+		sb.WriteString(`      </div>
+`)
+	}
 
-			if f.Remediation != "" {
-				sb.WriteString(fmt.Sprintf(`      <div class="finding-fix">
+	if f.Remediation != "" {
+		sb.WriteString(fmt.Sprintf(`      <div class="finding-fix">
         <div class="fix-label">Remediation</div>
         <p>%s</p>
       </div>
 `, esc(f.Remediation)))
-			}
+	}
 
-			if len(f.References) > 0 {
-				sb.WriteString(`      <div class="finding-refs">
+	if len(f.References) > 0 {
+		sb.WriteString(`      <div class="finding-refs">
 `)
-				for _, ref := range f.References {
-					sb.WriteString(fmt.Sprintf(`        <a href="%s" target="_blank" rel="noopener">%s</a>
+		for _, ref := range f.References {
+			sb.WriteString(fmt.Sprintf(`        <a href="%s" target="_blank" rel="noopener">%s</a>
 `, esc(ref), esc(ref)))
-				}
-				sb.WriteString(`      </div>
+		}
+		sb.WriteString(`      </div>
 `)
-			}
+	}
 
-			sb.WriteString(`    </div>
+	sb.WriteString(`    </div>
   </details>
 `)
+}
+
+// filterAttrs returns the data attributes htmlJS filters on.
+func filterAttrs(f models.Finding) string {
+	return fmt.Sprintf(`data-filter data-severity="%s" data-category="%s" data-scanner="%s" data-file="%s"`,
+		esc(f.Severity.String()), esc(f.Category), esc(f.Scanner), esc(f.File))
+}
+
+// writeFilterBar writes the search box and the severity, category,
+// scanner and file filters, offering the values present in findings and
+// changes. It stays hidden until htmlJS runs, so pages opened without
+// JavaScript show every finding.
+func writeFilterBar(sb *strings.Builder, findings []models.Finding, changes *models.ScanChanges) {
+	all := findings
+	if changes != nil {
+		all = append(append(slices.Clone(findings), changes.New...), changes.Resolved...)
+	}
+
+	severities := make(map[string]bool)
+	categories := make(map[string]bool)
+	scanners := make(map[string]bool)
+	for _, f := range all {
+		severities[f.Severity.String()] = true
+		if f.Category != "" {
+			categories[f.Category] = true
+		}
+		if f.Scanner != "" {
+			scanners[f.Scanner] = true
 		}
+	}
 
-		sb.WriteString(`</section>
+	sb.WriteString(`<div class="filters" hidden>
+  <input type="search" class="filter-input" data-filter-search placeholder="Search findings…" aria-label="Search findings">
 `)
+	var sevOptions []string
+	for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+		if severities[sev.String()] {
+			sevOptions = append(sevOptions, sev.String())
+		}
 	}
+	writeFilterSelect(sb, "severity", "All severities", sevOptions)
+	writeFilterSelect(sb, "category", "All categories", sortedKeys(categories))
+	writeFilterSelect(sb, "scanner", "All scanners", sortedKeys(scanners))
+	sb.WriteString(`  <input type="text" class="filter-input" data-filter-file placeholder="File path" aria-label="Filter by file">
+  <span class="filter-count" data-filter-count></span>
+</div>
+`)
+}
 
-	// ── Footer ──
-	sb.WriteString(`<footer class="report-footer">
-  <p>Generated by <a href="https://github.com/Eljakani/ward" target="_blank" rel="noopener">Ward</a> v0.2.0</p>
-</footer>
-</main>
-</body>
-</html>`)
+func writeFilterSelect(sb *strings.Builder, attr, label string, options []string) {
+	sb.WriteString(fmt.Sprintf(`  <select class="filter-select" data-filter-by="%s" aria-label="%s">
+    <option value="">%s</option>
+`, attr, label, label))
+	for _, o := range options {
+		sb.WriteString(fmt.Sprintf(`    <option value="%s">%s</option>
+`, esc(o), esc(o)))
+	}
+	sb.WriteString(`  </select>
+`)
+}
+
+// writeChanges writes the "changes since last scan" panel.
+func writeChanges(sb *strings.Builder, c *models.ScanChanges) {
+	sb.WriteString(fmt.Sprintf(`<div class="tab-panel" data-panel="changes">
+<section id="changes" class="section">
+  <h2 class="section-title">Changes Since Last Scan</h2>
+  <p class="header-meta">Compared with the scan of %s: %d → %d findings.</p>
+</section>
+`, c.PreviousAt.Local().Format("2006-01-02 15:04"), c.TotalBefore, c.TotalBefore+len(c.New)-len(c.Resolved)))
 
-	outPath := filepath.Join(r.OutputDir, "ward-report.html")
-	if err := os.WriteFile(outPath, []byte(sb.String()), 0644); err != nil {
-		return fmt.Errorf("writing HTML report to %s: %w", outPath, err)
+	sb.WriteString(fmt.Sprintf(`<section id="changes-new" class="section" data-group>
+  <div class="cat-header">
+    <h2 class="section-title">New</h2>
+    <span class="cat-count">%d finding%s</span>
+  </div>
+`, len(c.New), plural(len(c.New))))
+	if len(c.New) == 0 {
+		sb.WriteString(`  <p class="empty">No new findings.</p>
+`)
+	}
+	for idx, f := range c.New {
+		writeFinding(sb, f, fmt.Sprintf("new-%d", idx), true)
 	}
+	sb.WriteString(`</section>
+`)
 
-	return nil
+	sb.WriteString(fmt.Sprintf(`<section id="changes-resolved" class="section" data-group>
+  <div class="cat-header">
+    <h2 class="section-title">Resolved</h2>
+    <span class="cat-count">%d finding%s</span>
+  </div>
+`, len(c.Resolved), plural(len(c.Resolved))))
+	if len(c.Resolved) == 0 {
+		sb.WriteString(`  <p class="empty">No resolved findings.</p>
+`)
+	}
+	for idx, f := range c.Resolved {
+		if f.Title == "" {
+			// Only the key is known: no severity, title or description.
+			sb.WriteString(fmt.Sprintf(`  <div class="resolved-row" data-filter data-file="%s"><span class="finding-id">%s</span><span class="finding-loc">%s:%d</span></div>
+`, esc(f.File), esc(f.ID), esc(f.File), f.Line))
+			continue
+		}
+		writeFinding(sb, f, fmt.Sprintf("resolved-%d", idx), true)
+	}
+	sb.WriteString(`</section>
+</div>
+`)
 }
 
-func esc(s string) string {
-	return html.EscapeString(s)
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 func categorySlug(cat string) string {
@@ -583,6 +774,11 @@ const htmlCSS = `
     margin-bottom: 14px;
     line-height: 1.7;
   }
+  .finding-age {
+    font-size: 12px;
+    color: var(--text-dim);
+    margin-bottom: 14px;
+  }
   .finding-code {
     font-family: "SF Mono", Consolas, monospace;
     font-size: 13px;
@@ -595,6 +791,29 @@ const htmlCSS = `
     margin-bottom: 14px;
     line-height: 1.5;
   }
+  .finding-code .ln {
+    color: var(--text-dim);
+    user-select: none;
+  }
+  .finding-code .hl {
+    display: inline-block;
+    min-width: 100%;
+    background: rgba(255,82,82,.12);
+    color: var(--text);
+  }
+  .finding-related {
+    font-size: 13px;
+    margin-bottom: 14px;
+    line-height: 1.8;
+  }
+  .finding-related code {
+    font-family: "SF Mono", Consolas, monospace;
+    color: var(--low);
+  }
+  .related-note {
+    color: var(--text-dim);
+    font-style: italic;
+  }
   .finding-fix {
     background: rgba(124,77,255,.06);
     border-left: 3px solid var(--accent);
@@ -660,6 +879,69 @@ const htmlCSS = `
     text-decoration: none;
   }
 
+  /* ── Filters and tabs ── */
+  .filters {
+    position: sticky;
+    top: 0;
+    z-index: 5;
+    display: flex;
+    flex-wrap: wrap;
+    align-items: center;
+    gap: 8px;
+    padding: 14px 0;
+    margin-bottom: 24px;
+    background: var(--bg);
+    border-bottom: 1px solid var(--border);
+  }
+  .filter-input, .filter-select {
+    font: inherit;
+    font-size: 13px;
+    color: var(--text);
+    background: var(--surface);
+    border: 1px solid var(--border);
+    border-radius: 6px;
+    padding: 6px 10px;
+  }
+  .filter-input[type=search] { flex: 1; min-width: 200px; }
+  .filter-input:focus, .filter-select:focus { outline: none; border-color: var(--accent); }
+  .filter-count {
+    font-size: 12px;
+    color: var(--text-dim);
+    margin-left: auto;
+  }
+  .tabs {
+    display: flex;
+    gap: 6px;
+    margin-bottom: 28px;
+    border-bottom: 1px solid var(--border);
+  }
+  .tab {
+    font: inherit;
+    font-size: 14px;
+    color: var(--text-dim);
+    background: none;
+    border: none;
+    border-bottom: 2px solid transparent;
+    padding: 8px 14px;
+    cursor: pointer;
+  }
+  .tab.active { color: var(--text-bright); border-bottom-color: var(--accent); }
+  .empty, .no-match {
+    font-size: 14px;
+    color: var(--text-dim);
+  }
+  .resolved-row {
+    display: flex;
+    gap: 12px;
+    padding: 10px 18px;
+    margin-bottom: 6px;
+    background: var(--surface);
+    border: 1px solid var(--border);
+    border-radius: var(--radius);
+    text-decoration: line-through;
+  }
+  [hidden] { display: none !important; }
+
   /* ── Responsive ── */
   @media (max-width: 800px) {
     .sidebar { display: none; }
@@ -675,5 +957,104 @@ const htmlCSS = `
     .finding { break-inside: avoid; }
     body { background: #fff; color: #1a1a1a; }
     .stat-card, .info-grid, .finding { border-color: #ddd; background: #f9f9f9; }
+    .filters, .tabs { display: none !important; }
   }
 `
+
+// ── Embedded JavaScript ──
+
+// htmlJS wires up the filter bar and tabs. Cards and rows carrying
+// data-filter are matched against the filters; sections marked
+// data-group are hidden when none of their cards match.
+const htmlJS = `
+(function () {
+  var bar = document.querySelector('.filters');
+  if (!bar) return;
+  bar.hidden = false;
+
+  var search = bar.querySelector('[data-filter-search]');
+  var file = bar.querySelector('[data-filter-file]');
+  var selects = bar.querySelectorAll('[data-filter-by]');
+  var count = bar.querySelector('[data-filter-count]');
+  var panels = document.querySelectorAll('[data-panel]');
+  var tabs = document.querySelectorAll('.tab[data-tab]');
+  var noMatch = document.querySelector('.no-match');
+  var active = panels.length ? panels[0].getAttribute('data-panel') : '';
+
+  function scope() {
+    for (var i = 0; i < panels.length; i++) {
+      if (panels[i].getAttribute('data-panel') === active) return panels[i];
+    }
+    return document;
+  }
+
+  function apply() {
+    var q = search.value.trim().toLowerCase();
+    var f = file.value.trim().toLowerCase();
+    var items = document.querySelectorAll('[data-filter]');
+    for (var i = 0; i < items.length; i++) {
+      var el = items[i];
+      var show = true;
+      for (var j = 0; j < selects.length && show; j++) {
+        var v = selects[j].value;
+        if (v && el.getAttribute('data-' + selects[j].getAttribute('data-filter-by')) !== v) show = false;
+      }
+      if (show && f && (el.getAttribute('data-file') || '').toLowerCase().indexOf(f) < 0) show = false;
+      if (show && q && el.textContent.toLowerCase().indexOf(q) < 0) show = false;
+      el.hidden = !show;
+    }
+
+    var groups = document.querySelectorAll('[data-group]');
+    for (var k = 0; k < groups.length; k++) {
+      var g = groups[k];
+      var total = g.querySelectorAll('[data-filter]').length;
+      g.hidden = total > 0 && g.querySelectorAll('[data-filter]:not([hidden])').length === 0;
+    }
+
+    var root = scope();
+    var all = root.querySelectorAll('[data-filter]').length;
+    var shown = root.querySelectorAll('[data-filter]:not([hidden])').length;
+    count.textContent = shown === all ? all + ' shown' : shown + ' of ' + all + ' shown';
+    if (noMatch) noMatch.hidden = !(all > 0 && shown === 0);
+  }
+
+  function show(name) {
+    active = name;
+    for (var i = 0; i < panels.length; i++) {
+      panels[i].hidden = panels[i].getAttribute('data-panel') !== name;
+    }
+    for (var j = 0; j < tabs.length; j++) {
+      tabs[j].classList.toggle('active', tabs[j].getAttribute('data-tab') === name);
+    }
+    apply();
+  }
+
+  var tabBar = document.querySelector('.tabs');
+  if (tabBar) tabBar.hidden = false;
+  var links = document.querySelectorAll('[data-tab]');
+  for (var i = 0; i < links.length; i++) {
+    links[i].addEventListener('click', function () { show(this.getAttribute('data-tab')); });
+  }
+
+  search.addEventListener('input', apply);
+  file.addEventListener('input', apply);
+  for (var s = 0; s < selects.length; s++) selects[s].addEventListener('change', apply);
+  if (panels.length) show(active); else apply();
+})();
+`
+
+// htmlContext renders the source around line with line numbers, the
+// finding's line highlighted.
+func htmlContext(line int, c *models.SourceContext) string {
+	var sb strings.Builder
+	width := len(strconv.Itoa(c.EndLine()))
+	for i, text := range c.Lines {
+		n := c.StartLine + i
+		row := fmt.Sprintf(`<span class="ln">%*d</span>  %s`, width, n, esc(text))
+		if n == line {
+			row = `<span class="hl">` + row + `</span>`
+		}
+		sb.WriteString(row + "\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,154 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+func TestHTMLReporter_Filters(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewHTMLReporter("").Render(context.Background(), &buf, testReport()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	content := buf.String()
+
+	for _, want := range []string{
+		`data-filter data-severity="Critical" data-category="Test" data-scanner="test-scanner" data-file="app/Test.php"`,
+		`<select class="filter-select" data-filter-by="severity"`,
+		`<option value="Low">Low</option>`,
+		`<option value="test-scanner">test-scanner</option>`,
+		`data-filter-search`,
+		`<script>`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("HTML report missing %q", want)
+		}
+	}
+	if strings.Contains(content, `data-panel="changes"`) {
+		t.Error("a first scan has no changes tab")
+	}
+	for _, external := range []string{`<script src`, `<link `, `@import`} {
+		if strings.Contains(content, external) {
+			t.Errorf("HTML report should be self-contained, found %q", external)
+		}
+	}
+}
+
+func TestHTMLReporter_Changes(t *testing.T) {
+	report := testReport()
+	report.Changes = &models.ScanChanges{
+		PreviousAt:  time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+		TotalBefore: 2,
+		New:         report.Findings[:1],
+		Resolved:    []models.Finding{{ID: "OLD-001", File: "routes/web.php", Line: 7}},
+	}
+
+	var buf bytes.Buffer
+	if err := NewHTMLReporter("").Render(context.Background(), &buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	content := buf.String()
+
+	for _, want := range []string{
+		`data-panel="changes"`,
+		`Changes since last scan <span class="toc-count">+1 / −1</span>`,
+		`<details class="finding" id="new-0" data-filter data-severity="Critical"`,
+		`<div class="resolved-row" data-filter data-file="routes/web.php"><span class="finding-id">OLD-001</span><span class="finding-loc">routes/web.php:7</span></div>`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("HTML report missing %q", want)
+		}
+	}
+}
+
+func TestHTMLDiff(t *testing.T) {
+	before := testReport()
+	after := testReport()
+	after.Findings = []models.Finding{
+		before.Findings[0],
+		{ID: "TEST-003", Title: "Test New Finding", Severity: models.SeverityHigh, Category: "Test", Scanner: "test-scanner", File: "routes/web.php", Line: 3},
+	}
+
+	var buf bytes.Buffer
+	d := Diff{Before: before, After: after, BeforeLabel: "v1.json", AfterLabel: "v2.json"}
+	if err := d.RenderHTML(&buf); err != nil {
+		t.Fatalf("RenderHTML() error = %v", err)
+	}
+	content := buf.String()
+
+	if got := strings.Count(content, `<div class="diff-row new"`); got != 1 {
+		t.Errorf("new rows = %d, want 1", got)
+	}
+	if got := strings.Count(content, `<div class="diff-row resolved"`); got != 1 {
+		t.Errorf("resolved rows = %d, want 1", got)
+	}
+	if got := strings.Count(content, `<div class="diff-row unchanged"`); got != 1 {
+		t.Errorf("unchanged rows = %d, want 1", got)
+	}
+	for _, want := range []string{"v1.json", "v2.json", "Test New Finding", "Test Low Finding", "High</span><span class=\"info-value\">0 → 1 (+1)"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("HTML diff missing %q", want)
+		}
+	}
+}
+
+func TestReadJSON_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	report := testReport()
+	first := time.Date(2026, 10, 6, 12, 0, 0, 0, time.UTC)
+	report.Findings[0].Lifecycle = &models.Lifecycle{FirstSeen: first, FirstCommit: "3f2a1c9", LastSeen: first.Add(72 * time.Hour)}
+	report.Findings[0].Context = &models.SourceContext{StartLine: 41, Lines: []string{"{", "$password = 'hardcoded';", "}"}}
+	report.Findings[0].Related = []models.Location{{File: "app/Auth.php", Line: 7, Note: "inside", Snippet: "class Auth {"}}
+	report.Findings[0].Tags = []string{"cwe-798", "owasp-a07"}
+	report.Checks = map[string][]models.Check{"test-scanner": {{ID: "TEST-001", Title: "Test", Category: "Test", Tags: []string{"cwe-798"}}}}
+	if err := NewJSONReporter("").Render(context.Background(), &buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"age_days": 3`) {
+		t.Error("JSON report should contain the finding age")
+	}
+
+	got, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	if got.ProjectContext.ProjectName != "test/app" || got.Duration != report.Duration {
+		t.Errorf("project = %q, duration = %v", got.ProjectContext.ProjectName, got.Duration)
+	}
+	if !got.StartedAt.Equal(report.StartedAt) || !got.CompletedAt.Equal(report.CompletedAt) {
+		t.Errorf("scan times = %v – %v, want %v – %v", got.StartedAt, got.CompletedAt, report.StartedAt, report.CompletedAt)
+	}
+	if c := got.Checks["test-scanner"]; len(c) != 1 || c[0].ID != "TEST-001" || len(c[0].Tags) != 1 {
+		t.Errorf("checks = %+v, want the reported ones", got.Checks)
+	}
+	if len(got.Findings) != len(report.Findings) {
+		t.Fatalf("findings = %d, want %d", len(got.Findings), len(report.Findings))
+	}
+	for i, f := range got.Findings {
+		want := report.Findings[i]
+		if f.Fingerprint() != want.Fingerprint() || f.Severity != want.Severity || f.Remediation != want.Remediation {
+			t.Errorf("finding %d = %+v, want %+v", i, f, want)
+		}
+	}
+	if l := got.Findings[0].Lifecycle; l == nil || l.Age() != 72*time.Hour || l.FirstCommit != "3f2a1c9" {
+		t.Errorf("lifecycle = %+v, want the reported one", l)
+	}
+	if c := got.Findings[0].Context; c == nil || c.StartLine != 41 || len(c.Lines) != 3 {
+		t.Errorf("context = %+v, want lines 41-43", c)
+	}
+	if tags := got.Findings[0].Tags; len(tags) != 2 || tags[0] != "cwe-798" {
+		t.Errorf("tags = %v, want the finding's", tags)
+	}
+	if r := got.Findings[0].Related; len(r) != 1 || r[0].File != "app/Auth.php" || r[0].Note != "inside" {
+		t.Errorf("related = %+v, want the reported location", r)
+	}
+
+	if _, err := ReadJSON(strings.NewReader("not json")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
@@ -0,0 +1,196 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// RenderHTML writes the two scans side by side as a standalone HTML
+// page, for reviewing what a release adds and fixes. The filter bar works
+// as in the HTML report.
+func (d Diff) RenderHTML(w io.Writer) error {
+	added, resolved, unchanged := d.Compare()
+	before := make(map[string]models.Finding, len(d.Before.Findings))
+	for _, f := range d.Before.Findings {
+		before[f.Fingerprint()] = f
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>Ward Security Diff</title>
+<style>
+`)
+	sb.WriteString(htmlCSS)
+	sb.WriteString(htmlDiffCSS)
+	sb.WriteString(`
+</style>
+</head>
+<body>
+`)
+
+	sb.WriteString(fmt.Sprintf(`<aside class="sidebar">
+  <div class="sidebar-logo">WARD</div>
+  <nav class="toc">
+    <div class="toc-title">Diff</div>
+    <a href="#overview" class="toc-link">Overview</a>
+    <a href="#diff-new" class="toc-link"><span>New</span><span class="toc-count">%d</span></a>
+    <a href="#diff-resolved" class="toc-link"><span>Resolved</span><span class="toc-count">%d</span></a>
+    <a href="#diff-unchanged" class="toc-link"><span>Unchanged</span><span class="toc-count">%d</span></a>
+  </nav>
+</aside>
+<main class="main wide">
+`, len(added), len(resolved), len(unchanged)))
+
+	project := d.project()
+	sb.WriteString(fmt.Sprintf(`<header class="header">
+  <h1>Security Diff</h1>
+  <p class="header-meta">%s &middot; %s &rarr; %s</p>
+</header>
+`, esc(project), esc(d.BeforeLabel), esc(d.AfterLabel)))
+
+	// ── Overview ──
+	sb.WriteString(`<section id="overview" class="section">
+  <h2 class="section-title">Overview</h2>
+  <div class="stats-grid">
+`)
+	for _, card := range []struct {
+		class, label string
+		n            int
+	}{
+		{"info", "Before", len(d.Before.Findings)},
+		{"info", "After", len(d.After.Findings)},
+		{"critical", "New", len(added)},
+		{"low", "Resolved", len(resolved)},
+		{"total", "Unchanged", len(unchanged)},
+	} {
+		sb.WriteString(fmt.Sprintf(`    <div class="stat-card %s"><div class="stat-num">%d</div><div class="stat-label">%s</div></div>
+`, card.class, card.n, card.label))
+	}
+	sb.WriteString(`  </div>
+  <div class="info-grid">
+`)
+	beforeCounts, afterCounts := d.Before.CountBySeverity(), d.After.CountBySeverity()
+	for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo} {
+		b, a := beforeCounts[sev], afterCounts[sev]
+		writeInfoRow(&sb, sev.String(), fmt.Sprintf("%d → %d (%s)", b, a, signed(a-b)))
+	}
+	sb.WriteString(`  </div>
+</section>
+`)
+
+	all := append(append(append([]models.Finding{}, added...), resolved...), unchanged...)
+	writeFilterBar(&sb, all, nil)
+
+	writeDiffGroup(&sb, "diff-new", "New", d.BeforeLabel, d.AfterLabel, added, func(f models.Finding) (*models.Finding, *models.Finding) {
+		return nil, &f
+	})
+	writeDiffGroup(&sb, "diff-resolved", "Resolved", d.BeforeLabel, d.AfterLabel, resolved, func(f models.Finding) (*models.Finding, *models.Finding) {
+		return &f, nil
+	})
+	writeDiffGroup(&sb, "diff-unchanged", "Unchanged", d.BeforeLabel, d.AfterLabel, unchanged, func(f models.Finding) (*models.Finding, *models.Finding) {
+		b := before[f.Fingerprint()]
+		return &b, &f
+	})
+
+	sb.WriteString(`<p class="no-match" hidden>No findings match the current filters.</p>
+<footer class="report-footer">
+  <p>Generated by <a href="https://github.com/Eljakani/ward" target="_blank" rel="noopener">Ward</a></p>
+</footer>
+</main>
+<script>
+`)
+	sb.WriteString(htmlJS)
+	sb.WriteString(`</script>
+</body>
+</html>`)
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("writing HTML diff: %w", err)
+	}
+	return nil
+}
+
+// writeDiffGroup writes one section of the diff as rows of before and
+// after cells. sides returns the finding shown in each column, nil for
+// an empty cell.
+func writeDiffGroup(sb *strings.Builder, id, title, beforeLabel, afterLabel string, findings []models.Finding, sides func(models.Finding) (*models.Finding, *models.Finding)) {
+	sb.WriteString(fmt.Sprintf(`<section id="%s" class="section" data-group>
+  <div class="cat-header">
+    <h2 class="section-title">%s</h2>
+    <span class="cat-count">%d finding%s</span>
+  </div>
+`, id, title, len(findings), plural(len(findings))))
+	if len(findings) == 0 {
+		sb.WriteString(fmt.Sprintf(`  <p class="empty">No %s findings.</p>
+</section>
+`, strings.ToLower(title)))
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf(`  <div class="diff-head"><div>%s</div><div>%s</div></div>
+`, esc(beforeLabel), esc(afterLabel)))
+	for idx, f := range findings {
+		b, a := sides(f)
+		sb.WriteString(fmt.Sprintf(`  <div class="diff-row %s" %s>
+`, strings.ToLower(title), filterAttrs(f)))
+		for side, cell := range []*models.Finding{b, a} {
+			if cell == nil {
+				sb.WriteString(`   <div class="diff-cell diff-empty"></div>
+`)
+				continue
+			}
+			sb.WriteString(`   <div class="diff-cell">
+`)
+			writeFinding(sb, *cell, fmt.Sprintf("%s-%d-%d", id, idx, side), false)
+			sb.WriteString(`   </div>
+`)
+		}
+		sb.WriteString(`  </div>
+`)
+	}
+	sb.WriteString(`</section>
+`)
+}
+
+func signed(n int) string {
+	if n > 0 {
+		return fmt.Sprintf("+%d", n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+const htmlDiffCSS = `
+  .main.wide { max-width: 1400px; }
+  .diff-head, .diff-row {
+    display: grid;
+    grid-template-columns: 1fr 1fr;
+    gap: 10px;
+  }
+  .diff-head {
+    font-family: "SF Mono", Consolas, monospace;
+    font-size: 12px;
+    color: var(--text-dim);
+    margin-bottom: 8px;
+  }
+  .diff-cell { min-width: 0; }
+  .diff-empty {
+    border: 1px dashed var(--border);
+    border-radius: var(--radius);
+    margin-bottom: 10px;
+  }
+  .diff-row.new .diff-cell:last-child .finding { border-left: 3px solid var(--critical); }
+  .diff-row.resolved .diff-cell:first-child .finding { border-left: 3px solid var(--green); opacity: .75; }
+
+  @media (max-width: 800px) {
+    .diff-head, .diff-row { grid-template-columns: 1fr; }
+    .diff-empty { display: none; }
+  }
+`
@@ -4,35 +4,77 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
+	"time"
 
 	"github.com/eljakani/ward/internal/models"
 )
 
 // jsonFinding is the JSON-serializable representation of a finding.
 type jsonFinding struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Severity    string   `json:"severity"`
-	Category    string   `json:"category"`
-	Scanner     string   `json:"scanner"`
-	File        string   `json:"file,omitempty"`
-	Line        int      `json:"line,omitempty"`
-	CodeSnippet string   `json:"code_snippet,omitempty"`
-	Remediation string   `json:"remediation,omitempty"`
-	References  []string `json:"references,omitempty"`
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Severity    string         `json:"severity"`
+	Category    string         `json:"category"`
+	Scanner     string         `json:"scanner"`
+	File        string         `json:"file,omitempty"`
+	Line        int            `json:"line,omitempty"`
+	CodeSnippet string         `json:"code_snippet,omitempty"`
+	Remediation string         `json:"remediation,omitempty"`
+	References  []string       `json:"references,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Fix         *jsonFix       `json:"fix,omitempty"`
+	Lifecycle   *jsonLifecycle `json:"lifecycle,omitempty"`
+	Triage      string         `json:"triage,omitempty"`
+	Context     *jsonContext   `json:"context,omitempty"`
+	Related     []jsonLocation `json:"related,omitempty"`
+}
+
+// jsonContext is the source around a finding or related location.
+type jsonContext struct {
+	StartLine int      `json:"start_line"`
+	Lines     []string `json:"lines"`
+}
+
+// jsonLocation is a line related to a finding.
+type jsonLocation struct {
+	File    string       `json:"file"`
+	Line    int          `json:"line"`
+	Note    string       `json:"note,omitempty"`
+	Snippet string       `json:"snippet,omitempty"`
+	Context *jsonContext `json:"context,omitempty"`
+}
+
+// jsonFix is the JSON-serializable representation of a suggested fix.
+type jsonFix struct {
+	Description string `json:"description"`
+	Line        int    `json:"line"`
+	StartColumn int    `json:"start_column"`
+	EndColumn   int    `json:"end_column"`
+	Original    string `json:"original"`
+	Replacement string `json:"replacement"`
+}
+
+// jsonCheck is a check a scanner ran, whether or not it found anything.
+type jsonCheck struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Category string   `json:"category,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
 }
 
 // jsonReport is the top-level JSON output structure.
 type jsonReport struct {
-	Project  jsonProject          `json:"project"`
-	Summary  jsonSummary          `json:"summary"`
-	Findings []jsonFinding        `json:"findings"`
+	Project  jsonProject            `json:"project"`
+	Summary  jsonSummary            `json:"summary"`
+	Findings []jsonFinding          `json:"findings"`
+	Checks   map[string][]jsonCheck `json:"checks,omitempty"` // scanner name → checks
 }
 
 type jsonProject struct {
+	ID             string `json:"id,omitempty"`
 	Name           string `json:"name"`
 	Path           string `json:"path"`
 	LaravelVersion string `json:"laravel_version,omitempty"`
@@ -42,6 +84,8 @@ type jsonProject struct {
 type jsonSummary struct {
 	TotalFindings int            `json:"total_findings"`
 	BySeverity    map[string]int `json:"by_severity"`
+	StartedAt     time.Time      `json:"started_at,omitzero"`
+	CompletedAt   time.Time      `json:"completed_at,omitzero"`
 	Duration      string         `json:"duration"`
 	ScannersRun   []string       `json:"scanners_run"`
 }
@@ -61,9 +105,15 @@ func NewJSONReporter(outputDir string) *JSONReporter {
 func (r *JSONReporter) Name() string   { return "json" }
 func (r *JSONReporter) Format() string { return "json" }
 
-func (r *JSONReporter) Generate(_ context.Context, report *models.ScanReport) error {
+// Generate writes ward-report.json to OutputDir.
+func (r *JSONReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *JSONReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
 	jr := jsonReport{
 		Project: jsonProject{
+			ID:             report.ProjectContext.ProjectID,
 			Name:           report.ProjectContext.ProjectName,
 			Path:           report.ProjectContext.RootPath,
 			LaravelVersion: report.ProjectContext.LaravelVersion,
@@ -72,6 +122,8 @@ func (r *JSONReporter) Generate(_ context.Context, report *models.ScanReport) er
 		Summary: jsonSummary{
 			TotalFindings: len(report.Findings),
 			BySeverity:    make(map[string]int),
+			StartedAt:     report.StartedAt,
+			CompletedAt:   report.CompletedAt,
 			Duration:      report.Duration.String(),
 			ScannersRun:   report.ScannersRun,
 		},
@@ -82,9 +134,25 @@ func (r *JSONReporter) Generate(_ context.Context, report *models.ScanReport) er
 		jr.Summary.BySeverity[sev.String()] = count
 	}
 
-	jr.Findings = make([]jsonFinding, 0, len(report.Findings))
-	for _, f := range report.Findings {
-		jr.Findings = append(jr.Findings, jsonFinding{
+	jr.Findings = toJSONFindings(report.Findings)
+	jr.Checks = toJSONChecks(report.Checks)
+
+	data, err := json.MarshalIndent(jr, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling report: %w", err)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	return nil
+}
+
+func toJSONFindings(findings []models.Finding) []jsonFinding {
+	out := make([]jsonFinding, 0, len(findings))
+	for _, f := range findings {
+		out = append(out, jsonFinding{
 			ID:          f.ID,
 			Title:       f.Title,
 			Description: f.Description,
@@ -96,18 +164,179 @@ func (r *JSONReporter) Generate(_ context.Context, report *models.ScanReport) er
 			CodeSnippet: f.CodeSnippet,
 			Remediation: f.Remediation,
 			References:  f.References,
+			Tags:        f.Tags,
+			Fix:         toJSONFix(f.Fix),
+			Lifecycle:   toJSONLifecycle(f.Lifecycle),
+			Triage:      f.Triage,
+			Context:     toJSONContext(f.Context),
+			Related:     toJSONLocations(f.Related),
 		})
 	}
+	return out
+}
 
-	data, err := json.MarshalIndent(jr, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshalling report: %w", err)
+func toJSONChecks(checks map[string][]models.Check) map[string][]jsonCheck {
+	if len(checks) == 0 {
+		return nil
 	}
+	out := make(map[string][]jsonCheck, len(checks))
+	for scanner, list := range checks {
+		for _, c := range list {
+			out[scanner] = append(out[scanner], jsonCheck{ID: c.ID, Title: c.Title, Category: c.Category, Tags: c.Tags})
+		}
+	}
+	return out
+}
 
-	outPath := filepath.Join(r.OutputDir, "ward-report.json")
-	if err := os.WriteFile(outPath, data, 0644); err != nil {
-		return fmt.Errorf("writing report to %s: %w", outPath, err)
+func toJSONFix(fix *models.Fix) *jsonFix {
+	if fix == nil {
+		return nil
+	}
+	return &jsonFix{
+		Description: fix.Description,
+		Line:        fix.Line,
+		StartColumn: fix.StartColumn,
+		EndColumn:   fix.EndColumn,
+		Original:    fix.Original,
+		Replacement: fix.Replacement,
 	}
+}
 
-	return nil
+func toJSONContext(c *models.SourceContext) *jsonContext {
+	if c == nil {
+		return nil
+	}
+	return &jsonContext{StartLine: c.StartLine, Lines: c.Lines}
+}
+
+func toJSONLocations(locs []models.Location) []jsonLocation {
+	if len(locs) == 0 {
+		return nil
+	}
+	out := make([]jsonLocation, len(locs))
+	for i, l := range locs {
+		out[i] = jsonLocation{File: l.File, Line: l.Line, Note: l.Note, Snippet: l.Snippet, Context: toJSONContext(l.Context)}
+	}
+	return out
+}
+
+// jsonLifecycle is when a finding was first and last reported by a
+// stored scan of the project.
+type jsonLifecycle struct {
+	FirstSeen   time.Time `json:"first_seen"`
+	FirstCommit string    `json:"first_commit,omitempty"`
+	LastSeen    time.Time `json:"last_seen"`
+	AgeDays     int       `json:"age_days"`
+}
+
+func toJSONLifecycle(l *models.Lifecycle) *jsonLifecycle {
+	if l == nil {
+		return nil
+	}
+	return &jsonLifecycle{
+		FirstSeen:   l.FirstSeen,
+		FirstCommit: l.FirstCommit,
+		LastSeen:    l.LastSeen,
+		AgeDays:     int(l.Age().Hours() / 24),
+	}
+}
+
+// ReadJSON parses a report written by JSONReporter. Reports written
+// before tags, checks and scan times were recorded leave them zero.
+func ReadJSON(r io.Reader) (*models.ScanReport, error) {
+	var jr jsonReport
+	if err := json.NewDecoder(r).Decode(&jr); err != nil {
+		return nil, fmt.Errorf("parsing JSON report: %w", err)
+	}
+
+	duration, _ := time.ParseDuration(jr.Summary.Duration)
+	report := &models.ScanReport{
+		ProjectContext: models.ProjectContext{
+			ProjectID:      jr.Project.ID,
+			ProjectName:    jr.Project.Name,
+			RootPath:       jr.Project.Path,
+			LaravelVersion: jr.Project.LaravelVersion,
+			PHPVersion:     jr.Project.PHPVersion,
+		},
+		StartedAt:   jr.Summary.StartedAt,
+		CompletedAt: jr.Summary.CompletedAt,
+		Duration:    duration,
+		ScannersRun: jr.Summary.ScannersRun,
+		Findings:    make([]models.Finding, 0, len(jr.Findings)),
+		Checks:      fromJSONChecks(jr.Checks),
+	}
+	for _, f := range jr.Findings {
+		report.Findings = append(report.Findings, models.Finding{
+			ID:          f.ID,
+			Title:       f.Title,
+			Description: f.Description,
+			Severity:    models.ParseSeverity(f.Severity),
+			Category:    f.Category,
+			Scanner:     f.Scanner,
+			File:        f.File,
+			Line:        f.Line,
+			CodeSnippet: f.CodeSnippet,
+			Remediation: f.Remediation,
+			References:  f.References,
+			Tags:        f.Tags,
+			Fix:         fromJSONFix(f.Fix),
+			Lifecycle:   fromJSONLifecycle(f.Lifecycle),
+			Triage:      f.Triage,
+			Context:     fromJSONContext(f.Context),
+			Related:     fromJSONLocations(f.Related),
+		})
+	}
+	return report, nil
+}
+
+func fromJSONChecks(checks map[string][]jsonCheck) map[string][]models.Check {
+	if len(checks) == 0 {
+		return nil
+	}
+	out := make(map[string][]models.Check, len(checks))
+	for scanner, list := range checks {
+		for _, c := range list {
+			out[scanner] = append(out[scanner], models.Check{ID: c.ID, Title: c.Title, Category: c.Category, Tags: c.Tags})
+		}
+	}
+	return out
+}
+
+func fromJSONFix(fix *jsonFix) *models.Fix {
+	if fix == nil {
+		return nil
+	}
+	return &models.Fix{
+		Description: fix.Description,
+		Line:        fix.Line,
+		StartColumn: fix.StartColumn,
+		EndColumn:   fix.EndColumn,
+		Original:    fix.Original,
+		Replacement: fix.Replacement,
+	}
+}
+
+func fromJSONLifecycle(l *jsonLifecycle) *models.Lifecycle {
+	if l == nil {
+		return nil
+	}
+	return &models.Lifecycle{FirstSeen: l.FirstSeen, FirstCommit: l.FirstCommit, LastSeen: l.LastSeen}
+}
+
+func fromJSONContext(c *jsonContext) *models.SourceContext {
+	if c == nil {
+		return nil
+	}
+	return &models.SourceContext{StartLine: c.StartLine, Lines: c.Lines}
+}
+
+func fromJSONLocations(locs []jsonLocation) []models.Location {
+	if len(locs) == 0 {
+		return nil
+	}
+	out := make([]models.Location, len(locs))
+	for i, l := range locs {
+		out[i] = models.Location{File: l.File, Line: l.Line, Note: l.Note, Snippet: l.Snippet, Context: fromJSONContext(l.Context)}
+	}
+	return out
 }
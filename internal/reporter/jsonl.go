@@ -0,0 +1,107 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// jsonlRecord is one line of ward-report.jsonl. Project and scan metadata
+// repeat on every line so each record stands alone in a log pipeline.
+type jsonlRecord struct {
+	Fingerprint string      `json:"fingerprint"`
+	RuleID      string      `json:"rule_id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	Severity    string      `json:"severity"`
+	Category    string      `json:"category"`
+	Scanner     string      `json:"scanner"`
+	File        string      `json:"file,omitempty"`
+	Line        int         `json:"line,omitempty"`
+	CWE         []string    `json:"cwe,omitempty"`
+	OWASP       []string    `json:"owasp,omitempty"`
+	Remediation string      `json:"remediation,omitempty"`
+	Project     jsonProject `json:"project"`
+	Scan        jsonlScan   `json:"scan"`
+}
+
+type jsonlScan struct {
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	WardVersion string    `json:"ward_version"`
+}
+
+// JSONLReporter writes ward-report.jsonl with one finding per line, for
+// SIEMs and other newline-delimited JSON consumers.
+type JSONLReporter struct {
+	OutputDir string
+	Version   string
+}
+
+func NewJSONLReporter(outputDir string, version string) *JSONLReporter {
+	if outputDir == "" {
+		outputDir = "."
+	}
+	if version == "" {
+		version = "dev"
+	}
+	return &JSONLReporter{OutputDir: outputDir, Version: version}
+}
+
+func (r *JSONLReporter) Name() string   { return "jsonl" }
+func (r *JSONLReporter) Format() string { return "jsonl" }
+
+// Generate writes ward-report.jsonl to OutputDir.
+func (r *JSONLReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *JSONLReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
+	project := jsonProject{
+		Name:           report.ProjectContext.ProjectName,
+		Path:           report.ProjectContext.RootPath,
+		LaravelVersion: report.ProjectContext.LaravelVersion,
+		PHPVersion:     report.ProjectContext.PHPVersion,
+	}
+	scan := jsonlScan{
+		StartedAt:   report.StartedAt,
+		CompletedAt: report.CompletedAt,
+		WardVersion: r.Version,
+	}
+
+	enc := json.NewEncoder(w)
+	for _, f := range report.Findings {
+		if err := enc.Encode(toJSONLRecord(f, project, scan)); err != nil {
+			return fmt.Errorf("writing JSONL report: %w", err)
+		}
+	}
+	return nil
+}
+
+func toJSONLRecord(f models.Finding, project jsonProject, scan jsonlScan) jsonlRecord {
+	tx := findingTaxonomy(f)
+	rec := jsonlRecord{
+		Fingerprint: f.Fingerprint(),
+		RuleID:      f.ID,
+		Title:       f.Title,
+		Description: f.Description,
+		Severity:    f.Severity.String(),
+		Category:    f.Category,
+		Scanner:     f.Scanner,
+		File:        f.File,
+		Line:        f.Line,
+		OWASP:       tx.OWASP,
+		Remediation: f.Remediation,
+		Project:     project,
+		Scan:        scan,
+	}
+	for _, n := range tx.CWEs {
+		rec.CWE = append(rec.CWE, "CWE-"+n)
+	}
+	return rec
+}
@@ -0,0 +1,55 @@
+package reporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONLReporter(t *testing.T) {
+	dir := t.TempDir()
+	r := NewJSONLReporter(dir, "1.2.3")
+
+	report := testReport()
+	report.Findings[0].Tags = []string{"owasp-a07", "cwe-798"}
+
+	if err := r.Generate(context.Background(), report); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "ward-report.jsonl"))
+	if err != nil {
+		t.Fatalf("opening JSONL report: %v", err)
+	}
+	defer file.Close()
+
+	var records []jsonlRecord
+	sc := bufio.NewScanner(file)
+	for sc.Scan() {
+		var rec jsonlRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", len(records)+1, err)
+		}
+		records = append(records, rec)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(records))
+	}
+
+	first := records[0]
+	if first.Fingerprint != report.Findings[0].Fingerprint() || first.RuleID != "TEST-001" || first.Line != 42 {
+		t.Errorf("record = %+v", first)
+	}
+	if len(first.CWE) != 1 || first.CWE[0] != "CWE-798" || len(first.OWASP) != 1 || first.OWASP[0] != "A07:2021" {
+		t.Errorf("cwe = %v, owasp = %v", first.CWE, first.OWASP)
+	}
+	for i, rec := range records {
+		if rec.Project.Name != "test/app" || rec.Scan.WardVersion != "1.2.3" || rec.Scan.StartedAt.IsZero() {
+			t.Errorf("record %d missing project/scan metadata: %+v", i, rec)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package reporter
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// JUnitReporter generates JUnit XML for CI test dashboards (Jenkins,
+// GitLab, Azure DevOps). Every rule or built-in check is a testcase,
+// grouped into one testsuite per scanner.
+type JUnitReporter struct {
+	OutputDir string
+	// FailOn is the --fail-on severity. Checks whose findings are all
+	// below it are reported as skipped rather than failed. Empty fails
+	// every finding.
+	FailOn string
+}
+
+func NewJUnitReporter(outputDir string, failOn string) *JUnitReporter {
+	if outputDir == "" {
+		outputDir = "."
+	}
+	return &JUnitReporter{OutputDir: outputDir, FailOn: failOn}
+}
+
+func (r *JUnitReporter) Name() string   { return "junit" }
+func (r *JUnitReporter) Format() string { return "xml" }
+
+// Generate writes ward-report.xml to OutputDir.
+func (r *JUnitReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *JUnitReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
+	doc := r.build(report)
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling JUnit XML: %w", err)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("writing JUnit XML: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing JUnit XML: %w", err)
+	}
+	return nil
+}
+
+func (r *JUnitReporter) build(report *models.ScanReport) junitTestSuites {
+	byScanner := make(map[string]map[string][]models.Finding)
+	for _, f := range report.Findings {
+		if byScanner[f.Scanner] == nil {
+			byScanner[f.Scanner] = make(map[string][]models.Finding)
+		}
+		byScanner[f.Scanner][f.ID] = append(byScanner[f.Scanner][f.ID], f)
+	}
+
+	// Suites for every scanner that ran or failed, plus any scanner that
+	// only shows up in findings.
+	names := append([]string(nil), report.ScannersRun...)
+	for name := range report.ScannerErrors {
+		names = append(names, name)
+	}
+	for name := range byScanner {
+		names = append(names, name)
+	}
+	names = uniqueSorted(names)
+
+	doc := junitTestSuites{
+		Name: "Ward",
+		Time: seconds(report.Duration.Seconds()),
+	}
+
+	for _, name := range names {
+		suite := junitTestSuite{Name: name, Timestamp: report.StartedAt.Format("2006-01-02T15:04:05")}
+
+		if msg, failed := report.ScannerErrors[name]; failed {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      name,
+				ClassName: "ward." + name,
+				Error:     &junitResult{Message: "scanner failed", Type: "ScannerError", Body: msg},
+			})
+		}
+
+		findings := byScanner[name]
+		listed := make(map[string]bool)
+		for _, c := range report.Checks[name] {
+			listed[c.ID] = true
+			suite.TestCases = append(suite.TestCases, r.testCase(name, c, findings[c.ID]))
+		}
+
+		// Findings from checks the scanner didn't list (e.g. advisories).
+		var extra []string
+		for id := range findings {
+			if !listed[id] {
+				extra = append(extra, id)
+			}
+		}
+		sort.Strings(extra)
+		for _, id := range extra {
+			f := findings[id][0]
+			c := models.Check{ID: f.ID, Title: f.Title, Category: f.Category}
+			suite.TestCases = append(suite.TestCases, r.testCase(name, c, findings[id]))
+		}
+
+		// A scanner that ran without listing checks or finding anything
+		// still gets a passing testcase so the suite isn't empty.
+		if len(suite.TestCases) == 0 {
+			suite.TestCases = append(suite.TestCases, junitTestCase{Name: name, ClassName: "ward." + name})
+		}
+
+		for _, tc := range suite.TestCases {
+			suite.Tests++
+			switch {
+			case tc.Failure != nil:
+				suite.Failures++
+			case tc.Error != nil:
+				suite.Errors++
+			case tc.Skipped != nil:
+				suite.Skipped++
+			}
+		}
+
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Errors += suite.Errors
+		doc.Skipped += suite.Skipped
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	return doc
+}
+
+// testCase builds the testcase for one check. It fails if any finding
+// meets the --fail-on threshold and is skipped if all are below it.
+func (r *JUnitReporter) testCase(scanner string, c models.Check, findings []models.Finding) junitTestCase {
+	tc := junitTestCase{
+		Name:      c.ID,
+		ClassName: "ward." + scanner,
+	}
+	if c.Title != "" {
+		tc.Name = c.ID + ": " + c.Title
+	}
+	if c.Category != "" {
+		tc.ClassName += "." + className(c.Category)
+	}
+	if len(findings) == 0 {
+		return tc
+	}
+
+	worst := findings[0].Severity
+	for _, f := range findings[1:] {
+		if f.Severity > worst {
+			worst = f.Severity
+		}
+	}
+
+	message := fmt.Sprintf("%d finding(s), highest severity %s", len(findings), worst)
+	if r.FailOn != "" && worst < models.ParseSeverity(r.FailOn) {
+		tc.Skipped = &junitResult{Message: fmt.Sprintf("%s, below --fail-on %s", message, strings.ToLower(r.FailOn))}
+		return tc
+	}
+
+	tc.Failure = &junitResult{
+		Message: message,
+		Type:    worst.String(),
+		Body:    junitFailureBody(findings),
+	}
+	return tc
+}
+
+func junitFailureBody(findings []models.Finding) string {
+	var sb strings.Builder
+	for i, f := range findings {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		loc := f.File
+		if f.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", f.Severity, loc, f.Title))
+		if f.Description != "" {
+			sb.WriteString("  " + f.Description + "\n")
+		}
+		if f.CodeSnippet != "" {
+			sb.WriteString("  Code: " + strings.TrimSpace(f.CodeSnippet) + "\n")
+		}
+		if f.Remediation != "" {
+			sb.WriteString("  Remediation: " + f.Remediation + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// className turns a category like "Access Control" into "access_control".
+func className(category string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(category)), " ", "_")
+}
+
+func uniqueSorted(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	var out []string
+	for _, n := range names {
+		if n != "" && !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// seconds formats a duration in seconds the way JUnit consumers expect.
+type seconds float64
+
+func (s seconds) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{Name: name, Value: fmt.Sprintf("%.3f", float64(s))}, nil
+}
+
+// JUnit XML structures (the de facto schema used by Jenkins and GitLab).
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     seconds          `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Failure   *junitResult `xml:"failure,omitempty"`
+	Error     *junitResult `xml:"error,omitempty"`
+	Skipped   *junitResult `xml:"skipped,omitempty"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
@@ -0,0 +1,91 @@
+package reporter
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	dir := t.TempDir()
+	r := NewJUnitReporter(dir, "high")
+
+	if r.Format() != "xml" {
+		t.Errorf("Format() = %q, want %q", r.Format(), "xml")
+	}
+
+	report := testReport()
+	report.Checks = map[string][]models.Check{
+		"test-scanner": {
+			{ID: "TEST-001", Title: "Test Critical Finding", Category: "Test"},
+			{ID: "TEST-002", Title: "Test Low Finding", Category: "Test"},
+			{ID: "TEST-003", Title: "Clean check", Category: "Access Control"},
+		},
+	}
+	report.ScannerErrors = map[string]string{"dependency-scanner": "OSV.dev unreachable"}
+
+	if err := r.Generate(context.Background(), report); err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "ward-report.xml"))
+	if err != nil {
+		t.Fatalf("reading JUnit report: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "<?xml") {
+		t.Error("report should start with an XML header")
+	}
+
+	var doc junitTestSuites
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+
+	if doc.Tests != 4 || doc.Failures != 1 || doc.Skipped != 1 || doc.Errors != 1 {
+		t.Errorf("totals = %d tests, %d failures, %d skipped, %d errors; want 4, 1, 1, 1",
+			doc.Tests, doc.Failures, doc.Skipped, doc.Errors)
+	}
+	if len(doc.Suites) != 2 || doc.Suites[0].Name != "dependency-scanner" || doc.Suites[1].Name != "test-scanner" {
+		t.Fatalf("suites = %+v, want dependency-scanner and test-scanner", doc.Suites)
+	}
+
+	depErr := doc.Suites[0].TestCases[0].Error
+	if depErr == nil || depErr.Body != "OSV.dev unreachable" {
+		t.Errorf("scanner error testcase = %+v", doc.Suites[0].TestCases[0])
+	}
+
+	cases := doc.Suites[1].TestCases
+	critical, low, clean := cases[0], cases[1], cases[2]
+
+	if critical.Name != "TEST-001: Test Critical Finding" || critical.ClassName != "ward.test-scanner.test" {
+		t.Errorf("testcase = %q (%s)", critical.Name, critical.ClassName)
+	}
+	if critical.Failure == nil {
+		t.Fatal("critical finding should be a failure")
+	}
+	for _, want := range []string{"app/Test.php:42", "Remediation: Fix it."} {
+		if !strings.Contains(critical.Failure.Body, want) {
+			t.Errorf("failure body missing %q:\n%s", want, critical.Failure.Body)
+		}
+	}
+
+	if low.Failure != nil || low.Skipped == nil {
+		t.Errorf("low finding below --fail-on should be skipped, got %+v", low)
+	}
+	if clean.Failure != nil || clean.Skipped != nil || clean.ClassName != "ward.test-scanner.access_control" {
+		t.Errorf("check without findings should pass, got %+v", clean)
+	}
+}
+
+func TestJUnitReporter_NoFailOn(t *testing.T) {
+	doc := NewJUnitReporter("", "").build(testReport())
+
+	if doc.Tests != 2 || doc.Failures != 2 {
+		t.Errorf("without --fail-on every finding should fail: %d tests, %d failures", doc.Tests, doc.Failures)
+	}
+}
@@ -3,11 +3,13 @@ package reporter
 import (
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/snippet"
 )
 
 // MarkdownReporter generates a Markdown report.
@@ -29,7 +31,12 @@ func NewMarkdownReporter(outputDir string, version string) *MarkdownReporter {
 func (r *MarkdownReporter) Name() string   { return "markdown" }
 func (r *MarkdownReporter) Format() string { return "md" }
 
-func (r *MarkdownReporter) Generate(_ context.Context, report *models.ScanReport) error {
+// Generate writes ward-report.md to OutputDir.
+func (r *MarkdownReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *MarkdownReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
 	counts := report.CountBySeverity()
 
 	var sb strings.Builder
@@ -64,40 +71,15 @@ func (r *MarkdownReporter) Generate(_ context.Context, report *models.ScanReport
 		sb.WriteString(fmt.Sprintf("### %s %s (%d)\n\n", severityEmoji(sev), sev.String(), len(sevFindings)))
 
 		for _, f := range sevFindings {
-			sb.WriteString(fmt.Sprintf("#### %s — %s\n\n", f.ID, f.Title))
-			sb.WriteString(fmt.Sprintf("- **File:** `%s:%d`\n", f.File, f.Line))
-			sb.WriteString(fmt.Sprintf("- **Category:** %s\n", f.Category))
-			sb.WriteString(fmt.Sprintf("- **Scanner:** %s\n\n", f.Scanner))
-			sb.WriteString(f.Description + "\n\n")
-
-			if f.CodeSnippet != "" {
-				sb.WriteString("```\n")
-				sb.WriteString(f.CodeSnippet + "\n")
-				sb.WriteString("```\n\n")
-			}
-
-			if f.Remediation != "" {
-				sb.WriteString("**Remediation:**\n\n")
-				sb.WriteString(f.Remediation + "\n\n")
-			}
-
-			if len(f.References) > 0 {
-				sb.WriteString("**References:**\n")
-				for _, ref := range f.References {
-					sb.WriteString(fmt.Sprintf("- %s\n", ref))
-				}
-				sb.WriteString("\n")
-			}
-
+			sb.WriteString(FindingMarkdown(f))
 			sb.WriteString("---\n\n")
 		}
 	}
 
 	sb.WriteString(fmt.Sprintf("*Generated by [Ward](https://github.com/Eljakani/ward) %s*\n", r.Version))
 
-	outPath := filepath.Join(r.OutputDir, "ward-report.md")
-	if err := os.WriteFile(outPath, []byte(sb.String()), 0644); err != nil {
-		return fmt.Errorf("writing Markdown report to %s: %w", outPath, err)
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("writing Markdown report: %w", err)
 	}
 
 	return nil
@@ -127,3 +109,87 @@ func filterFindings(findings []models.Finding, sev models.Severity) []models.Fin
 	}
 	return result
 }
+
+// FindingMarkdown renders one finding as it appears in the Markdown
+// report, from its heading to its references.
+func FindingMarkdown(f models.Finding) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("#### %s — %s\n\n", f.ID, f.Title))
+	sb.WriteString(fmt.Sprintf("- **File:** `%s:%d`\n", f.File, f.Line))
+	sb.WriteString(fmt.Sprintf("- **Category:** %s\n", f.Category))
+	sb.WriteString(fmt.Sprintf("- **Scanner:** %s\n", f.Scanner))
+	if f.Lifecycle != nil {
+		sb.WriteString(fmt.Sprintf("- **Age:** %s\n", f.Lifecycle))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(f.Description + "\n\n")
+
+	if f.Context != nil {
+		sb.WriteString(markdownContext(f.File, f.Line, f.Context))
+	} else if f.CodeSnippet != "" {
+		sb.WriteString("```\n")
+		sb.WriteString(f.CodeSnippet + "\n")
+		sb.WriteString("```\n\n")
+	}
+
+	if len(f.Related) > 0 {
+		sb.WriteString("**Related locations:**\n\n")
+		for _, r := range f.Related {
+			sb.WriteString(fmt.Sprintf("- `%s:%d`", r.File, r.Line))
+			if r.Note != "" {
+				sb.WriteString(" (" + r.Note + ")")
+			}
+			if r.Snippet != "" {
+				sb.WriteString(" — `" + strings.ReplaceAll(r.Snippet, "`", "'") + "`")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if f.Remediation != "" {
+		sb.WriteString("**Remediation:**\n\n")
+		sb.WriteString(f.Remediation + "\n\n")
+	}
+
+	if f.Fix != nil {
+		sb.WriteString(fmt.Sprintf("**Suggested fix:** %s\n\n", f.Fix.Description))
+		sb.WriteString("```diff\n")
+		sb.WriteString("- " + f.Fix.Original + "\n")
+		sb.WriteString("+ " + f.Fix.Replacement + "\n")
+		sb.WriteString("```\n\n")
+	}
+
+	if len(f.References) > 0 {
+		sb.WriteString("**References:**\n")
+		for _, ref := range f.References {
+			sb.WriteString(fmt.Sprintf("- %s\n", ref))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// markdownContext renders the source around line as a fenced block with
+// line numbers, the finding's line marked with ">".
+func markdownContext(file string, line int, c *models.SourceContext) string {
+	// The fence must be longer than any run of backticks in the code.
+	fence := "```"
+	for strings.Contains(strings.Join(c.Lines, "\n"), fence) {
+		fence += "`"
+	}
+
+	var sb strings.Builder
+	width := len(strconv.Itoa(c.EndLine()))
+	sb.WriteString(fence + snippet.Language(file) + "\n")
+	for i, text := range c.Lines {
+		n := c.StartLine + i
+		mark := " "
+		if n == line {
+			mark = ">"
+		}
+		sb.WriteString(fmt.Sprintf("%s %*d | %s\n", mark, width, n, text))
+	}
+	sb.WriteString(fence + "\n\n")
+	return sb.String()
+}
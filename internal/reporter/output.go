@@ -0,0 +1,110 @@
+package reporter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/eljakani/ward/internal/fsutil"
+	"github.com/eljakani/ward/internal/models"
+)
+
+// Stdout is the output path that sends a report to standard output.
+const Stdout = "-"
+
+// Write renders report with r to path. Stdout writes to standard output;
+// any other path is written atomically, so a reader never sees a
+// half-written report.
+func Write(ctx context.Context, r Reporter, path string, report *models.ScanReport) error {
+	return WriteFunc(path, func(w io.Writer) error {
+		return r.Render(ctx, w, report)
+	})
+}
+
+// WriteFunc is Write for output that isn't a Reporter, such as a Diff.
+func WriteFunc(path string, render func(io.Writer) error) error {
+	if path == Stdout {
+		w := bufio.NewWriter(os.Stdout)
+		if err := render(w); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+	return writeAtomic(path, render)
+}
+
+// writeAtomic creates path's directory and writes path atomically.
+func writeAtomic(path string, render func(io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	return fsutil.WriteAtomic(path, 0644, render)
+}
+
+var placeholderRe = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// PathVars returns the placeholder values for a report written in format
+// by r: the project name made filename-safe, the scan's start date
+// (2006-01-02) and time (150405), the short git commit ("nocommit"
+// outside a repository), the format name and r's file extension.
+func PathVars(report *models.ScanReport, format string, r Reporter) map[string]string {
+	project := report.ProjectContext.ProjectName
+	if project == "" || project == "." {
+		project = filepath.Base(report.ProjectContext.RootPath)
+	}
+	commit := report.ProjectContext.GitCommit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	if commit == "" {
+		commit = "nocommit"
+	}
+	return map[string]string{
+		"project": safeName(project),
+		"date":    report.StartedAt.Format("2006-01-02"),
+		"time":    report.StartedAt.Format("150405"),
+		"commit":  commit,
+		"format":  format,
+		"ext":     r.Format(),
+	}
+}
+
+// ExpandPath substitutes {name} placeholders in tmpl with vars. Unknown
+// placeholders are an error.
+func ExpandPath(tmpl string, vars map[string]string) (string, error) {
+	var unknown []string
+	out := placeholderRe.ReplaceAllStringFunc(tmpl, func(m string) string {
+		name := m[1 : len(m)-1]
+		v, ok := vars[name]
+		if !ok {
+			unknown = append(unknown, m)
+		}
+		return v
+	})
+	if len(unknown) > 0 {
+		return "", fmt.Errorf("unknown placeholder %s in %q", strings.Join(unknown, ", "), tmpl)
+	}
+	return out, nil
+}
+
+// safeName replaces characters that don't belong in a file name, such as
+// the slash in a composer package name, with "-".
+func safeName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			return r
+		}
+		return '-'
+	}, s)
+	s = strings.Trim(s, ".-")
+	if s == "" {
+		return "project"
+	}
+	return s
+}
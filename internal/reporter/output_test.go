@@ -0,0 +1,118 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+)
+
+func TestWrite_Atomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "reports", "out.csv")
+
+	if err := Write(context.Background(), NewCSVReporter(""), path, testReport()); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	if len(data) == 0 {
+		t.Error("report is empty")
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0644 {
+		t.Errorf("mode = %v, want 0644", info.Mode().Perm())
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("temp files left behind: %v", entries)
+	}
+}
+
+type failingReporter struct{}
+
+func (failingReporter) Name() string   { return "failing" }
+func (failingReporter) Format() string { return "txt" }
+func (failingReporter) Render(_ context.Context, w io.Writer, _ *models.ScanReport) error {
+	io.WriteString(w, "partial")
+	return errors.New("render failed")
+}
+
+func TestWrite_KeepsOldReportOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ward-report.txt")
+	os.WriteFile(path, []byte("previous"), 0644)
+
+	if err := Write(context.Background(), failingReporter{}, path, testReport()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "previous" {
+		t.Errorf("report = %q, want the previous one untouched", data)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("temp files left behind: %v", entries)
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	report := testReport()
+	report.ProjectContext.ProjectName = "acme/shop"
+	report.ProjectContext.GitCommit = "3f2a1c9e8b7d6a5f"
+	report.StartedAt = time.Date(2026, 3, 1, 9, 5, 7, 0, time.UTC)
+
+	vars := PathVars(report, "sarif", NewSARIFReporter("", ""))
+	got, err := ExpandPath("{project}-{date}-{time}-{commit}.{format}.{ext}", vars)
+	if err != nil {
+		t.Fatalf("ExpandPath() error: %v", err)
+	}
+	if want := "acme-shop-2026-03-01-090507-3f2a1c9.sarif.sarif"; got != want {
+		t.Errorf("ExpandPath() = %q, want %q", got, want)
+	}
+
+	if _, err := ExpandPath("{branch}.json", vars); err == nil {
+		t.Error("expected an error for an unknown placeholder")
+	}
+
+	report.ProjectContext.GitCommit = ""
+	if vars := PathVars(report, "json", NewJSONReporter("")); vars["commit"] != "nocommit" {
+		t.Errorf("commit outside a repository = %q, want nocommit", vars["commit"])
+	}
+}
+
+func TestPathVars_MatchConfig(t *testing.T) {
+	var names []string
+	for name := range PathVars(testReport(), "json", NewJSONReporter("")) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	want := slices.Clone(config.PathPlaceholders)
+	sort.Strings(want)
+	if !slices.Equal(names, want) {
+		t.Errorf("PathVars provides %v, config.PathPlaceholders allows %v", names, want)
+	}
+}
+
+func TestSafeName(t *testing.T) {
+	tests := map[string]string{
+		"acme/shop":    "acme-shop",
+		"my app":       "my-app",
+		"../etc":       "etc",
+		"":             "project",
+		"shop_v2.1-rc": "shop_v2.1-rc",
+	}
+	for in, want := range tests {
+		if got := safeName(in); got != want {
+			t.Errorf("safeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -2,13 +2,29 @@ package reporter
 
 import (
 	"context"
+	"io"
 
 	"github.com/eljakani/ward/internal/models"
 )
 
-// Reporter generates output from a scan report.
+// Reporter renders a scan report in one output format. Where the output
+// goes is up to the caller; see Write.
 type Reporter interface {
 	Name() string
 	Format() string // file extension
-	Generate(ctx context.Context, report *models.ScanReport) error
+	Render(ctx context.Context, w io.Writer, report *models.ScanReport) error
+}
+
+// FileNamer is implemented by reporters whose output file doesn't follow
+// the ward-report.<format> convention, e.g. names a CI system expects.
+type FileNamer interface {
+	FileName() string
+}
+
+// FileName returns the default base name of the file r writes.
+func FileName(r Reporter) string {
+	if fn, ok := r.(FileNamer); ok {
+		return fn.FileName()
+	}
+	return "ward-report." + r.Format()
 }
@@ -158,3 +158,28 @@ func TestMarkdownReporter(t *testing.T) {
 		t.Error("Markdown report should contain severity section")
 	}
 }
+
+func TestSourceContext(t *testing.T) {
+	f := testReport().Findings[0]
+	f.File = "resources/views/a.blade.php"
+	f.Context = &models.SourceContext{StartLine: 40, Lines: []string{"@if ($x)", "  {!! $html !!}", "@endif"}}
+	f.Line = 41
+
+	md := FindingMarkdown(f)
+	if !strings.Contains(md, "```blade\n  40 | @if ($x)\n> 41 |   {!! $html !!}\n") {
+		t.Errorf("Markdown context block missing or unmarked:\n%s", md)
+	}
+	if strings.Contains(md, f.CodeSnippet) {
+		t.Error("Markdown should show the context instead of the snippet")
+	}
+
+	f.Context.Lines[2] = "```"
+	if md := FindingMarkdown(f); !strings.Contains(md, "````blade\n") {
+		t.Errorf("fence should outgrow backticks in the code:\n%s", md)
+	}
+
+	html := htmlContext(f.Line, f.Context)
+	if !strings.Contains(html, `<span class="hl"><span class="ln">41</span>    {!! $html !!}</span>`) {
+		t.Errorf("HTML context should highlight the finding's line:\n%s", html)
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/eljakani/ward/internal/models"
 )
@@ -29,7 +33,12 @@ func NewSARIFReporter(outputDir string, version string) *SARIFReporter {
 func (r *SARIFReporter) Name() string   { return "sarif" }
 func (r *SARIFReporter) Format() string { return "sarif" }
 
-func (r *SARIFReporter) Generate(_ context.Context, report *models.ScanReport) error {
+// Generate writes ward-report.sarif to OutputDir.
+func (r *SARIFReporter) Generate(ctx context.Context, report *models.ScanReport) error {
+	return Write(ctx, r, filepath.Join(r.OutputDir, FileName(r)), report)
+}
+
+func (r *SARIFReporter) Render(_ context.Context, w io.Writer, report *models.ScanReport) error {
 	// Build rules from unique finding IDs
 	ruleIndex := make(map[string]int)
 	var rules []sarifRule
@@ -37,21 +46,7 @@ func (r *SARIFReporter) Generate(_ context.Context, report *models.ScanReport) e
 	for _, f := range report.Findings {
 		if _, exists := ruleIndex[f.ID]; !exists {
 			ruleIndex[f.ID] = len(rules)
-			rule := sarifRule{
-				ID:               f.ID,
-				Name:             f.Title,
-				ShortDescription: sarifMessage{Text: f.Title},
-				FullDescription:  sarifMessage{Text: f.Description},
-				DefaultConfiguration: sarifRuleConfig{
-					Level: severityToSARIFLevel(f.Severity),
-				},
-				Help: sarifMessage{Text: f.Remediation},
-				Properties: sarifRuleProperties{
-					Tags:     []string{f.Category},
-					Security: severityToSARIFSecurity(f.Severity),
-				},
-			}
-			rules = append(rules, rule)
+			rules = append(rules, sarifRuleFor(f))
 		}
 	}
 
@@ -79,7 +74,13 @@ func (r *SARIFReporter) Generate(_ context.Context, report *models.ScanReport) e
 		if f.CodeSnippet != "" {
 			result.Locations[0].PhysicalLocation.Region.Snippet = &sarifSnippet{Text: f.CodeSnippet}
 		}
-		result.PartialFingerprints = map[string]string{"primaryLocationLineHash/v1": f.Fingerprint()}
+		// primaryLocationLineHash is computed by GitHub itself; Ward's
+		// fingerprint goes under its own key so alerts keep their identity
+		// across scans.
+		result.PartialFingerprints = map[string]string{"wardFingerprint/v1": f.Fingerprint()}
+		if f.Fix != nil {
+			result.Fixes = []sarifFix{sarifFixFor(f)}
+		}
 		results = append(results, result)
 	}
 
@@ -97,7 +98,13 @@ func (r *SARIFReporter) Generate(_ context.Context, report *models.ScanReport) e
 						Rules:           rules,
 					},
 				},
-				Results: results,
+				// A distinct category keeps Ward's run apart when its
+				// SARIF is merged or uploaded next to other tools'.
+				AutomationDetails:        &sarifAutomationDetails{ID: "ward/"},
+				Invocations:              []sarifInvocation{sarifInvocationFor(report)},
+				VersionControlProvenance: sarifVersionControl(report.ProjectContext),
+				ColumnKind:               "utf16CodeUnits",
+				Results:                  results,
 			},
 		},
 	}
@@ -107,14 +114,190 @@ func (r *SARIFReporter) Generate(_ context.Context, report *models.ScanReport) e
 		return fmt.Errorf("marshalling SARIF report: %w", err)
 	}
 
-	outPath := filepath.Join(r.OutputDir, "ward-report.sarif")
-	if err := os.WriteFile(outPath, data, 0644); err != nil {
-		return fmt.Errorf("writing SARIF report to %s: %w", outPath, err)
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("writing SARIF report: %w", err)
 	}
 
 	return nil
 }
 
+// sarifRuleFor describes the rule behind f: help from its remediation and
+// references, CWE and OWASP tags, and a numeric security-severity, which
+// GitHub uses to rank alerts.
+func sarifRuleFor(f models.Finding) sarifRule {
+	tx := findingTaxonomy(f)
+
+	description := f.Description
+	if description == "" {
+		description = f.Title
+	}
+
+	rule := sarifRule{
+		ID:               f.ID,
+		Name:             f.Title,
+		ShortDescription: sarifMessage{Text: f.Title},
+		FullDescription:  sarifMessage{Text: description},
+		DefaultConfiguration: sarifRuleConfig{
+			Level: severityToSARIFLevel(f.Severity),
+		},
+		Help: sarifHelp(f, tx),
+		Properties: sarifRuleProperties{
+			Tags:     sarifTags(f, tx),
+			Security: severityToSARIFSecurity(f.Severity),
+		},
+	}
+	if len(f.References) > 0 {
+		rule.HelpURI = f.References[0]
+	} else if len(tx.CWEs) > 0 {
+		rule.HelpURI = cweURL(tx.CWEs[0])
+	}
+	return rule
+}
+
+func sarifHelp(f models.Finding, tx taxonomy) sarifMessage {
+	var text, md strings.Builder
+	if f.Remediation != "" {
+		text.WriteString(f.Remediation)
+		md.WriteString("**Remediation**\n\n" + f.Remediation)
+	}
+
+	var links []string
+	for _, n := range tx.CWEs {
+		links = append(links, fmt.Sprintf("[CWE-%s](%s)", n, cweURL(n)))
+	}
+	for _, id := range tx.OWASP {
+		links = append(links, "OWASP "+owaspName(id))
+	}
+	links = append(links, f.References...)
+	if len(links) > 0 {
+		if text.Len() > 0 {
+			text.WriteString("\n\n")
+			md.WriteString("\n\n")
+		}
+		text.WriteString("References:")
+		md.WriteString("**References**\n")
+		for _, l := range links {
+			text.WriteString("\n- " + l)
+			md.WriteString("\n- " + l)
+		}
+	}
+
+	if text.Len() == 0 {
+		return sarifMessage{Text: f.Title}
+	}
+	return sarifMessage{Text: text.String(), Markdown: md.String()}
+}
+
+// sarifTags lists "security" and the finding's category, then CWE and
+// OWASP entries in the external/<taxonomy>/ form GitHub recognises, then
+// any other rule tags.
+func sarifTags(f models.Finding, tx taxonomy) []string {
+	tags := []string{"security"}
+	if f.Category != "" {
+		tags = append(tags, f.Category)
+	}
+	for _, n := range tx.CWEs {
+		num, _ := strconv.Atoi(n)
+		tags = append(tags, fmt.Sprintf("external/cwe/cwe-%03d", num))
+	}
+	for _, id := range tx.OWASP {
+		tags = append(tags, "external/owasp/"+strings.ToLower(id))
+	}
+	for _, tag := range f.Tags {
+		if !cweTagRe.MatchString(tag) && !owaspTagRe.MatchString(tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	seen := make(map[string]bool)
+	out := tags[:0]
+	for _, t := range tags {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// sarifInvocationFor records the scan's timing and any scanner that
+// failed. The run still counts as successful unless nothing ran at all.
+func sarifInvocationFor(report *models.ScanReport) sarifInvocation {
+	inv := sarifInvocation{
+		ExecutionSuccessful: len(report.ScannersRun) > 0 || len(report.ScannerErrors) == 0,
+	}
+	if !report.StartedAt.IsZero() {
+		inv.StartTimeUTC = report.StartedAt.UTC().Format(time.RFC3339)
+	}
+	if !report.CompletedAt.IsZero() {
+		inv.EndTimeUTC = report.CompletedAt.UTC().Format(time.RFC3339)
+	}
+
+	var failed []string
+	for name := range report.ScannerErrors {
+		failed = append(failed, name)
+	}
+	sort.Strings(failed)
+	for _, name := range failed {
+		inv.ToolExecutionNotifications = append(inv.ToolExecutionNotifications, sarifNotification{
+			Level:      "error",
+			Message:    sarifMessage{Text: fmt.Sprintf("%s failed: %s", name, report.ScannerErrors[name])},
+			Descriptor: &sarifDescriptorRef{ID: name},
+		})
+	}
+	return inv
+}
+
+// sarifVersionControl records the repository and commit that was scanned.
+// SARIF requires a repository URI, so nothing is recorded without a
+// remote.
+func sarifVersionControl(pc models.ProjectContext) []sarifVersionControlDetails {
+	if pc.GitRemote == "" {
+		return nil
+	}
+	return []sarifVersionControlDetails{{
+		RepositoryURI: sarifRepositoryURI(pc.GitRemote),
+		RevisionID:    pc.GitCommit,
+		Branch:        pc.GitBranch,
+	}}
+}
+
+// sarifRepositoryURI turns an scp-style remote (git@host:owner/repo.git)
+// into an ssh:// URI; other remotes are already URIs.
+func sarifRepositoryURI(remote string) string {
+	if strings.Contains(remote, "://") {
+		return remote
+	}
+	if host, path, ok := strings.Cut(remote, ":"); ok && !strings.Contains(host, "/") {
+		return "ssh://" + host + "/" + strings.TrimPrefix(path, "/")
+	}
+	return remote
+}
+
+// sarifFixFor converts a finding's Fix into a SARIF fix object, which code
+// scanning UIs render as a suggested change.
+func sarifFixFor(f models.Finding) sarifFix {
+	start, end := f.Fix.UTF16Columns()
+	return sarifFix{
+		Description: sarifMessage{Text: f.Fix.Description},
+		ArtifactChanges: []sarifArtifactChange{
+			{
+				ArtifactLocation: sarifArtifactLocation{URI: f.File},
+				Replacements: []sarifReplacement{
+					{
+						DeletedRegion: sarifRegion{
+							StartLine:   f.Fix.Line,
+							StartColumn: start,
+							EndColumn:   end,
+						},
+						InsertedContent: &sarifArtifactContent{Text: f.Fix.Replacement},
+					},
+				},
+			},
+		},
+	}
+}
+
 func severityToSARIFLevel(s models.Severity) string {
 	switch s {
 	case models.SeverityCritical, models.SeverityHigh:
@@ -126,18 +309,21 @@ func severityToSARIFLevel(s models.Severity) string {
 	}
 }
 
+// severityToSARIFSecurity returns a CVSS-style score in the middle of the
+// band GitHub maps to each severity (critical is 9.0 and above, high 7.0,
+// medium 4.0, low 0.1). Info findings get none.
 func severityToSARIFSecurity(s models.Severity) string {
 	switch s {
 	case models.SeverityCritical:
-		return "critical"
+		return "9.5"
 	case models.SeverityHigh:
-		return "high"
+		return "8.0"
 	case models.SeverityMedium:
-		return "medium"
+		return "5.5"
 	case models.SeverityLow:
-		return "low"
+		return "2.0"
 	default:
-		return "informational"
+		return ""
 	}
 }
 
@@ -150,8 +336,39 @@ type sarifDocument struct {
 }
 
 type sarifRun struct {
-	Tool    sarifTool     `json:"tool"`
-	Results []sarifResult `json:"results"`
+	Tool                     sarifTool                    `json:"tool"`
+	AutomationDetails        *sarifAutomationDetails      `json:"automationDetails,omitempty"`
+	Invocations              []sarifInvocation            `json:"invocations,omitempty"`
+	VersionControlProvenance []sarifVersionControlDetails `json:"versionControlProvenance,omitempty"`
+	ColumnKind               string                       `json:"columnKind,omitempty"`
+	Results                  []sarifResult                `json:"results"`
+}
+
+type sarifAutomationDetails struct {
+	ID string `json:"id"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	StartTimeUTC               string              `json:"startTimeUtc,omitempty"`
+	EndTimeUTC                 string              `json:"endTimeUtc,omitempty"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level      string              `json:"level"`
+	Message    sarifMessage        `json:"message"`
+	Descriptor *sarifDescriptorRef `json:"descriptor,omitempty"`
+}
+
+type sarifDescriptorRef struct {
+	ID string `json:"id"`
+}
+
+type sarifVersionControlDetails struct {
+	RepositoryURI string `json:"repositoryUri"`
+	RevisionID    string `json:"revisionId,omitempty"`
+	Branch        string `json:"branch,omitempty"`
 }
 
 type sarifTool struct {
@@ -172,6 +389,7 @@ type sarifRule struct {
 	ShortDescription     sarifMessage        `json:"shortDescription"`
 	FullDescription      sarifMessage        `json:"fullDescription"`
 	DefaultConfiguration sarifRuleConfig     `json:"defaultConfiguration"`
+	HelpURI              string              `json:"helpUri,omitempty"`
 	Help                 sarifMessage        `json:"help"`
 	Properties           sarifRuleProperties `json:"properties"`
 }
@@ -182,11 +400,12 @@ type sarifRuleConfig struct {
 
 type sarifRuleProperties struct {
 	Tags     []string `json:"tags"`
-	Security string   `json:"security-severity"`
+	Security string   `json:"security-severity,omitempty"`
 }
 
 type sarifMessage struct {
-	Text string `json:"text"`
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
 }
 
 type sarifResult struct {
@@ -196,6 +415,7 @@ type sarifResult struct {
 	Message             sarifMessage      `json:"message"`
 	Locations           []sarifLocation   `json:"locations"`
 	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Fixes               []sarifFix        `json:"fixes,omitempty"`
 }
 
 type sarifLocation struct {
@@ -208,14 +428,36 @@ type sarifPhysicalLocation struct {
 }
 
 type sarifArtifactLocation struct {
-	URI string `json:"uri"`
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"` // left empty: URIs are relative to the repository root
 }
 
 type sarifRegion struct {
-	StartLine int           `json:"startLine"`
-	Snippet   *sarifSnippet `json:"snippet,omitempty"`
+	StartLine   int           `json:"startLine"`
+	StartColumn int           `json:"startColumn,omitempty"`
+	EndColumn   int           `json:"endColumn,omitempty"`
+	Snippet     *sarifSnippet `json:"snippet,omitempty"`
 }
 
 type sarifSnippet struct {
 	Text string `json:"text"`
 }
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion           `json:"deletedRegion"`
+	InsertedContent *sarifArtifactContent `json:"insertedContent,omitempty"`
+}
+
+type sarifArtifactContent struct {
+	Text string `json:"text"`
+}
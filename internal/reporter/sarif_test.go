@@ -1,12 +1,17 @@
 package reporter
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/eljakani/ward/internal/models"
 )
 
 func TestSARIFReporter_Generate_ValidatesOutput(t *testing.T) {
@@ -62,3 +67,149 @@ func TestSARIFReporter_Generate_ValidatesOutput(t *testing.T) {
 		t.Error("URI should not be empty")
 	}
 }
+
+func TestSARIFReporter_Fixes(t *testing.T) {
+	dir := t.TempDir()
+	r := NewSARIFReporter(dir, "1.0.0")
+
+	report := testReport()
+	report.Findings[0].Fix = &models.Fix{
+		Description: "Hash the password with Hash::make()",
+		Line:        42,
+		StartColumn: 13,
+		EndColumn:   28,
+		Original:    "md5($password)",
+		Replacement: "Hash::make($password)",
+	}
+	if err := r.Generate(context.Background(), report); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "ward-report.sarif"))
+	var doc sarifDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		t.Fatalf("generated invalid JSON: %v", err)
+	}
+
+	results := doc.Runs[0].Results
+	if len(results[0].Fixes) != 1 {
+		t.Fatalf("expected 1 fix on the first result, got %d", len(results[0].Fixes))
+	}
+	if len(results[1].Fixes) != 0 {
+		t.Errorf("finding without a fix should have no fixes, got %d", len(results[1].Fixes))
+	}
+
+	change := results[0].Fixes[0].ArtifactChanges[0]
+	if change.ArtifactLocation.URI != "app/Test.php" {
+		t.Errorf("fix URI = %q, want app/Test.php", change.ArtifactLocation.URI)
+	}
+	rep := change.Replacements[0]
+	if rep.DeletedRegion.StartLine != 42 || rep.DeletedRegion.StartColumn != 13 || rep.DeletedRegion.EndColumn != 28 {
+		t.Errorf("deletedRegion = %+v, want line 42 columns 13-28", rep.DeletedRegion)
+	}
+	if rep.InsertedContent == nil || rep.InsertedContent.Text != "Hash::make($password)" {
+		t.Errorf("insertedContent = %+v, want Hash::make($password)", rep.InsertedContent)
+	}
+}
+
+func TestSARIFReporter_FixColumnsUTF16(t *testing.T) {
+	dir := t.TempDir()
+	r := NewSARIFReporter(dir, "1.0.0")
+
+	// é is 2 bytes and 1 UTF-16 unit, 😀 4 bytes and 2 units, so the
+	// match starts at byte column 28 but UTF-16 column 25.
+	line := `$msg = "héllo 😀"; $h = md5($password);`
+	report := testReport()
+	report.Findings[0].Fix = models.LineFix("Hash the password", 42, line, regexp.MustCompile(`md5\((\$\w+)\)`), "Hash::make($1)")
+	if report.Findings[0].Fix.StartColumn != 28 {
+		t.Fatalf("byte StartColumn = %d, want 28", report.Findings[0].Fix.StartColumn)
+	}
+	if err := r.Generate(context.Background(), report); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "ward-report.sarif"))
+	var doc sarifDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		t.Fatalf("generated invalid JSON: %v", err)
+	}
+	region := doc.Runs[0].Results[0].Fixes[0].ArtifactChanges[0].Replacements[0].DeletedRegion
+	if region.StartColumn != 25 || region.EndColumn != 39 {
+		t.Errorf("deletedRegion columns = %d-%d, want 25-39", region.StartColumn, region.EndColumn)
+	}
+}
+
+func TestSARIFReporter_Metadata(t *testing.T) {
+	report := testReport()
+	report.StartedAt = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	report.CompletedAt = report.StartedAt.Add(2 * time.Second)
+	report.ProjectContext.GitCommit = "3f2a1c9e8b7d6a5f4e3d2c1b0a9f8e7d6c5b4a39"
+	report.ProjectContext.GitBranch = "main"
+	report.ProjectContext.GitRemote = "git@github.com:acme/shop.git"
+	report.ScannersRun = []string{"env-scanner"}
+	report.ScannerErrors = map[string]string{"dependency-scanner": "OSV.dev unreachable"}
+	report.Findings[0].Tags = []string{"secrets", "cwe-798", "owasp-a07"}
+	report.Findings[0].References = []string{"https://laravel.com/docs/hashing"}
+
+	var buf bytes.Buffer
+	if err := NewSARIFReporter("", "1.0.0").Render(context.Background(), &buf, report); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var doc sarifDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("generated invalid JSON: %v", err)
+	}
+	run := doc.Runs[0]
+
+	rule := run.Tool.Driver.Rules[0]
+	if rule.HelpURI != "https://laravel.com/docs/hashing" {
+		t.Errorf("helpUri = %q", rule.HelpURI)
+	}
+	if !strings.Contains(rule.Help.Markdown, "**Remediation**") || !strings.Contains(rule.Help.Markdown, "[CWE-798](https://cwe.mitre.org/data/definitions/798.html)") {
+		t.Errorf("help.markdown = %q", rule.Help.Markdown)
+	}
+	wantTags := []string{"security", "Test", "external/cwe/cwe-798", "external/owasp/a07:2021", "secrets"}
+	if strings.Join(rule.Properties.Tags, ",") != strings.Join(wantTags, ",") {
+		t.Errorf("tags = %v, want %v", rule.Properties.Tags, wantTags)
+	}
+	if rule.Properties.Security != "9.5" {
+		t.Errorf("security-severity = %q, want 9.5 for a critical finding", rule.Properties.Security)
+	}
+
+	for i, res := range run.Results {
+		if res.PartialFingerprints["wardFingerprint/v1"] != report.Findings[i].Fingerprint() {
+			t.Errorf("result %d partialFingerprints = %v", i, res.PartialFingerprints)
+		}
+	}
+
+	if run.AutomationDetails == nil || run.AutomationDetails.ID != "ward/" {
+		t.Errorf("automationDetails = %+v", run.AutomationDetails)
+	}
+
+	inv := run.Invocations[0]
+	if !inv.ExecutionSuccessful || inv.StartTimeUTC != "2026-03-01T10:00:00Z" {
+		t.Errorf("invocation = %+v", inv)
+	}
+	if len(inv.ToolExecutionNotifications) != 1 || inv.ToolExecutionNotifications[0].Descriptor.ID != "dependency-scanner" {
+		t.Errorf("notifications = %+v", inv.ToolExecutionNotifications)
+	}
+
+	want := sarifVersionControlDetails{
+		RepositoryURI: "ssh://git@github.com/acme/shop.git",
+		RevisionID:    report.ProjectContext.GitCommit,
+		Branch:        "main",
+	}
+	if len(run.VersionControlProvenance) != 1 || run.VersionControlProvenance[0] != want {
+		t.Errorf("versionControlProvenance = %+v, want %+v", run.VersionControlProvenance, want)
+	}
+}
+
+func TestSARIFReporter_NoRemote(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewSARIFReporter("", "1.0.0").Render(context.Background(), &buf, testReport()); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "versionControlProvenance") {
+		t.Error("versionControlProvenance needs a repository URI and should be omitted without a remote")
+	}
+}
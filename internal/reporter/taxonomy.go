@@ -0,0 +1,88 @@
+package reporter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// Ward's owasp-aNN tags and OWASP links refer to the 2021 Top 10.
+const owaspEdition = "2021"
+
+var (
+	cweTagRe    = regexp.MustCompile(`(?i)^cwe-(\d+)$`)
+	owaspTagRe  = regexp.MustCompile(`(?i)^owasp-a(\d{1,2})$`)
+	cweURLRe    = regexp.MustCompile(`cwe\.mitre\.org/data/definitions/(\d+)\.html`)
+	owaspURLRe  = regexp.MustCompile(`owasp\.org/Top10/A(\d{2})_(\d{4})`)
+	owaspTitles = map[string]string{
+		"A01": "Broken Access Control",
+		"A02": "Cryptographic Failures",
+		"A03": "Injection",
+		"A04": "Insecure Design",
+		"A05": "Security Misconfiguration",
+		"A06": "Vulnerable and Outdated Components",
+		"A07": "Identification and Authentication Failures",
+		"A08": "Software and Data Integrity Failures",
+		"A09": "Security Logging and Monitoring Failures",
+		"A10": "Server-Side Request Forgery",
+	}
+)
+
+// taxonomy holds the CWE and OWASP Top 10 entries a finding maps to.
+type taxonomy struct {
+	CWEs  []string // numbers, e.g. "89"
+	OWASP []string // e.g. "A03:2021"
+}
+
+// findingTaxonomy collects CWE and OWASP identifiers from a finding's
+// rule tags (cwe-89, owasp-a03) and reference URLs, without duplicates.
+func findingTaxonomy(f models.Finding) taxonomy {
+	var t taxonomy
+	seen := make(map[string]bool)
+	addCWE := func(n string) {
+		n = strings.TrimLeft(n, "0")
+		if n != "" && !seen["cwe:"+n] {
+			seen["cwe:"+n] = true
+			t.CWEs = append(t.CWEs, n)
+		}
+	}
+	addOWASP := func(n, year string) {
+		num, _ := strconv.Atoi(n)
+		id := fmt.Sprintf("A%02d:%s", num, year)
+		if num > 0 && !seen["owasp:"+id] {
+			seen["owasp:"+id] = true
+			t.OWASP = append(t.OWASP, id)
+		}
+	}
+
+	for _, tag := range f.Tags {
+		if m := cweTagRe.FindStringSubmatch(tag); m != nil {
+			addCWE(m[1])
+		} else if m := owaspTagRe.FindStringSubmatch(tag); m != nil {
+			addOWASP(m[1], owaspEdition)
+		}
+	}
+	for _, ref := range f.References {
+		if m := cweURLRe.FindStringSubmatch(ref); m != nil {
+			addCWE(m[1])
+		} else if m := owaspURLRe.FindStringSubmatch(ref); m != nil {
+			addOWASP(m[1], m[2])
+		}
+	}
+	return t
+}
+
+func cweURL(n string) string {
+	return "https://cwe.mitre.org/data/definitions/" + n + ".html"
+}
+
+// owaspName returns e.g. "A03:2021 - Injection".
+func owaspName(id string) string {
+	if title, ok := owaspTitles[id[:3]]; ok && strings.HasSuffix(id, ":"+owaspEdition) {
+		return id + " - " + title
+	}
+	return id
+}
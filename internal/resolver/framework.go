@@ -64,7 +64,7 @@ func (r *FrameworkResolver) resolveComposer(root string, pc *models.ProjectConte
 }
 
 func (r *FrameworkResolver) resolveEnv(root string, pc *models.ProjectContext) {
-	envVars := parseEnvFile(filepath.Join(root, ".env"))
+	envVars := ParseEnvFile(filepath.Join(root, ".env"))
 
 	if name, ok := envVars["APP_NAME"]; ok && pc.ProjectName == "" {
 		pc.ProjectName = name
@@ -89,8 +89,9 @@ func (r *FrameworkResolver) resolveEnv(root string, pc *models.ProjectContext) {
 	}
 }
 
-// parseEnvFile reads a .env file into a key-value map.
-func parseEnvFile(path string) map[string]string {
+// ParseEnvFile reads a .env file into a key-value map. It returns nil if
+// the file can't be read.
+func ParseEnvFile(path string) map[string]string {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil
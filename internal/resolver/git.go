@@ -0,0 +1,166 @@
+package resolver
+
+import (
+	"bufio"
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// GitResolver records the commit and branch the project is checked out
+// at and its origin remote. It reads .git directly so scans don't depend
+// on a git binary.
+type GitResolver struct{}
+
+func NewGitResolver() *GitResolver {
+	return &GitResolver{}
+}
+
+func (r *GitResolver) Name() string  { return "git" }
+func (r *GitResolver) Priority() int { return 30 }
+
+func (r *GitResolver) Resolve(_ context.Context, root string, pc *models.ProjectContext) error {
+	gitDir := findGitDir(root)
+	if gitDir == "" {
+		return nil // not a repository
+	}
+	dirs := gitDirs(gitDir)
+	pc.GitCommit, pc.GitBranch = readHead(gitDir, dirs)
+	pc.GitRemote = originURL(dirs)
+	return nil
+}
+
+// findGitDir returns the git directory for root, following the
+// "gitdir:" file used by worktrees and submodules.
+func findGitDir(root string) string {
+	path := filepath.Join(root, ".git")
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	if info.IsDir() {
+		return path
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	dir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	dir = strings.TrimSpace(dir)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	return dir
+}
+
+// gitDirs returns gitDir followed by the main repository's git directory
+// for worktrees, which keep shared refs and config there.
+func gitDirs(gitDir string) []string {
+	dirs := []string{gitDir}
+	if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		c := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(c) {
+			c = filepath.Join(gitDir, c)
+		}
+		dirs = append(dirs, c)
+	}
+	return dirs
+}
+
+// readHead resolves HEAD to a commit hash and branch name, looking the
+// ref up as a loose file first and then in packed-refs. The branch is
+// empty for a detached HEAD.
+func readHead(gitDir string, dirs []string) (commit, branch string) {
+	data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", ""
+	}
+	head := strings.TrimSpace(string(data))
+	ref, ok := strings.CutPrefix(head, "ref: ")
+	if !ok {
+		return head, "" // detached HEAD
+	}
+	branch = strings.TrimPrefix(ref, "refs/heads/")
+
+	for _, dir := range dirs {
+		if data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(ref))); err == nil {
+			return strings.TrimSpace(string(data)), branch
+		}
+	}
+	for _, dir := range dirs {
+		if hash := packedRef(filepath.Join(dir, "packed-refs"), ref); hash != "" {
+			return hash, branch
+		}
+	}
+	return "", branch
+}
+
+// originURL reads the url of [remote "origin"] from the repository
+// config, dropping any credentials embedded in it.
+func originURL(dirs []string) string {
+	for _, dir := range dirs {
+		if u := configOrigin(filepath.Join(dir, "config")); u != "" {
+			return stripCredentials(u)
+		}
+	}
+	return ""
+}
+
+func configOrigin(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	inOrigin := false
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(line, "[") {
+			inOrigin = line == `[remote "origin"]`
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if inOrigin && ok && strings.TrimSpace(key) == "url" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// stripCredentials removes user:token@ from URL-style remotes. scp-style
+// remotes (git@host:path) carry no secret and are kept as they are.
+func stripCredentials(remote string) string {
+	u, err := url.Parse(remote)
+	if err != nil || u.Scheme == "" || u.User == nil {
+		return remote
+	}
+	u.User = nil
+	return u.String()
+}
+
+func packedRef(path, ref string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		hash, name, ok := strings.Cut(sc.Text(), " ")
+		if ok && name == ref {
+			return hash
+		}
+	}
+	return ""
+}
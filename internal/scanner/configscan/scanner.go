@@ -20,6 +20,57 @@ func New() *Scanner { return &Scanner{} }
 func (s *Scanner) Name() string        { return "config-scanner" }
 func (s *Scanner) Description() string { return "Laravel configuration security checks" }
 
+// checks are the built-in config/*.php checks, as Checks lists them.
+// Findings take their ID, severity, category and tags from here, and
+// their title unless they set a more specific one.
+var checks = []check{
+	{ID: "CFG-001", Title: "Debug mode hardcoded to true in app.php", Severity: models.SeverityHigh, Category: "Configuration", Tags: []string{"cwe-215"}},
+	{ID: "CFG-002", Title: "Non-standard encryption cipher configured", Severity: models.SeverityMedium, Category: "Cryptography", Tags: []string{"cwe-327"}},
+	{ID: "CFG-003", Title: "Password reset token expiry is very long", Severity: models.SeverityLow, Category: "Authentication", Tags: []string{"cwe-640"}},
+	{ID: "CFG-004", Title: "Session cookie missing HttpOnly flag", Severity: models.SeverityHigh, Category: "Configuration", Tags: []string{"cwe-1004"}},
+	{ID: "CFG-005", Title: "Session cookie missing Secure flag", Severity: models.SeverityMedium, Category: "Configuration", Tags: []string{"cwe-614"}},
+	{ID: "CFG-006", Title: "Session cookie SameSite set to none", Severity: models.SeverityMedium, Category: "Configuration", Tags: []string{"cwe-1275"}},
+	{ID: "CFG-007", Title: "Session lifetime is excessively long", Severity: models.SeverityLow, Category: "Configuration", Tags: []string{"cwe-613"}},
+	{ID: "CFG-008", Title: "Mail password hardcoded in config", Severity: models.SeverityHigh, Category: "Secrets", Tags: []string{"cwe-798"}},
+	{ID: "CFG-009", Title: "CORS allows all origins", Severity: models.SeverityMedium, Category: "Configuration", Tags: []string{"cwe-942"}},
+	{ID: "CFG-010", Title: "CORS allows credentials with wildcard origin", Severity: models.SeverityHigh, Category: "Configuration", Tags: []string{"cwe-942"}},
+	{ID: "CFG-011", Title: "Database password hardcoded in config", Severity: models.SeverityHigh, Category: "Secrets", Tags: []string{"cwe-798"}},
+	{ID: "CFG-012", Title: "Broadcasting secret/key hardcoded in config", Severity: models.SeverityMedium, Category: "Secrets", Tags: []string{"cwe-798"}},
+	{ID: "CFG-013", Title: "Slack webhook URL hardcoded in logging config", Severity: models.SeverityMedium, Category: "Secrets", Tags: []string{"cwe-798"}},
+}
+
+type check struct {
+	ID       string
+	Title    string
+	Severity models.Severity
+	Category string
+	Tags     []string
+}
+
+// Checks lists the built-in config/*.php checks.
+func (s *Scanner) Checks() []models.Check {
+	out := make([]models.Check, len(checks))
+	for i, c := range checks {
+		out[i] = models.Check{ID: c.ID, Title: c.Title, Category: c.Category, Tags: c.Tags}
+	}
+	return out
+}
+
+// finding completes f from the check with the given ID.
+func (s *Scanner) finding(id string, f models.Finding) models.Finding {
+	for _, c := range checks {
+		if c.ID == id {
+			f.ID, f.Severity, f.Category, f.Tags = c.ID, c.Severity, c.Category, c.Tags
+			if f.Title == "" {
+				f.Title = c.Title
+			}
+			break
+		}
+	}
+	f.Scanner = s.Name()
+	return f
+}
+
 func (s *Scanner) Scan(_ context.Context, project models.ProjectContext, emit func(models.Finding)) ([]models.Finding, error) {
 	configDir := filepath.Join(project.RootPath, "config")
 	if _, err := os.Stat(configDir); err != nil {
@@ -64,35 +115,26 @@ func (s *Scanner) checkApp(path string) []models.Finding {
 
 	// Debug mode hardcoded to true
 	if line, n := findPattern(lines, `'debug'\s*=>\s*true`); n > 0 {
-		findings = append(findings, models.Finding{
-			ID:          "CFG-001",
-			Title:       "Debug mode hardcoded to true in app.php",
+		findings = append(findings, s.finding("CFG-001", models.Finding{
 			Description: "config/app.php has 'debug' => true instead of reading from env(). This means debug mode is always on, even in production.",
-			Severity:    models.SeverityHigh,
-			Category:    "Configuration",
-			Scanner:     s.Name(),
 			File:        "config/app.php",
 			Line:        n,
 			CodeSnippet: line,
 			Remediation: "Use: 'debug' => env('APP_DEBUG', false),",
 			References:  []string{"https://owasp.org/Top10/A05_2021-Security_Misconfiguration/"},
-		})
+			Fix:         lineFix(lines, n, `'debug'\s*=>\s*true`, "'debug' => env('APP_DEBUG', false)", "Read debug mode from APP_DEBUG"),
+		}))
 	}
 
 	// Cipher not AES-256-CBC
 	if line, n := findPattern(lines, `'cipher'\s*=>\s*'(?i)((?!aes-256-cbc).+)'`); n > 0 {
-		findings = append(findings, models.Finding{
-			ID:          "CFG-002",
-			Title:       "Non-standard encryption cipher configured",
+		findings = append(findings, s.finding("CFG-002", models.Finding{
 			Description: "The application encryption cipher is not the recommended AES-256-CBC. Using a weaker cipher reduces the security of encrypted data.",
-			Severity:    models.SeverityMedium,
-			Category:    "Cryptography",
-			Scanner:     s.Name(),
 			File:        "config/app.php",
 			Line:        n,
 			CodeSnippet: line,
 			Remediation: "Use: 'cipher' => 'AES-256-CBC',",
-		})
+		}))
 	}
 
 	return findings
@@ -105,18 +147,13 @@ func (s *Scanner) checkAuth(path string) []models.Finding {
 
 	// Password reset expiry too long (> 120 minutes)
 	if line, n := findPattern(lines, `'expire'\s*=>\s*(\d{3,})`); n > 0 {
-		findings = append(findings, models.Finding{
-			ID:          "CFG-003",
-			Title:       "Password reset token expiry is very long",
+		findings = append(findings, s.finding("CFG-003", models.Finding{
 			Description: "The password reset token expires after a very long period. Long-lived reset tokens increase the window for token theft and reuse.",
-			Severity:    models.SeverityLow,
-			Category:    "Authentication",
-			Scanner:     s.Name(),
 			File:        "config/auth.php",
 			Line:        n,
 			CodeSnippet: line,
 			Remediation: "Set a reasonable expiry: 'expire' => 60, (60 minutes)",
-		})
+		}))
 	}
 
 	return findings
@@ -129,68 +166,50 @@ func (s *Scanner) checkSession(path string) []models.Finding {
 
 	// Session cookie not httponly
 	if line, n := findPattern(lines, `'http_only'\s*=>\s*false`); n > 0 {
-		findings = append(findings, models.Finding{
-			ID:          "CFG-004",
-			Title:       "Session cookie missing HttpOnly flag",
+		findings = append(findings, s.finding("CFG-004", models.Finding{
 			Description: "The session cookie HttpOnly flag is set to false. This allows JavaScript to access the session cookie, enabling theft through XSS attacks.",
-			Severity:    models.SeverityHigh,
-			Category:    "Configuration",
-			Scanner:     s.Name(),
 			File:        "config/session.php",
 			Line:        n,
 			CodeSnippet: line,
 			Remediation: "Set: 'http_only' => true,",
 			References:  []string{"https://cwe.mitre.org/data/definitions/1004.html"},
-		})
+			Fix:         lineFix(lines, n, `('http_only'\s*=>\s*)false`, "${1}true", "Enable the HttpOnly flag"),
+		}))
 	}
 
 	// Session cookie not secure
 	if line, n := findPattern(lines, `'secure'\s*=>\s*false`); n > 0 {
-		findings = append(findings, models.Finding{
-			ID:          "CFG-005",
-			Title:       "Session cookie missing Secure flag",
+		findings = append(findings, s.finding("CFG-005", models.Finding{
 			Description: "The session cookie Secure flag is false. The cookie will be sent over plain HTTP, allowing session hijacking via network sniffing.",
-			Severity:    models.SeverityMedium,
-			Category:    "Configuration",
-			Scanner:     s.Name(),
 			File:        "config/session.php",
 			Line:        n,
 			CodeSnippet: line,
 			Remediation: "Set: 'secure' => env('SESSION_SECURE_COOKIE', true),",
 			References:  []string{"https://cwe.mitre.org/data/definitions/614.html"},
-		})
+			Fix:         lineFix(lines, n, `'secure'\s*=>\s*false`, "'secure' => env('SESSION_SECURE_COOKIE', true)", "Read the Secure flag from SESSION_SECURE_COOKIE"),
+		}))
 	}
 
 	// SameSite not strict or lax
 	if line, n := findPattern(lines, `'same_site'\s*=>\s*('none'|null)`); n > 0 {
-		findings = append(findings, models.Finding{
-			ID:          "CFG-006",
-			Title:       "Session cookie SameSite set to none",
+		findings = append(findings, s.finding("CFG-006", models.Finding{
 			Description: "The SameSite attribute is set to 'none', allowing the cookie to be sent with cross-site requests. This weakens CSRF protection.",
-			Severity:    models.SeverityMedium,
-			Category:    "Configuration",
-			Scanner:     s.Name(),
 			File:        "config/session.php",
 			Line:        n,
 			CodeSnippet: line,
 			Remediation: "Set: 'same_site' => 'lax', (or 'strict' for maximum protection)",
-		})
+		}))
 	}
 
 	// Session lifetime very long (> 480 minutes = 8 hours)
 	if line, n := findPattern(lines, `'lifetime'\s*=>\s*(\d{4,})`); n > 0 {
-		findings = append(findings, models.Finding{
-			ID:          "CFG-007",
-			Title:       "Session lifetime is excessively long",
+		findings = append(findings, s.finding("CFG-007", models.Finding{
 			Description: "Sessions persist for an unusually long time. Long session lifetimes increase the risk of session hijacking and unauthorized access from abandoned sessions.",
-			Severity:    models.SeverityLow,
-			Category:    "Configuration",
-			Scanner:     s.Name(),
 			File:        "config/session.php",
 			Line:        n,
 			CodeSnippet: line,
 			Remediation: "Set a reasonable session lifetime: 'lifetime' => 120, (2 hours)",
-		})
+		}))
 	}
 
 	return findings
@@ -205,18 +224,13 @@ func (s *Scanner) checkMail(path string) []models.Finding {
 	if line, n := findPattern(lines, `'password'\s*=>\s*'[^']{4,}'`); n > 0 {
 		// Make sure it's not env()
 		if !strings.Contains(line, "env(") {
-			findings = append(findings, models.Finding{
-				ID:          "CFG-008",
-				Title:       "Mail password hardcoded in config",
+			findings = append(findings, s.finding("CFG-008", models.Finding{
 				Description: "A mail password is hardcoded in config/mail.php instead of using env(). This credential is exposed to anyone with source access.",
-				Severity:    models.SeverityHigh,
-				Category:    "Secrets",
-				Scanner:     s.Name(),
 				File:        "config/mail.php",
 				Line:        n,
 				CodeSnippet: strings.Replace(line, line, maskConfigValue(line), 1),
 				Remediation: "Use: 'password' => env('MAIL_PASSWORD'),",
-			})
+			}))
 		}
 	}
 
@@ -229,37 +243,27 @@ func (s *Scanner) checkCORS(path string) []models.Finding {
 	lines := toLines(content)
 
 	if line, n := findPattern(lines, `'allowed_origins'\s*=>\s*\[\s*'\*'\s*\]`); n > 0 {
-		findings = append(findings, models.Finding{
-			ID:          "CFG-009",
-			Title:       "CORS allows all origins",
+		findings = append(findings, s.finding("CFG-009", models.Finding{
 			Description: "config/cors.php allows requests from any origin ('*'). This permits cross-site data theft if authenticated endpoints return sensitive data.",
-			Severity:    models.SeverityMedium,
-			Category:    "Configuration",
-			Scanner:     s.Name(),
 			File:        "config/cors.php",
 			Line:        n,
 			CodeSnippet: line,
 			Remediation: "Specify allowed origins: 'allowed_origins' => [env('FRONTEND_URL')],",
 			References:  []string{"https://cwe.mitre.org/data/definitions/942.html"},
-		})
+		}))
 	}
 
 	if line, n := findPattern(lines, `'supports_credentials'\s*=>\s*true`); n > 0 {
 		// Credentials + wildcard is especially dangerous
 		if _, wn := findPattern(lines, `'allowed_origins'\s*=>\s*\[\s*'\*'\s*\]`); wn > 0 {
-			findings = append(findings, models.Finding{
-				ID:          "CFG-010",
-				Title:       "CORS allows credentials with wildcard origin",
+			findings = append(findings, s.finding("CFG-010", models.Finding{
 				Description: "CORS is configured with both 'supports_credentials' => true and wildcard allowed_origins. This combination allows any website to make authenticated requests to your API.",
-				Severity:    models.SeverityHigh,
-				Category:    "Configuration",
-				Scanner:     s.Name(),
 				File:        "config/cors.php",
 				Line:        n,
 				CodeSnippet: line,
 				Remediation: "Never combine 'supports_credentials' => true with wildcard origins. Specify exact allowed origins.",
 				References:  []string{"https://cwe.mitre.org/data/definitions/942.html"},
-			})
+			}))
 		}
 	}
 
@@ -274,18 +278,13 @@ func (s *Scanner) checkDatabase(path string) []models.Finding {
 	// Hardcoded database password
 	if line, n := findPattern(lines, `'password'\s*=>\s*'[^']{4,}'`); n > 0 {
 		if !strings.Contains(line, "env(") {
-			findings = append(findings, models.Finding{
-				ID:          "CFG-011",
-				Title:       "Database password hardcoded in config",
+			findings = append(findings, s.finding("CFG-011", models.Finding{
 				Description: "A database password is hardcoded in config/database.php. Use env() to keep credentials out of source.",
-				Severity:    models.SeverityHigh,
-				Category:    "Secrets",
-				Scanner:     s.Name(),
 				File:        "config/database.php",
 				Line:        n,
 				CodeSnippet: maskConfigValue(line),
 				Remediation: "Use: 'password' => env('DB_PASSWORD', ''),",
-			})
+			}))
 		}
 	}
 
@@ -300,18 +299,13 @@ func (s *Scanner) checkBroadcasting(path string) []models.Finding {
 	// Hardcoded Pusher keys
 	if line, n := findPattern(lines, `'(secret|key)'\s*=>\s*'[a-zA-Z0-9]{10,}'`); n > 0 {
 		if !strings.Contains(line, "env(") {
-			findings = append(findings, models.Finding{
-				ID:          "CFG-012",
-				Title:       "Broadcasting secret/key hardcoded in config",
+			findings = append(findings, s.finding("CFG-012", models.Finding{
 				Description: "A Pusher or broadcasting service key is hardcoded instead of using env().",
-				Severity:    models.SeverityMedium,
-				Category:    "Secrets",
-				Scanner:     s.Name(),
 				File:        "config/broadcasting.php",
 				Line:        n,
 				CodeSnippet: maskConfigValue(line),
 				Remediation: "Use: 'secret' => env('PUSHER_APP_SECRET'),",
-			})
+			}))
 		}
 	}
 
@@ -326,18 +320,13 @@ func (s *Scanner) checkLogging(path string) []models.Finding {
 	// Slack webhook URL in config
 	if line, n := findPattern(lines, `hooks\.slack\.com/services`); n > 0 {
 		if !strings.Contains(line, "env(") {
-			findings = append(findings, models.Finding{
-				ID:          "CFG-013",
-				Title:       "Slack webhook URL hardcoded in logging config",
+			findings = append(findings, s.finding("CFG-013", models.Finding{
 				Description: "A Slack webhook URL is hardcoded in config/logging.php. Webhook URLs are sensitive — anyone with the URL can post to your Slack channel.",
-				Severity:    models.SeverityMedium,
-				Category:    "Secrets",
-				Scanner:     s.Name(),
 				File:        "config/logging.php",
 				Line:        n,
 				CodeSnippet: maskConfigValue(line),
 				Remediation: "Use: 'url' => env('LOG_SLACK_WEBHOOK_URL'),",
-			})
+			}))
 		}
 	}
 
@@ -376,6 +365,14 @@ func findPattern(lines []string, pattern string) (string, int) {
 	return "", 0
 }
 
+// lineFix builds a fix for line n (1-based) replacing the first match of pattern.
+func lineFix(lines []string, n int, pattern, template, description string) *models.Fix {
+	if n <= 0 || n > len(lines) {
+		return nil
+	}
+	return models.LineFix(description, n, lines[n-1], regexp.MustCompile(pattern), template)
+}
+
 func maskConfigValue(line string) string {
 	// Replace quoted values longer than 4 chars with masked version
 	re := regexp.MustCompile(`=>\s*'([^']{4,})'`)
@@ -150,3 +150,33 @@ func TestConfigScanner_EmitCallback(t *testing.T) {
 		t.Error("Expected emit callback to be called")
 	}
 }
+
+func TestConfigScanner_FindingsMatchChecks(t *testing.T) {
+	dir := setupConfigProject(t, map[string]string{
+		"session.php": `<?php
+return [
+    'secure' => false,
+    'http_only' => false,
+    'same_site' => 'none',
+];`,
+	})
+	listed := make(map[string]models.Check)
+	for _, c := range New().Checks() {
+		listed[c.ID] = c
+	}
+
+	findings := scanConfig(t, dir)
+	if len(findings) == 0 {
+		t.Fatal("expected findings")
+	}
+	for _, f := range findings {
+		c, ok := listed[f.ID]
+		if !ok {
+			t.Errorf("%s is not listed by Checks()", f.ID)
+			continue
+		}
+		if f.Title != c.Title || f.Category != c.Category || len(f.Tags) != len(c.Tags) || f.Scanner != "config-scanner" {
+			t.Errorf("%s = %q/%s/%v, want %q/%s/%v", f.ID, f.Title, f.Category, f.Tags, c.Title, c.Category, c.Tags)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/eljakani/ward/internal/models"
@@ -18,22 +19,63 @@ func New() *Scanner       { return &Scanner{} }
 func (s *Scanner) Name() string        { return "env-scanner" }
 func (s *Scanner) Description() string { return "Environment file security checks" }
 
+// checks are the built-in .env checks, as Checks lists them. Findings
+// take their ID, severity, category and tags from here, and their title
+// unless they set a more specific one.
+var checks = []check{
+	{ID: "ENV-001", Title: "No .env file found", Severity: models.SeverityInfo, Category: "Configuration"},
+	{ID: "ENV-002", Title: "APP_DEBUG is enabled", Severity: models.SeverityHigh, Category: "Configuration", Tags: []string{"cwe-215"}},
+	{ID: "ENV-003", Title: "APP_KEY is empty or undefined", Severity: models.SeverityCritical, Category: "Cryptography", Tags: []string{"cwe-326"}},
+	{ID: "ENV-004", Title: "APP_KEY appears to be a default or weak key", Severity: models.SeverityCritical, Category: "Cryptography", Tags: []string{"cwe-321"}},
+	{ID: "ENV-005", Title: "APP_ENV is not production", Severity: models.SeverityMedium, Category: "Configuration", Tags: []string{"owasp-a05"}},
+	{ID: "ENV-006", Title: "Database password is empty", Severity: models.SeverityLow, Category: "Configuration", Tags: []string{"cwe-258", "owasp-a07"}},
+	{ID: "ENV-007", Title: "File-based sessions in production", Severity: models.SeverityLow, Category: "Configuration", Tags: []string{"owasp-a05"}},
+	{ID: "ENV-008", Title: "Potential real credential in .env.example", Severity: models.SeverityMedium, Category: "Secrets", Tags: []string{"cwe-798"}},
+}
+
+type check struct {
+	ID       string
+	Title    string
+	Severity models.Severity
+	Category string
+	Tags     []string
+}
+
+// Checks lists the built-in .env checks.
+func (s *Scanner) Checks() []models.Check {
+	out := make([]models.Check, len(checks))
+	for i, c := range checks {
+		out[i] = models.Check{ID: c.ID, Title: c.Title, Category: c.Category, Tags: c.Tags}
+	}
+	return out
+}
+
+// finding completes f from the check with the given ID.
+func (s *Scanner) finding(id string, f models.Finding) models.Finding {
+	for _, c := range checks {
+		if c.ID == id {
+			f.ID, f.Severity, f.Category, f.Tags = c.ID, c.Severity, c.Category, c.Tags
+			if f.Title == "" {
+				f.Title = c.Title
+			}
+			break
+		}
+	}
+	f.Scanner = s.Name()
+	return f
+}
+
 func (s *Scanner) Scan(_ context.Context, project models.ProjectContext, emit func(models.Finding)) ([]models.Finding, error) {
 	var findings []models.Finding
 
 	envPath := filepath.Join(project.RootPath, ".env")
 	envVars, err := readEnvFile(envPath)
 	if err != nil {
-		f := models.Finding{
-			ID:          "ENV-001",
-			Title:       "No .env file found",
+		f := s.finding("ENV-001", models.Finding{
 			Description: "The project has no .env file. While this may be intentional in containerized deployments, ensure environment configuration is provided through another mechanism.",
-			Severity:    models.SeverityInfo,
-			Category:    "Configuration",
-			Scanner:     s.Name(),
 			File:        ".env",
 			Remediation: "Copy .env.example to .env and configure your environment variables.",
-		}
+		})
 		findings = append(findings, f)
 		emit(f)
 		return findings, nil
@@ -41,19 +83,15 @@ func (s *Scanner) Scan(_ context.Context, project models.ProjectContext, emit fu
 
 	// APP_DEBUG=true
 	if val, ok := envVars["APP_DEBUG"]; ok && strings.EqualFold(val, "true") {
-		f := models.Finding{
-			ID:          "ENV-002",
-			Title:       "APP_DEBUG is enabled",
+		f := s.finding("ENV-002", models.Finding{
 			Description: "APP_DEBUG is set to true. In production, this exposes detailed error messages including stack traces, database queries, and environment variables to end users.",
-			Severity:    models.SeverityHigh,
-			Category:    "Configuration",
-			Scanner:     s.Name(),
 			File:        ".env",
 			Line:        findLine(envPath, "APP_DEBUG"),
 			CodeSnippet: fmt.Sprintf("APP_DEBUG=%s", val),
 			Remediation: "Set APP_DEBUG=false in your production .env file. Use Laravel's logging system for error tracking instead.",
 			References:  []string{"https://owasp.org/Top10/A05_2021-Security_Misconfiguration/"},
-		}
+			Fix:         envFix(envPath, "APP_DEBUG", "false", "Disable APP_DEBUG"),
+		})
 		findings = append(findings, f)
 		emit(f)
 	}
@@ -61,50 +99,37 @@ func (s *Scanner) Scan(_ context.Context, project models.ProjectContext, emit fu
 	// APP_KEY empty or default
 	if val, ok := envVars["APP_KEY"]; ok {
 		if val == "" {
-			f := models.Finding{
-				ID:          "ENV-003",
+			f := s.finding("ENV-003", models.Finding{
 				Title:       "APP_KEY is empty",
 				Description: "The application encryption key is not set. Laravel uses this key to encrypt cookies, sessions, and other sensitive data. Without it, encrypted data is insecure.",
-				Severity:    models.SeverityCritical,
-				Category:    "Cryptography",
-				Scanner:     s.Name(),
 				File:        ".env",
 				Line:        findLine(envPath, "APP_KEY"),
 				CodeSnippet: "APP_KEY=",
 				Remediation: "Generate a new application key: php artisan key:generate",
 				References:  []string{"https://cwe.mitre.org/data/definitions/321.html"},
-			}
+			})
 			findings = append(findings, f)
 			emit(f)
 		} else if isWeakKey(val) {
-			f := models.Finding{
-				ID:          "ENV-004",
-				Title:       "APP_KEY appears to be a default or weak key",
+			f := s.finding("ENV-004", models.Finding{
 				Description: "The application key looks like a default or placeholder value. This makes all encrypted data (sessions, cookies, passwords) predictable and breakable.",
-				Severity:    models.SeverityCritical,
-				Category:    "Cryptography",
-				Scanner:     s.Name(),
 				File:        ".env",
 				Line:        findLine(envPath, "APP_KEY"),
 				CodeSnippet: fmt.Sprintf("APP_KEY=%s", val),
 				Remediation: "Generate a new application key: php artisan key:generate",
 				References:  []string{"https://cwe.mitre.org/data/definitions/321.html"},
-			}
+			})
 			findings = append(findings, f)
 			emit(f)
 		}
 	} else {
-		f := models.Finding{
-			ID:          "ENV-003",
+		f := s.finding("ENV-003", models.Finding{
 			Title:       "APP_KEY is not defined",
 			Description: "No APP_KEY variable found in .env. Laravel requires this key for all encryption operations.",
-			Severity:    models.SeverityCritical,
-			Category:    "Cryptography",
-			Scanner:     s.Name(),
 			File:        ".env",
 			Remediation: "Add APP_KEY to .env and generate a key: php artisan key:generate",
 			References:  []string{"https://cwe.mitre.org/data/definitions/321.html"},
-		}
+		})
 		findings = append(findings, f)
 		emit(f)
 	}
@@ -113,18 +138,14 @@ func (s *Scanner) Scan(_ context.Context, project models.ProjectContext, emit fu
 	if val, ok := envVars["APP_ENV"]; ok {
 		lower := strings.ToLower(val)
 		if lower == "local" || lower == "development" || lower == "dev" {
-			f := models.Finding{
-				ID:          "ENV-005",
+			f := s.finding("ENV-005", models.Finding{
 				Title:       fmt.Sprintf("APP_ENV is set to '%s'", val),
 				Description: "The application environment suggests a non-production configuration. If this is a production server, this may cause debug features to be enabled and performance optimizations to be skipped.",
-				Severity:    models.SeverityMedium,
-				Category:    "Configuration",
-				Scanner:     s.Name(),
 				File:        ".env",
 				Line:        findLine(envPath, "APP_ENV"),
 				CodeSnippet: fmt.Sprintf("APP_ENV=%s", val),
 				Remediation: "Set APP_ENV=production on production servers.",
-			}
+			})
 			findings = append(findings, f)
 			emit(f)
 		}
@@ -132,18 +153,13 @@ func (s *Scanner) Scan(_ context.Context, project models.ProjectContext, emit fu
 
 	// Empty DB_PASSWORD
 	if val, ok := envVars["DB_PASSWORD"]; ok && val == "" {
-		f := models.Finding{
-			ID:          "ENV-006",
-			Title:       "Database password is empty",
+		f := s.finding("ENV-006", models.Finding{
 			Description: "DB_PASSWORD is set to an empty string. While this may be valid for local development with trust authentication, it's a security risk if this configuration reaches production.",
-			Severity:    models.SeverityLow,
-			Category:    "Configuration",
-			Scanner:     s.Name(),
 			File:        ".env",
 			Line:        findLine(envPath, "DB_PASSWORD"),
 			CodeSnippet: "DB_PASSWORD=",
 			Remediation: "Set a strong database password for non-local environments.",
-		}
+		})
 		findings = append(findings, f)
 		emit(f)
 	}
@@ -151,18 +167,13 @@ func (s *Scanner) Scan(_ context.Context, project models.ProjectContext, emit fu
 	// SESSION_DRIVER=file in production-looking env
 	if val, ok := envVars["SESSION_DRIVER"]; ok && val == "file" {
 		if env, ok := envVars["APP_ENV"]; ok && strings.EqualFold(env, "production") {
-			f := models.Finding{
-				ID:          "ENV-007",
-				Title:       "File-based sessions in production",
+			f := s.finding("ENV-007", models.Finding{
 				Description: "SESSION_DRIVER is set to 'file' in what appears to be a production environment. File sessions don't scale across multiple servers and are slower than alternatives.",
-				Severity:    models.SeverityLow,
-				Category:    "Configuration",
-				Scanner:     s.Name(),
 				File:        ".env",
 				Line:        findLine(envPath, "SESSION_DRIVER"),
 				CodeSnippet: fmt.Sprintf("SESSION_DRIVER=%s", val),
 				Remediation: "Use redis, memcached, or database session drivers for production: SESSION_DRIVER=redis",
-			}
+			})
 			findings = append(findings, f)
 			emit(f)
 		}
@@ -200,19 +211,15 @@ func (s *Scanner) checkEnvExample(root string) []models.Finding {
 		}
 		// If it's longer than 6 chars and not a placeholder, flag it
 		if len(val) > 6 {
-			f := models.Finding{
-				ID:          "ENV-008",
+			f := s.finding("ENV-008", models.Finding{
 				Title:       fmt.Sprintf("Potential real credential in .env.example: %s", key),
 				Description: fmt.Sprintf("The .env.example file contains a value for %s that doesn't look like a placeholder. This file is typically committed to version control and should only contain example/placeholder values.", key),
-				Severity:    models.SeverityMedium,
-				Category:    "Secrets",
-				Scanner:     s.Name(),
 				File:        ".env.example",
 				Line:        findLine(exPath, key),
 				CodeSnippet: fmt.Sprintf("%s=%s", key, maskValue(val)),
 				Remediation: fmt.Sprintf("Replace the value of %s in .env.example with a placeholder like 'your_%s_here'.", key, strings.ToLower(key)),
 				References:  []string{"https://cwe.mitre.org/data/definitions/798.html"},
-			}
+			})
 			findings = append(findings, f)
 		}
 	}
@@ -265,6 +272,35 @@ func findLine(path, key string) int {
 	return 0
 }
 
+// envFix builds a fix that sets key to value on its line in the env file.
+func envFix(path, key, value, description string) *models.Fix {
+	n := findLine(path, key)
+	if n == 0 {
+		return nil
+	}
+	lines, err := readLines(path)
+	if err != nil || n > len(lines) {
+		return nil
+	}
+	re := regexp.MustCompile(`^(\s*` + regexp.QuoteMeta(key) + `\s*=\s*).*$`)
+	return models.LineFix(description, n, lines[n-1], re, "${1}"+value)
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
 func isWeakKey(val string) bool {
 	lower := strings.ToLower(val)
 	// All zeros/A's base64 key
@@ -139,3 +139,31 @@ func TestEnvScanner_EmitCallback(t *testing.T) {
 		t.Error("expected emit callback to be called")
 	}
 }
+
+func TestEnvScanner_FindingsMatchChecks(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, ".env"), []byte("APP_ENV=local\nAPP_DEBUG=true\nAPP_KEY=\nDB_PASSWORD=\n"), 0644)
+
+	listed := make(map[string]models.Check)
+	for _, c := range New().Checks() {
+		listed[c.ID] = c
+	}
+
+	findings, err := New().Scan(context.Background(), models.ProjectContext{RootPath: dir}, func(f models.Finding) {})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) < 4 {
+		t.Fatalf("expected 4 findings, got %d", len(findings))
+	}
+	for _, f := range findings {
+		c, ok := listed[f.ID]
+		if !ok {
+			t.Errorf("%s is not listed by Checks()", f.ID)
+			continue
+		}
+		if f.Category != c.Category || len(f.Tags) != len(c.Tags) || f.Scanner != "env-scanner" {
+			t.Errorf("%s = %s/%v, want %s/%v", f.ID, f.Category, f.Tags, c.Category, c.Tags)
+		}
+	}
+}
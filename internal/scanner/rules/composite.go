@@ -0,0 +1,418 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+)
+
+// hit is a single line matched while evaluating a composite expression.
+type hit struct {
+	file    string // relative to the project root
+	line    int
+	text    string
+	related []models.Location // other lines that made the expression match
+}
+
+// maxRelated caps the related locations recorded for a hit, so a broad
+// all: part doesn't attach every line it matched.
+const maxRelated = 5
+
+// relate returns hits with loc added to the related locations of each.
+func relate(hits []hit, loc func(h hit) (models.Location, bool)) []hit {
+	out := make([]hit, len(hits))
+	for i, h := range hits {
+		out[i] = h
+		if len(h.related) >= maxRelated {
+			continue
+		}
+		if l, ok := loc(h); ok {
+			out[i].related = append(h.related[:len(h.related):len(h.related)], l)
+		}
+	}
+	return out
+}
+
+// location converts a hit into a related location.
+func (h hit) location(note string) models.Location {
+	return models.Location{File: h.file, Line: h.line, Note: note, Snippet: truncate(h.text, 200)}
+}
+
+// evalResult is the outcome of evaluating one MatchExpr node. Hits are the
+// matches that justify the result and are used to anchor findings.
+type evalResult struct {
+	ok   bool
+	hits []hit
+}
+
+// evaluator evaluates MatchExpr trees for a single rule, caching file
+// contents, target resolution and compiled regexes across nodes.
+type evaluator struct {
+	root    string
+	lines   map[string][]string
+	targets map[string]map[string]bool
+	order   map[string][]string
+	regexes map[string]*regexp.Regexp
+}
+
+func newEvaluator(root string) *evaluator {
+	return &evaluator{
+		root:    root,
+		lines:   make(map[string][]string),
+		targets: make(map[string]map[string]bool),
+		order:   make(map[string][]string),
+		regexes: make(map[string]*regexp.Regexp),
+	}
+}
+
+// evaluateMatch runs the rule's composite expression. In file scope (the
+// default) the expression is evaluated once per candidate file; in project
+// scope once across the whole project.
+func (s *Scanner) evaluateMatch(rule config.RuleDefinition, root string) []models.Finding {
+	e := newEvaluator(root)
+
+	var hits []hit
+	if rule.Match.Scope == "project" {
+		res := e.eval(rule.Match, "")
+		if !res.ok {
+			return nil
+		}
+		hits = res.hits
+		if len(hits) == 0 {
+			hits = []hit{{}}
+		}
+	} else {
+		for _, file := range e.candidates(rule.Match) {
+			res := e.eval(rule.Match, file)
+			if !res.ok {
+				continue
+			}
+			if len(res.hits) == 0 {
+				hits = append(hits, hit{file: file})
+				continue
+			}
+			hits = append(hits, res.hits...)
+		}
+	}
+
+	var findings []models.Finding
+	seen := make(map[string]bool)
+	for _, h := range hits {
+		key := fmt.Sprintf("%s:%d", h.file, h.line)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		f := s.buildFinding(rule, h.file, h.line, h.text)
+		f.Related = h.related
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// eval evaluates a node. file is the file being evaluated in file scope,
+// or "" in project scope.
+func (e *evaluator) eval(x *config.MatchExpr, file string) evalResult {
+	var res evalResult
+
+	switch {
+	case x.Type != "":
+		res = e.evalLeaf(x.PatternDef, file)
+	case len(x.All) > 0:
+		res.ok = true
+		var others []hit
+		for i := range x.All {
+			r := e.eval(&x.All[i], file)
+			if !r.ok {
+				return evalResult{}
+			}
+			// Anchor on the first child that matched something; by
+			// convention that is the primary pattern of the rule. The
+			// other children's hits become its related locations.
+			if len(res.hits) == 0 {
+				res.hits = r.hits
+			} else {
+				others = append(others, r.hits...)
+			}
+		}
+		for _, o := range others {
+			if o.line == 0 {
+				continue
+			}
+			res.hits = relate(res.hits, func(h hit) (models.Location, bool) {
+				return o.location("also matched"), o.file != h.file || o.line != h.line
+			})
+		}
+	case len(x.Any) > 0:
+		for i := range x.Any {
+			if r := e.eval(&x.Any[i], file); r.ok {
+				res.ok = true
+				res.hits = append(res.hits, r.hits...)
+			}
+		}
+	case x.Not != nil:
+		res.ok = !e.eval(x.Not, file).ok
+	}
+
+	if x.Inside != nil && res.ok {
+		res = e.narrow(res, x.Inside)
+	}
+
+	return res
+}
+
+// evalLeaf evaluates a single pattern. In file scope a leaf without a
+// target applies to the current file.
+func (e *evaluator) evalLeaf(pat config.PatternDef, file string) evalResult {
+	var res evalResult
+
+	if pat.Type == "file-exists" {
+		matches, _ := filepath.Glob(filepath.Join(e.root, pat.Pattern))
+		res.ok = len(matches) > 0
+		if file == "" && !pat.Negative {
+			for _, m := range matches {
+				rel, _ := filepath.Rel(e.root, m)
+				res.hits = append(res.hits, hit{file: rel})
+			}
+		}
+	} else {
+		var files []string
+		switch {
+		case file == "":
+			files = e.targetFiles(pat.Target)
+		case pat.Target == "" || e.inTarget(pat.Target, file):
+			files = []string{file}
+		}
+
+		for _, f := range files {
+			res.hits = append(res.hits, e.matchLines(f, pat)...)
+		}
+		res.ok = len(res.hits) > 0
+	}
+
+	if pat.Negative {
+		return evalResult{ok: !res.ok}
+	}
+	return res
+}
+
+// narrow keeps only the hits that fall inside a region matched by inside:
+// N lines around each of its matches when Within is set, otherwise the
+// brace scope opened on each matching line.
+// Each kept hit records the inside: match whose region it fell in.
+func (e *evaluator) narrow(res evalResult, inside *config.MatchExpr) evalResult {
+	regions := make(map[string]map[int]hit)
+
+	var kept []hit
+	for _, h := range res.hits {
+		region, ok := regions[h.file]
+		if !ok {
+			region = e.region(inside, h.file)
+			regions[h.file] = region
+		}
+		if opener, ok := region[h.line]; ok {
+			kept = append(kept, relate([]hit{h}, func(h hit) (models.Location, bool) {
+				return opener.location("inside"), opener.line != h.line
+			})...)
+		}
+	}
+
+	return evalResult{ok: len(kept) > 0, hits: kept}
+}
+
+// region maps the lines of file inside a region matched by inside to the
+// match that opened the region.
+func (e *evaluator) region(inside *config.MatchExpr, file string) map[int]hit {
+	region := make(map[int]hit)
+	if file == "" {
+		return region
+	}
+
+	r := e.eval(inside, file)
+	if !r.ok {
+		return region
+	}
+
+	if inside.Within > 0 {
+		for _, h := range r.hits {
+			for l := h.line - inside.Within; l <= h.line+inside.Within; l++ {
+				if _, ok := region[l]; !ok {
+					region[l] = h
+				}
+			}
+		}
+		return region
+	}
+
+	openers := make(map[int]hit, len(r.hits))
+	for _, h := range r.hits {
+		if _, ok := openers[h.line]; !ok {
+			openers[h.line] = h
+		}
+	}
+	return scopeOpeners(e.fileLines(file), openers)
+}
+
+// scopeOpeners maps each line inside the brace scope of an opener line
+// to its innermost opener, in one pass. A scope is delimited as
+// scopeRanges does: from the opener line, through the line its braces
+// open on if that comes later, to the line before they close.
+func scopeOpeners(lines []string, openers map[int]hit) map[int]hit {
+	type scope struct {
+		opener  hit
+		base    int  // brace depth before the opener line
+		pending bool // its opening brace hasn't been seen yet
+	}
+
+	region := make(map[int]hit)
+	var open []scope
+	depth := 0
+	for i, line := range lines {
+		lineNum := i + 1
+		before := depth
+		depth += countBraces(line)
+
+		// Scopes still open after this line contain it. One waiting for
+		// its brace contains every line up to and including the brace.
+		kept := open[:0]
+		for _, s := range open {
+			switch {
+			case s.pending:
+				s.pending = depth-s.base <= 0
+				kept = append(kept, s)
+			case depth-s.base > 0:
+				kept = append(kept, s)
+			}
+		}
+		open = kept
+
+		if h, ok := openers[lineNum]; ok {
+			open = append(open, scope{opener: h, base: before, pending: depth-before <= 0})
+		}
+		if len(open) > 0 {
+			region[lineNum] = open[len(open)-1].opener
+		}
+	}
+	return region
+}
+
+// matchLines returns the lines of file matching a content pattern.
+func (e *evaluator) matchLines(file string, pat config.PatternDef) []hit {
+	lines := e.fileLines(file)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	var re *regexp.Regexp
+	if pat.Type != "contains" {
+		if re = e.regex(pat.Pattern); re == nil {
+			return nil
+		}
+	}
+
+	var excludeRe *regexp.Regexp
+	if pat.ExcludePattern != "" {
+		excludeRe = e.regex(pat.ExcludePattern)
+	}
+
+	var protected map[int]bool
+	if pat.Type == "regex-scoped" && pat.ScopeExclude != "" {
+		protected = buildProtectedRanges(lines, e.regex(pat.ScopeExclude))
+	}
+
+	var hits []hit
+	for i, line := range lines {
+		lineNum := i + 1
+		if protected[lineNum] {
+			continue
+		}
+
+		var matched bool
+		if re != nil {
+			matched = re.MatchString(line)
+		} else {
+			matched = strings.Contains(line, pat.Pattern)
+		}
+		if !matched || (excludeRe != nil && excludeRe.MatchString(line)) {
+			continue
+		}
+
+		hits = append(hits, hit{file: file, line: lineNum, text: strings.TrimSpace(line)})
+	}
+	return hits
+}
+
+// candidates returns the files a file-scoped expression is evaluated
+// against: the union of its leaves' targets, in discovery order.
+func (e *evaluator) candidates(x *config.MatchExpr) []string {
+	var files []string
+	seen := make(map[string]bool)
+
+	var walk func(x *config.MatchExpr)
+	walk = func(x *config.MatchExpr) {
+		if x == nil {
+			return
+		}
+		if x.Type != "" && x.Type != "file-exists" && x.Target != "" {
+			for _, f := range e.targetFiles(x.Target) {
+				if !seen[f] {
+					seen[f] = true
+					files = append(files, f)
+				}
+			}
+		}
+		for i := range x.All {
+			walk(&x.All[i])
+		}
+		for i := range x.Any {
+			walk(&x.Any[i])
+		}
+		walk(x.Not)
+	}
+	walk(x)
+
+	return files
+}
+
+func (e *evaluator) targetFiles(target string) []string {
+	if _, ok := e.targets[target]; !ok {
+		set := make(map[string]bool)
+		var rels []string
+		for _, f := range resolveTarget(target, e.root) {
+			rel, _ := filepath.Rel(e.root, f)
+			set[rel] = true
+			rels = append(rels, rel)
+		}
+		e.targets[target] = set
+		e.order[target] = rels
+	}
+	return e.order[target]
+}
+
+func (e *evaluator) inTarget(target, file string) bool {
+	e.targetFiles(target)
+	return e.targets[target][file]
+}
+
+func (e *evaluator) fileLines(file string) []string {
+	lines, ok := e.lines[file]
+	if !ok {
+		lines, _ = readLines(filepath.Join(e.root, file))
+		e.lines[file] = lines
+	}
+	return lines
+}
+
+// regex compiles and caches a pattern; invalid patterns yield nil.
+func (e *evaluator) regex(pattern string) *regexp.Regexp {
+	re, ok := e.regexes[pattern]
+	if !ok {
+		re, _ = regexp.Compile(pattern)
+		e.regexes[pattern] = re
+	}
+	return re
+}
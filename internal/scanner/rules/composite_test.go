@@ -0,0 +1,238 @@
+package rules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+func compositeRule(t *testing.T, src string) config.RuleDefinition {
+	t.Helper()
+	var rf config.RuleFile
+	if err := yaml.Unmarshal([]byte(src), &rf); err != nil {
+		t.Fatalf("parsing rule: %v", err)
+	}
+	return rf.Rules[0]
+}
+
+func scanRule(rule config.RuleDefinition, dir string) []models.Finding {
+	findings, _ := New([]config.RuleDefinition{rule}).Scan(context.Background(), models.ProjectContext{RootPath: dir}, func(models.Finding) {})
+	return findings
+}
+
+func TestComposite_AllNot(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "routes"), 0755)
+	os.WriteFile(filepath.Join(dir, "routes", "web.php"), []byte(`<?php
+Route::get('/', HomeController::class);
+Route::post('/login', LoginController::class);
+`), 0644)
+	os.WriteFile(filepath.Join(dir, "routes", "api.php"), []byte(`<?php
+Route::middleware('throttle:api')->group(function () {
+    Route::post('/token', TokenController::class);
+});
+`), 0644)
+
+	rule := compositeRule(t, `rules:
+  - id: COMP-001
+    title: "POST routes without throttling"
+    severity: medium
+    enabled: true
+    match:
+      all:
+        - type: contains
+          target: routes-files
+          pattern: "Route::post"
+        - not:
+            type: contains
+            pattern: "throttle"
+`)
+
+	findings := scanRule(rule, dir)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if findings[0].File != filepath.Join("routes", "web.php") || findings[0].Line != 3 {
+		t.Errorf("finding anchored at %s:%d, want routes/web.php:3", findings[0].File, findings[0].Line)
+	}
+}
+
+func TestComposite_ProjectScope(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "app", "Models"), 0755)
+	os.MkdirAll(filepath.Join(dir, "app", "Http", "Controllers"), 0755)
+	os.WriteFile(filepath.Join(dir, "app", "Models", "User.php"), []byte(`<?php
+class User extends Model {
+    protected $guarded = [];
+}
+`), 0644)
+	controller := filepath.Join(dir, "app", "Http", "Controllers", "UserController.php")
+	os.WriteFile(controller, []byte(`<?php
+User::create($request->validated());
+`), 0644)
+
+	rule := compositeRule(t, `rules:
+  - id: COMP-002
+    title: "Unguarded model mass-assigned from request"
+    severity: high
+    enabled: true
+    match:
+      scope: project
+      all:
+        - type: regex
+          target: app/Models/*.php
+          pattern: '\$guarded\s*=\s*\[\s*\]'
+        - type: regex
+          target: php-files
+          pattern: '::create\(\$request->all\(\)\)'
+`)
+
+	if findings := scanRule(rule, dir); len(findings) != 0 {
+		t.Fatalf("expected no findings without the controller call, got %d", len(findings))
+	}
+
+	os.WriteFile(controller, []byte(`<?php
+User::create($request->all());
+`), 0644)
+
+	findings := scanRule(rule, dir)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if findings[0].File != filepath.Join("app", "Models", "User.php") || findings[0].Line != 3 {
+		t.Errorf("finding anchored at %s:%d, want the model's $guarded line", findings[0].File, findings[0].Line)
+	}
+	related := findings[0].Related
+	if len(related) != 1 || related[0].File != filepath.Join("app", "Http", "Controllers", "UserController.php") || related[0].Line != 2 {
+		t.Errorf("related = %+v, want the controller's create call", related)
+	}
+}
+
+func TestComposite_InsideWithin(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "app"), 0755)
+	os.WriteFile(filepath.Join(dir, "app", "Report.php"), []byte(`<?php
+$term = $request->input('q');
+$rows = DB::select("SELECT * FROM reports WHERE name = '$term'");
+$other = DB::select('SELECT 1');
+`), 0644)
+
+	rule := compositeRule(t, `rules:
+  - id: COMP-003
+    title: "Raw query near request input"
+    severity: high
+    enabled: true
+    match:
+      type: regex
+      target: php-files
+      pattern: 'DB::select\('
+      inside:
+        type: contains
+        pattern: "$request->"
+        within: 1
+`)
+
+	findings := scanRule(rule, dir)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if findings[0].Line != 3 {
+		t.Errorf("finding line = %d, want 3", findings[0].Line)
+	}
+	if related := findings[0].Related; len(related) != 1 || related[0].Line != 2 || related[0].Note != "inside" {
+		t.Errorf("related = %+v, want the request input on line 2", related)
+	}
+}
+
+func TestComposite_InsideScope(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "app"), 0755)
+	os.WriteFile(filepath.Join(dir, "app", "Job.php"), []byte(`<?php
+public function handle()
+{
+    eval($code);
+}
+
+public function helper() {
+    foreach ($items as $item) {
+        eval($item);
+    }
+}
+`), 0644)
+
+	rule := compositeRule(t, `rules:
+  - id: COMP-004
+    title: "eval inside a loop"
+    severity: high
+    enabled: true
+    match:
+      any:
+        - type: contains
+          target: php-files
+          pattern: "eval("
+      inside:
+        type: regex
+        pattern: '\bforeach\s*\('
+`)
+
+	findings := scanRule(rule, dir)
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	if findings[0].Line != 9 {
+		t.Errorf("finding line = %d, want 9", findings[0].Line)
+	}
+	if related := findings[0].Related; len(related) != 1 || related[0].Line != 8 {
+		t.Errorf("related = %+v, want the foreach on line 8", related)
+	}
+}
+
+func TestScopeOpeners_InnermostInOnePass(t *testing.T) {
+	lines := []string{
+		"class A {",              // 1 opener
+		"    function f()",       // 2 opener, brace on the next line
+		"    {",                  // 3
+		"        foreach ($x) {", // 4 opener
+		"            eval($y);",  // 5
+		"        }",              // 6
+		"        return 1;",      // 7
+		"    }",                  // 8
+		"    function g() { }",   // 9 opener closed on its own line
+		"    } else {",           // 10 opener with net zero braces
+		"        echo '}';",      // 11
+		"    }",                  // 12
+		"}",                      // 13
+	}
+	openers := make(map[int]hit)
+	for _, n := range []int{1, 2, 4, 9, 10} {
+		openers[n] = hit{line: n}
+	}
+
+	got := scopeOpeners(lines, openers)
+
+	// Each opener's scope on its own, innermost opener winning.
+	want := make(map[int]int)
+	for _, n := range []int{1, 2, 4, 9, 10} {
+		for l := range scopeRanges(lines, func(_ string, lineNum int) bool { return lineNum == n }) {
+			if want[l] < n {
+				want[l] = n
+			}
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d lines in scopes, want %d", len(got), len(want))
+	}
+	for l, n := range want {
+		if got[l].line != n {
+			t.Errorf("line %d: opener %d, want %d", l, got[l].line, n)
+		}
+	}
+	if got[5].line != 4 || got[7].line != 2 || got[11].line != 10 {
+		t.Errorf("innermost openers = %d, %d, %d, want 4, 2, 10", got[5].line, got[7].line, got[11].line)
+	}
+}
@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+)
+
+// SkippedRule records a rule whose `when:` conditions did not hold.
+type SkippedRule struct {
+	ID     string
+	Reason string
+}
+
+// checkCondition reports whether the rule's conditions hold for the project.
+// When they don't, the returned reason names the first failing check.
+func checkCondition(cond *config.RuleCondition, project models.ProjectContext, env map[string]string) (bool, string) {
+	if cond == nil {
+		return true, ""
+	}
+
+	if cond.Laravel != "" {
+		v := laravelVersion(project)
+		if v == "" {
+			return false, "Laravel version unknown"
+		}
+		if !versionSatisfies(v, cond.Laravel) {
+			return false, fmt.Sprintf("Laravel %s does not satisfy %q", v, cond.Laravel)
+		}
+	}
+
+	if cond.PHP != "" {
+		v := lowerBound(project.PHPVersion)
+		if v == "" {
+			return false, "PHP version unknown"
+		}
+		if !versionSatisfies(v, cond.PHP) {
+			return false, fmt.Sprintf("PHP %s does not satisfy %q", v, cond.PHP)
+		}
+	}
+
+	for _, pkg := range cond.Packages {
+		installed, ok := packageVersion(project, pkg.Name)
+		if pkg.Absent {
+			if ok {
+				return false, fmt.Sprintf("package %s is installed", pkg.Name)
+			}
+			continue
+		}
+		if !ok {
+			return false, fmt.Sprintf("package %s is not installed", pkg.Name)
+		}
+		if pkg.Version != "" && !versionSatisfies(installed, pkg.Version) {
+			return false, fmt.Sprintf("package %s %s does not satisfy %q", pkg.Name, installed, pkg.Version)
+		}
+	}
+
+	for key, want := range cond.Env {
+		got := env[key]
+		if !envMatches(got, want) {
+			return false, fmt.Sprintf("env %s=%q does not match %q", key, got, want)
+		}
+	}
+
+	for _, pattern := range cond.FileExists {
+		if matches, _ := filepath.Glob(filepath.Join(project.RootPath, pattern)); len(matches) == 0 {
+			return false, fmt.Sprintf("no file matches %s", pattern)
+		}
+	}
+
+	for _, pattern := range cond.FileMissing {
+		if matches, _ := filepath.Glob(filepath.Join(project.RootPath, pattern)); len(matches) > 0 {
+			return false, fmt.Sprintf("file %s exists", pattern)
+		}
+	}
+
+	if len(cond.Any) > 0 {
+		reasons := make([]string, 0, len(cond.Any))
+		for i := range cond.Any {
+			ok, reason := checkCondition(&cond.Any[i], project, env)
+			if ok {
+				return true, ""
+			}
+			reasons = append(reasons, reason)
+		}
+		return false, "none of any: " + strings.Join(reasons, "; ")
+	}
+
+	return true, ""
+}
+
+// laravelVersion prefers the resolved version from composer.lock and falls
+// back to the lower bound of the composer.json constraint.
+func laravelVersion(project models.ProjectContext) string {
+	if v, ok := project.InstalledPackages["laravel/framework"]; ok && v != "" {
+		return v
+	}
+	return lowerBound(project.LaravelVersion)
+}
+
+// packageVersion looks up a package in composer.lock, then composer.json.
+func packageVersion(project models.ProjectContext, name string) (string, bool) {
+	if v, ok := project.InstalledPackages[name]; ok {
+		return v, true
+	}
+	if v, ok := project.ComposerDeps[name]; ok {
+		return lowerBound(v), true
+	}
+	return "", false
+}
+
+// envMatches compares an .env value against an expectation:
+// "*" matches any non-empty value, a leading "!" negates, otherwise
+// the comparison is case-insensitive.
+func envMatches(got, want string) bool {
+	if want == "*" {
+		return got != ""
+	}
+	if strings.HasPrefix(want, "!") {
+		return !strings.EqualFold(got, want[1:])
+	}
+	return strings.EqualFold(got, want)
+}
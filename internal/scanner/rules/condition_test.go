@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+)
+
+func TestVersionSatisfies(t *testing.T) {
+	tests := []struct {
+		version    string
+		constraint string
+		want       bool
+	}{
+		{"v11.2.0", ">=11", true},
+		{"v10.48.3", ">=11", false},
+		{"10.48.3", ">=10 <11", true},
+		{"10.48.3", ">=10, <10.40", false},
+		{"11.0.0", "^11.0", true},
+		{"12.0.0", "^11.0", false},
+		{"0.3.1", "^0.3", true},
+		{"0.4.0", "^0.3", false},
+		{"1.2.9", "~1.2.3", true},
+		{"1.3.0", "~1.2.3", false},
+		{"1.9.0", "~1.2", true},
+		{"11.5.0", "11.*", true},
+		{"11.5.0", "10", false},
+		{"8.2.0", "8.1 || 8.2", true},
+		{"3.9.2", "!= 3.9.2", false},
+		{"dev-master", ">=1", false},
+	}
+
+	for _, tt := range tests {
+		if got := versionSatisfies(tt.version, tt.constraint); got != tt.want {
+			t.Errorf("versionSatisfies(%q, %q) = %v, want %v", tt.version, tt.constraint, got, tt.want)
+		}
+	}
+}
+
+func TestLowerBound(t *testing.T) {
+	tests := map[string]string{
+		"^11.0":        "11.0",
+		">=8.1 <9":     "8.1",
+		"<9, >=8.2":    "8.2",
+		"10.*":         "10",
+		"^8.1 || ^9.0": "8.1",
+		"":             "",
+	}
+
+	for in, want := range tests {
+		if got := lowerBound(in); got != want {
+			t.Errorf("lowerBound(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCheckCondition(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "artisan"), []byte(""), 0644)
+
+	project := models.ProjectContext{
+		RootPath:          dir,
+		LaravelVersion:    "^10.0",
+		PHPVersion:        "^8.1",
+		InstalledPackages: map[string]string{"laravel/framework": "v10.48.3", "barryvdh/laravel-debugbar": "v3.9.2"},
+	}
+	env := map[string]string{"APP_ENV": "production", "APP_DEBUG": "false"}
+
+	tests := []struct {
+		name string
+		cond config.RuleCondition
+		want bool
+	}{
+		{"laravel match", config.RuleCondition{Laravel: "^10.0"}, true},
+		{"laravel mismatch", config.RuleCondition{Laravel: ">=11"}, false},
+		{"php from constraint", config.RuleCondition{PHP: ">=8.1"}, true},
+		{"package present", config.RuleCondition{Packages: []config.PackageCondition{{Name: "barryvdh/laravel-debugbar"}}}, true},
+		{"package version", config.RuleCondition{Packages: []config.PackageCondition{{Name: "barryvdh/laravel-debugbar", Version: "<3.0"}}}, false},
+		{"package missing", config.RuleCondition{Packages: []config.PackageCondition{{Name: "laravel/telescope"}}}, false},
+		{"package absent", config.RuleCondition{Packages: []config.PackageCondition{{Name: "laravel/telescope", Absent: true}}}, true},
+		{"env value", config.RuleCondition{Env: map[string]string{"APP_ENV": "Production"}}, true},
+		{"env negated", config.RuleCondition{Env: map[string]string{"APP_DEBUG": "!false"}}, false},
+		{"env any", config.RuleCondition{Env: map[string]string{"APP_KEY": "*"}}, false},
+		{"file exists", config.RuleCondition{FileExists: []string{"artisan"}}, true},
+		{"file missing", config.RuleCondition{FileMissing: []string{"artisan"}}, false},
+		{"any one holds", config.RuleCondition{Any: []config.RuleCondition{
+			{Packages: []config.PackageCondition{{Name: "itsgoingd/clockwork"}}},
+			{Packages: []config.PackageCondition{{Name: "barryvdh/laravel-debugbar"}}},
+		}}, true},
+		{"any none holds", config.RuleCondition{Any: []config.RuleCondition{
+			{Packages: []config.PackageCondition{{Name: "itsgoingd/clockwork"}}},
+			{Laravel: ">=11"},
+		}}, false},
+	}
+
+	for _, tt := range tests {
+		got, reason := checkCondition(&tt.cond, project, env)
+		if got != tt.want {
+			t.Errorf("%s: checkCondition() = %v (%s), want %v", tt.name, got, reason, tt.want)
+		}
+		if !got && reason == "" {
+			t.Errorf("%s: failing condition should give a reason", tt.name)
+		}
+	}
+}
+
+func TestRulesScanner_WhenSkipsRule(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "config"), 0755)
+	os.WriteFile(filepath.Join(dir, "config", "debugbar.php"), []byte(`<?php
+return ['enabled' => true];
+`), 0644)
+
+	rules := []config.RuleDefinition{
+		{
+			ID:       "TEST-WHEN",
+			Title:    "Debugbar enabled",
+			Severity: "medium",
+			Enabled:  true,
+			When: &config.RuleCondition{
+				Packages: []config.PackageCondition{{Name: "barryvdh/laravel-debugbar"}},
+			},
+			Patterns: []config.PatternDef{
+				{Type: "contains", Target: "config-files", Pattern: "'enabled' => true"},
+			},
+		},
+	}
+
+	s := New(rules)
+	findings, _ := s.Scan(context.Background(), models.ProjectContext{RootPath: dir}, func(f models.Finding) {})
+	if len(findings) != 0 {
+		t.Errorf("expected rule to be skipped, got %d findings", len(findings))
+	}
+	if len(s.Skipped()) != 1 || s.Skipped()[0].ID != "TEST-WHEN" {
+		t.Errorf("Skipped() = %+v, want TEST-WHEN", s.Skipped())
+	}
+
+	pc := models.ProjectContext{
+		RootPath:          dir,
+		InstalledPackages: map[string]string{"barryvdh/laravel-debugbar": "v3.9.2"},
+	}
+	findings, _ = s.Scan(context.Background(), pc, func(f models.Finding) {})
+	if len(findings) != 1 {
+		t.Errorf("expected 1 finding with package installed, got %d", len(findings))
+	}
+	if len(s.Skipped()) != 0 {
+		t.Errorf("Skipped() should be reset between scans, got %+v", s.Skipped())
+	}
+}
+
+func TestDebug005_ClockworkOnly(t *testing.T) {
+	defs, err := config.LoadRulesFromFile(filepath.Join("..", "..", "config", "defaults", "rules", "debug.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rules []config.RuleDefinition
+	for _, r := range defs {
+		if r.ID == "DEBUG-005" {
+			rules = append(rules, r)
+		}
+	}
+	if len(rules) != 1 {
+		t.Fatalf("DEBUG-005 not found in debug.yaml")
+	}
+
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "config"), 0755)
+	os.WriteFile(filepath.Join(dir, "config", "clockwork.php"), []byte(`<?php
+return ['enabled' => env('CLOCKWORK_ENABLED', true)];
+`), 0644)
+
+	pc := models.ProjectContext{
+		RootPath:          dir,
+		ConfigFiles:       []string{filepath.Join("config", "clockwork.php")},
+		InstalledPackages: map[string]string{"itsgoingd/clockwork": "v5.2.0"},
+	}
+	findings, _ := New(rules).Scan(context.Background(), pc, func(f models.Finding) {})
+	if len(findings) != 1 {
+		t.Errorf("expected DEBUG-005 to flag Clockwork without Debugbar, got %d findings", len(findings))
+	}
+}
@@ -11,11 +11,13 @@ import (
 
 	"github.com/eljakani/ward/internal/config"
 	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/resolver"
 )
 
 // Scanner executes YAML-defined custom rules against the project.
 type Scanner struct {
-	rules []config.RuleDefinition
+	rules   []config.RuleDefinition
+	skipped []SkippedRule
 }
 
 // New creates a rules scanner with the given rule definitions.
@@ -28,12 +30,29 @@ func (s *Scanner) Description() string { return "Custom YAML rule checks" }
 
 func (s *Scanner) Scan(_ context.Context, project models.ProjectContext, emit func(models.Finding)) ([]models.Finding, error) {
 	var findings []models.Finding
+	s.skipped = nil
 
+	var env map[string]string
 	for _, rule := range s.rules {
 		if !rule.Enabled {
 			continue
 		}
 
+		if rule.When != nil {
+			if env == nil {
+				// A missing file leaves an empty map, so conditions on
+				// unset variables still evaluate.
+				env = resolver.ParseEnvFile(filepath.Join(project.RootPath, ".env"))
+				if env == nil {
+					env = map[string]string{}
+				}
+			}
+			if ok, reason := checkCondition(rule.When, project, env); !ok {
+				s.skipped = append(s.skipped, SkippedRule{ID: rule.ID, Reason: reason})
+				continue
+			}
+		}
+
 		rf := s.evaluateRule(rule, project.RootPath)
 		for _, f := range rf {
 			findings = append(findings, f)
@@ -44,6 +63,26 @@ func (s *Scanner) Scan(_ context.Context, project models.ProjectContext, emit fu
 	return findings, nil
 }
 
+// Skipped returns the rules whose `when:` conditions failed during the last Scan.
+func (s *Scanner) Skipped() []SkippedRule {
+	return s.skipped
+}
+
+// Checks lists the enabled rules that ran during the last Scan.
+func (s *Scanner) Checks() []models.Check {
+	skipped := make(map[string]bool, len(s.skipped))
+	for _, sk := range s.skipped {
+		skipped[sk.ID] = true
+	}
+	var checks []models.Check
+	for _, rule := range s.rules {
+		if rule.Enabled && !skipped[rule.ID] {
+			checks = append(checks, models.Check{ID: rule.ID, Title: rule.Title, Category: rule.Category, Tags: rule.Tags})
+		}
+	}
+	return checks
+}
+
 func (s *Scanner) evaluateRule(rule config.RuleDefinition, root string) []models.Finding {
 	var findings []models.Finding
 
@@ -52,6 +91,14 @@ func (s *Scanner) evaluateRule(rule config.RuleDefinition, root string) []models
 		findings = append(findings, pf...)
 	}
 
+	if rule.Match != nil {
+		findings = append(findings, s.evaluateMatch(rule, root)...)
+	}
+
+	if rule.Fix != nil {
+		attachFixes(rule.Fix, findings, root)
+	}
+
 	return findings
 }
 
@@ -365,10 +412,16 @@ func (s *Scanner) checkScopedFileContent(rule config.RuleDefinition, pat config.
 // a brace-depth scope opened by a line matching scopeRe. Lines where braces
 // appear inside single- or double-quoted strings are ignored to avoid skew.
 func buildProtectedRanges(lines []string, scopeRe *regexp.Regexp) map[int]bool {
-	protected := make(map[int]bool)
 	if scopeRe == nil {
-		return protected
+		return make(map[int]bool)
 	}
+	return scopeRanges(lines, func(line string, _ int) bool { return scopeRe.MatchString(line) })
+}
+
+// scopeRanges returns the line numbers (1-based) covered by brace scopes
+// opened on lines for which isOpener returns true.
+func scopeRanges(lines []string, isOpener func(line string, lineNum int) bool) map[int]bool {
+	protected := make(map[int]bool)
 
 	n := len(lines)
 	i := 0
@@ -376,7 +429,7 @@ func buildProtectedRanges(lines []string, scopeRe *regexp.Regexp) map[int]bool {
 		line := lines[i]
 		lineNum := i + 1
 
-		if scopeRe.MatchString(line) {
+		if isOpener(line, lineNum) {
 			// Count net braces on the triggering line itself.
 			depth := countBraces(line)
 
@@ -465,6 +518,36 @@ func readLines(path string) ([]string, error) {
 	return lines, sc.Err()
 }
 
+// attachFixes sets a Fix on every finding whose line matches the rule's fix.
+func attachFixes(def *config.FixDef, findings []models.Finding, root string) {
+	re, err := regexp.Compile(def.Find)
+	if err != nil {
+		return
+	}
+
+	description := def.Description
+	if description == "" {
+		description = "Replace with " + def.Replace
+	}
+
+	files := make(map[string][]string)
+	for i := range findings {
+		f := &findings[i]
+		if f.Line <= 0 {
+			continue
+		}
+		lines, ok := files[f.File]
+		if !ok {
+			lines, _ = readLines(filepath.Join(root, f.File))
+			files[f.File] = lines
+		}
+		if f.Line > len(lines) {
+			continue
+		}
+		f.Fix = models.LineFix(description, f.Line, lines[f.Line-1], re, def.Replace)
+	}
+}
+
 func (s *Scanner) buildFinding(rule config.RuleDefinition, file string, line int, snippet string) models.Finding {
 	return models.Finding{
 		ID:          rule.ID,
@@ -478,6 +561,7 @@ func (s *Scanner) buildFinding(rule config.RuleDefinition, file string, line int
 		CodeSnippet: truncate(snippet, 200),
 		Remediation: rule.Remediation,
 		References:  rule.References,
+		Tags:        rule.Tags,
 	}
 }
 
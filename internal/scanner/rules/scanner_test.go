@@ -246,3 +246,41 @@ func TestSkipDir(t *testing.T) {
 		}
 	}
 }
+
+func TestRulesScanner_Fix(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "app"), 0755)
+	os.WriteFile(filepath.Join(dir, "app", "Auth.php"), []byte(`<?php
+    $hash = md5($password);
+    $etag = md5($body);
+`), 0644)
+
+	rules := []config.RuleDefinition{
+		{
+			ID:       "TEST-FIX",
+			Title:    "md5 used",
+			Severity: "high",
+			Enabled:  true,
+			Patterns: []config.PatternDef{
+				{Type: "regex", Target: "php-files", Pattern: `\bmd5\s*\(`},
+			},
+			Fix: &config.FixDef{Find: `\bmd5\s*\((\$\w*password\w*)\)`, Replace: "Hash::make($1)"},
+		},
+	}
+
+	findings, _ := New(rules).Scan(context.Background(), models.ProjectContext{RootPath: dir}, func(f models.Finding) {})
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %d", len(findings))
+	}
+
+	fix := findings[0].Fix
+	if fix == nil {
+		t.Fatal("password hash should carry a fix")
+	}
+	if fix.Replacement != "Hash::make($password)" || fix.StartColumn != 13 {
+		t.Errorf("fix = %+v, want Hash::make($password) at column 13", fix)
+	}
+	if findings[1].Fix != nil {
+		t.Errorf("non-password md5 should have no fix, got %+v", findings[1].Fix)
+	}
+}
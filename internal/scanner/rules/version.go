@@ -0,0 +1,187 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+)
+
+// versionSatisfies reports whether version matches a composer-style constraint.
+// Alternatives are separated by "||"; clauses within an alternative are
+// separated by commas or spaces and must all hold. Supported clauses:
+// >=, <=, >, <, =, !=, ^, ~, wildcards (11.*) and bare prefixes (11 = 11.*).
+func versionSatisfies(version, constraint string) bool {
+	v, ok := parseVersion(version)
+	if !ok {
+		return false
+	}
+
+	for _, alt := range strings.Split(constraint, "||") {
+		clauses := splitClauses(alt)
+		if len(clauses) == 0 {
+			continue
+		}
+		all := true
+		for _, c := range clauses {
+			if !clauseSatisfied(v, c) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+// splitClauses tokenizes a constraint alternative, joining operators that
+// were separated from their version by whitespace (">= 10").
+func splitClauses(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+
+	var clauses []string
+	pending := ""
+	for _, f := range fields {
+		if strings.Trim(f, "<>=!^~") == "" {
+			pending += f
+			continue
+		}
+		clauses = append(clauses, pending+f)
+		pending = ""
+	}
+	return clauses
+}
+
+func clauseSatisfied(v []int, clause string) bool {
+	op, rest := splitOperator(clause)
+
+	if op == "" || op == "=" || op == "==" {
+		if strings.HasSuffix(rest, ".*") || strings.HasSuffix(rest, ".x") || strings.Count(rest, ".") < 2 {
+			return prefixMatch(v, strings.TrimSuffix(strings.TrimSuffix(rest, ".*"), ".x"))
+		}
+	}
+
+	c, ok := parseVersion(rest)
+	if !ok {
+		return false
+	}
+	cmp := compareVersions(v, c)
+
+	switch op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case "!=":
+		return cmp != 0
+	case "^":
+		return cmp >= 0 && compareVersions(v, caretUpper(c)) < 0
+	case "~":
+		return cmp >= 0 && compareVersions(v, tildeUpper(c, strings.Count(rest, "."))) < 0
+	default:
+		return cmp == 0
+	}
+}
+
+func splitOperator(clause string) (string, string) {
+	for _, op := range []string{">=", "<=", "!=", "==", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(clause, op) {
+			return op, strings.TrimSpace(clause[len(op):])
+		}
+	}
+	return "", clause
+}
+
+// prefixMatch reports whether v starts with the given dotted prefix.
+func prefixMatch(v []int, prefix string) bool {
+	p, ok := parseVersion(prefix)
+	if !ok {
+		return false
+	}
+	n := strings.Count(prefix, ".") + 1
+	for i := 0; i < n && i < len(p); i++ {
+		if v[i] != p[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// caretUpper returns the exclusive upper bound for ^c: the next major
+// version, or the next minor for 0.x releases.
+func caretUpper(c []int) []int {
+	if c[0] == 0 {
+		return []int{0, c[1] + 1, 0}
+	}
+	return []int{c[0] + 1, 0, 0}
+}
+
+// tildeUpper returns the exclusive upper bound for ~c. With two parts
+// (~1.2) the major may not change; with three (~1.2.3) the minor may not.
+func tildeUpper(c []int, dots int) []int {
+	if dots >= 2 {
+		return []int{c[0], c[1] + 1, 0}
+	}
+	return []int{c[0] + 1, 0, 0}
+}
+
+// parseVersion turns "v11.2.3-beta" into [11 2 3]. Missing parts are zero.
+func parseVersion(s string) ([]int, bool) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "v"), "V")
+	if i := strings.IndexAny(s, "-+@ "); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" {
+		return nil, false
+	}
+
+	v := make([]int, 3)
+	for i, part := range strings.SplitN(s, ".", 4) {
+		if i >= 3 {
+			break
+		}
+		if part == "*" || part == "x" {
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+func compareVersions(a, b []int) int {
+	for i := 0; i < 3; i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// lowerBound extracts the minimum version from a composer constraint,
+// e.g. "^11.0" → "11.0", ">=8.1 <9" → "8.1". Returns "" if none is found.
+func lowerBound(constraint string) string {
+	alt := strings.Split(constraint, "||")[0]
+	for _, c := range splitClauses(alt) {
+		op, rest := splitOperator(c)
+		if op == "<" || op == "<=" || op == "!=" {
+			continue
+		}
+		rest = strings.TrimSuffix(strings.TrimSuffix(rest, ".*"), ".x")
+		if _, ok := parseVersion(rest); ok {
+			return rest
+		}
+	}
+	return ""
+}
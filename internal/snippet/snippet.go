@@ -0,0 +1,114 @@
+package snippet
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// DefaultLines is the context captured on each side of a finding's line
+// when output.context_lines isn't set.
+const DefaultLines = 3
+
+// maxLineLen caps each captured line, so minified files don't bloat
+// reports.
+const maxLineLen = 240
+
+// Attach captures n lines of context around each finding and related
+// location that points at a line of a readable file under root. Reading
+// happens at scan time so reports rendered later, from the store or after
+// a remote clone is removed, still show the code that was scanned.
+//
+// Secrets findings and dotenv files are skipped: scanners mask the values
+// in their snippets, and the surrounding lines would show them in full.
+func Attach(root string, findings []models.Finding, n int) {
+	if n < 0 {
+		return
+	}
+	files := make(map[string][]string)
+	window := func(file string, line int) *models.SourceContext {
+		if file == "" || line < 1 || isDotenv(file) {
+			return nil
+		}
+		lines, ok := files[file]
+		if !ok {
+			lines = readLines(root, file)
+			files[file] = lines
+		}
+		return Window(lines, line, n)
+	}
+
+	for i := range findings {
+		f := &findings[i]
+		if f.Category == "Secrets" {
+			continue
+		}
+		f.Context = window(f.File, f.Line)
+		for j := range f.Related {
+			r := &f.Related[j]
+			r.Context = window(r.File, r.Line)
+		}
+	}
+}
+
+// Window returns n lines on each side of line, or nil when line is not in
+// lines.
+func Window(lines []string, line, n int) *models.SourceContext {
+	if line < 1 || line > len(lines) {
+		return nil
+	}
+	start := max(line-n, 1)
+	end := min(line+n, len(lines))
+
+	ctx := &models.SourceContext{StartLine: start, Lines: make([]string, 0, end-start+1)}
+	for _, l := range lines[start-1 : end] {
+		ctx.Lines = append(ctx.Lines, clip(l))
+	}
+	return ctx
+}
+
+// Language names the syntax of file for highlighting: "blade", "php", or
+// "" for anything else.
+func Language(file string) string {
+	switch {
+	case strings.HasSuffix(file, ".blade.php"):
+		return "blade"
+	case strings.HasSuffix(file, ".php"):
+		return "php"
+	}
+	return ""
+}
+
+func isDotenv(file string) bool {
+	return strings.HasPrefix(filepath.Base(file), ".env")
+}
+
+func readLines(root, file string) []string {
+	path := file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, file)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !utf8.Valid(data) {
+		return nil
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// clip shortens a line to maxLineLen bytes without splitting a rune, and
+// expands tabs so columns line up in terminals.
+func clip(line string) string {
+	line = strings.ReplaceAll(line, "\t", "    ")
+	if len(line) <= maxLineLen {
+		return line
+	}
+	cut := maxLineLen
+	for cut > 0 && !utf8.RuneStart(line[cut]) {
+		cut--
+	}
+	return line[:cut] + "…"
+}
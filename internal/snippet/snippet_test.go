@@ -0,0 +1,55 @@
+package snippet
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+func TestAttach(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "app"), 0755)
+	var src strings.Builder
+	for i := 1; i <= 10; i++ {
+		src.WriteString("line" + string(rune('0'+i%10)) + "\n")
+	}
+	os.WriteFile(filepath.Join(dir, "app", "A.php"), []byte(src.String()), 0644)
+
+	findings := []models.Finding{
+		{File: "app/A.php", Line: 2, Related: []models.Location{{File: "app/A.php", Line: 10}}},
+		{File: "app/Missing.php", Line: 1},
+		{File: "app/A.php"},
+		{File: "app/A.php", Line: 3, Category: "Secrets"},
+		{File: ".env.production", Line: 1},
+	}
+	os.WriteFile(filepath.Join(dir, ".env.production"), []byte("APP_KEY=secret\n"), 0644)
+	Attach(dir, findings, 2)
+
+	ctx := findings[0].Context
+	if ctx == nil || ctx.StartLine != 1 || ctx.EndLine() != 4 || ctx.Lines[1] != "line2" {
+		t.Errorf("context = %+v, want lines 1-4", ctx)
+	}
+	if r := findings[0].Related[0].Context; r == nil || r.StartLine != 8 || r.EndLine() != 10 {
+		t.Errorf("related context = %+v, want lines 8-10", r)
+	}
+	if findings[1].Context != nil || findings[2].Context != nil {
+		t.Error("expected no context for a missing file or a finding without a line")
+	}
+	if findings[3].Context != nil || findings[4].Context != nil {
+		t.Error("expected no context for secrets or dotenv files")
+	}
+}
+
+func TestClip(t *testing.T) {
+	long := strings.Repeat("é", maxLineLen)
+	got := clip(long)
+	if !strings.HasSuffix(got, "…") || len(got) > maxLineLen+len("…") {
+		t.Errorf("clip produced %d bytes", len(got))
+	}
+	if clip("\tx") != "    x" {
+		t.Errorf("tabs not expanded: %q", clip("\tx"))
+	}
+}
@@ -0,0 +1,166 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/eljakani/ward/internal/config"
+
+	_ "modernc.org/sqlite" // pure-Go SQLite driver, registered as "sqlite"
+)
+
+// dbFile is the SQLite database in ~/.ward/store.
+const dbFile = "ward.db"
+
+// schemaVersion is kept in PRAGMA user_version and bumped with each
+// change to the schema.
+const schemaVersion = 4
+
+// schema creates version 1 of the store; migrate brings it up to date.
+// Times and durations are nanoseconds; the severity columns of scans keep
+// the counts of scans imported from JSON records, whose findings have no
+// severity.
+const schema = `
+CREATE TABLE scans (
+	id            TEXT PRIMARY KEY,
+	project_name  TEXT NOT NULL,
+	project_path  TEXT NOT NULL,
+	scanned_at    INTEGER NOT NULL,
+	duration      INTEGER NOT NULL,
+	finding_count INTEGER NOT NULL,
+	critical      INTEGER NOT NULL DEFAULT 0,
+	high          INTEGER NOT NULL DEFAULT 0,
+	medium        INTEGER NOT NULL DEFAULT 0,
+	low           INTEGER NOT NULL DEFAULT 0,
+	info          INTEGER NOT NULL DEFAULT 0,
+	scanners      TEXT NOT NULL,
+	report        BLOB
+);
+CREATE INDEX scans_project ON scans (project_path, scanned_at);
+CREATE INDEX scans_time ON scans (scanned_at);
+
+CREATE TABLE findings (
+	scan_id     TEXT NOT NULL REFERENCES scans (id) ON DELETE CASCADE,
+	fingerprint TEXT NOT NULL,
+	rule_id     TEXT NOT NULL,
+	severity    TEXT NOT NULL DEFAULT '',
+	category    TEXT NOT NULL DEFAULT '',
+	scanner     TEXT NOT NULL DEFAULT '',
+	file        TEXT NOT NULL,
+	line        INTEGER NOT NULL,
+	title       TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX findings_scan ON findings (scan_id);
+CREATE INDEX findings_rule ON findings (rule_id);
+CREATE INDEX findings_file ON findings (file);
+CREATE INDEX findings_fingerprint ON findings (fingerprint);
+`
+
+// open opens the store database in ~/.ward/store, creating it on first
+// use and importing the JSON records earlier versions of Ward wrote.
+func open() (*sql.DB, error) {
+	dir, err := config.StoreDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating store dir: %w", err)
+	}
+	return openPath(filepath.Join(dir, dbFile))
+}
+
+// openPath opens the database at path. Concurrent ward processes share
+// it safely: writes take the lock up front (BEGIN IMMEDIATE) and wait up
+// to the busy timeout for each other, and WAL mode lets reads run
+// alongside a write.
+func openPath(path string) (*sql.DB, error) {
+	// Stored reports hold code snippets, which can include the secrets a
+	// finding is about; SQLite keeps these permissions for its WAL files.
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening scan store: %w", err)
+	}
+	f.Close()
+
+	dsn := path + "?_txlock=immediate" +
+		"&_pragma=busy_timeout(10000)" +
+		"&_pragma=auto_vacuum(incremental)" +
+		"&_pragma=journal_mode(wal)" +
+		"&_pragma=foreign_keys(1)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening scan store: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db, filepath.Dir(path)); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// migrate brings the schema up to date. The first process to get the
+// write lock does the work; others find it done when they get it.
+func migrate(db *sql.DB, dir string) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("opening scan store: %w", err)
+	}
+	if version == schemaVersion {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("migrating scan store: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("migrating scan store: %w", err)
+	}
+	switch {
+	case version == schemaVersion:
+		return nil
+	case version > schemaVersion:
+		return fmt.Errorf("scan store %s was written by a newer version of Ward", filepath.Join(dir, dbFile))
+	}
+
+	if version < 1 {
+		if _, err := tx.Exec(schema); err != nil {
+			return fmt.Errorf("creating scan store: %w", err)
+		}
+	}
+	if version < 2 {
+		if err := addProjectID(tx); err != nil {
+			return fmt.Errorf("migrating scan store: %w", err)
+		}
+	}
+	if version < 3 {
+		if err := addCommit(tx); err != nil {
+			return fmt.Errorf("migrating scan store: %w", err)
+		}
+	}
+	if version < 1 {
+		// Imported with the current schema, once it's in place.
+		if err := importJSON(tx, dir); err != nil {
+			return err
+		}
+	}
+	if version < 4 {
+		// Replays every scan, imported ones included.
+		if err := addLifecycles(tx); err != nil {
+			return fmt.Errorf("migrating scan store: %w", err)
+		}
+	}
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+		return fmt.Errorf("migrating scan store: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migrating scan store: %w", err)
+	}
+	return nil
+}
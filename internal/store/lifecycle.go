@@ -0,0 +1,321 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// FindingLifecycle is one lifecycle of a finding in a project's history.
+type FindingLifecycle struct {
+	ProjectID   string
+	ProjectName string
+	Finding     models.Finding // as last reported
+	models.Lifecycle
+
+	unrated bool // only reported by scans imported from JSON records, which have no severity
+}
+
+// lifecycleTracker follows the findings of one project through its
+// scans, oldest first.
+type lifecycleTracker struct {
+	projectName string
+	open        map[string]*FindingLifecycle // by fingerprint
+	done        []FindingLifecycle
+}
+
+func newLifecycleTracker() *lifecycleTracker {
+	return &lifecycleTracker{open: make(map[string]*FindingLifecycle)}
+}
+
+// scan moves the tracker past a scan. Findings it reports open a
+// lifecycle or extend theirs; open findings it doesn't report are
+// resolved, unless their scanner didn't run.
+func (t *lifecycleTracker) scan(at time.Time, commit string, scanners []string, findings []models.Finding, unrated bool) {
+	seen := make(map[string]bool, len(findings))
+	for _, f := range findings {
+		fp := f.Fingerprint()
+		seen[fp] = true
+		l, ok := t.open[fp]
+		if !ok {
+			l = &FindingLifecycle{Lifecycle: models.Lifecycle{FirstSeen: at, FirstCommit: commit}, unrated: true}
+			t.open[fp] = l
+		}
+		l.Finding, l.LastSeen = f, at
+		l.unrated = l.unrated && unrated
+	}
+	for fp, l := range t.open {
+		if seen[fp] || (l.Finding.Scanner != "" && !slices.Contains(scanners, l.Finding.Scanner)) {
+			continue
+		}
+		l.ResolvedAt = at
+		t.done = append(t.done, *l)
+		delete(t.open, fp)
+	}
+}
+
+// trackLifecycles replays the stored scans of the project with the given
+// identity, or of every project for "", and returns a tracker for each
+// project by identity. Only the migration that fills the lifecycles
+// table replays history; scans saved since move it on one at a time.
+func trackLifecycles(tx *sql.Tx, projectID string) (map[string]*lifecycleTracker, error) {
+	rows, err := tx.Query(`SELECT s.id, s.project_id, s.project_name, s.scanned_at, s.git_commit, s.scanners, s.report IS NULL,
+			f.rule_id, f.severity, f.category, f.scanner, f.file, f.line, f.title
+		FROM scans s LEFT JOIN findings f ON f.scan_id = s.id
+		WHERE ? = '' OR s.project_id = ?
+		ORDER BY s.project_id, s.scanned_at, s.id`, projectID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("reading finding history: %w", err)
+	}
+	defer rows.Close()
+
+	trackers := make(map[string]*lifecycleTracker)
+	type pending struct {
+		id, projectID, commit string
+		at                    time.Time
+		scanners              []string
+		imported              bool
+		findings              []models.Finding
+	}
+	var cur *pending
+	flush := func() {
+		if cur == nil {
+			return
+		}
+		t := trackers[cur.projectID]
+		cur.findings = slices.DeleteFunc(cur.findings, func(f models.Finding) bool { return f.ID == "" })
+		t.scan(cur.at, cur.commit, cur.scanners, cur.findings, cur.imported)
+	}
+
+	for rows.Next() {
+		var (
+			id, project, name, commit, scanners string
+			at                                  int64
+			imported                            bool
+			rule, severity, category, scanner   sql.NullString
+			file, title                         sql.NullString
+			line                                sql.NullInt64
+		)
+		if err := rows.Scan(&id, &project, &name, &at, &commit, &scanners, &imported,
+			&rule, &severity, &category, &scanner, &file, &line, &title); err != nil {
+			return nil, fmt.Errorf("reading finding history: %w", err)
+		}
+
+		if cur == nil || cur.id != id {
+			flush()
+			cur = &pending{id: id, projectID: project, commit: commit, at: time.Unix(0, at), imported: imported}
+			if scanners != "" {
+				cur.scanners = strings.Split(scanners, ",")
+			}
+			if trackers[project] == nil {
+				trackers[project] = newLifecycleTracker()
+			}
+			trackers[project].projectName = name
+		}
+		cur.findings = append(cur.findings, models.Finding{
+			ID:       rule.String,
+			Severity: models.ParseSeverity(severity.String),
+			Category: category.String,
+			Scanner:  scanner.String,
+			File:     file.String,
+			Line:     int(line.Int64),
+			Title:    title.String,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading finding history: %w", err)
+	}
+	flush()
+	return trackers, nil
+}
+
+const lifecycleColumns = `project_id, project_name, rule_id, severity, category, scanner, file, line, title,
+	first_seen, first_commit, last_seen, resolved_at, rated`
+
+// insertLifecycle adds a lifecycle to the lifecycles table.
+func insertLifecycle(tx *sql.Tx, l FindingLifecycle) error {
+	f := l.Finding
+	var resolved int64
+	if !l.Open() {
+		resolved = l.ResolvedAt.UnixNano()
+	}
+	_, err := tx.Exec(`INSERT INTO lifecycles (fingerprint, `+lifecycleColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		f.Fingerprint(), l.ProjectID, l.ProjectName, f.ID, f.Severity.String(), f.Category, f.Scanner, f.File, f.Line, f.Title,
+		l.FirstSeen.UnixNano(), l.FirstCommit, l.LastSeen.UnixNano(), resolved, !l.unrated)
+	return err
+}
+
+// trackScan moves the lifecycles of a project past a scan just stored,
+// as lifecycleTracker.scan does for a replayed one. findings are those
+// the scan made before its severity threshold and baseline filtered
+// them, so a finding the report leaves out isn't taken as resolved.
+func trackScan(tx *sql.Tx, record *ScanRecord, findings []models.Finding) error {
+	type openLifecycle struct {
+		rowid   int64
+		scanner string
+	}
+	rows, err := tx.Query(`SELECT rowid, fingerprint, scanner FROM lifecycles
+		WHERE project_id = ? AND resolved_at = 0`, record.ProjectID)
+	if err != nil {
+		return err
+	}
+	lifecycles := make(map[string]openLifecycle)
+	for rows.Next() {
+		var (
+			l  openLifecycle
+			fp string
+		)
+		if err := rows.Scan(&l.rowid, &fp, &l.scanner); err != nil {
+			rows.Close()
+			return err
+		}
+		lifecycles[fp] = l
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	at := record.Timestamp.UnixNano()
+	seen := make(map[string]bool, len(findings))
+	for _, f := range findings {
+		fp := f.Fingerprint()
+		if seen[fp] {
+			continue
+		}
+		seen[fp] = true
+		if l, ok := lifecycles[fp]; ok {
+			_, err = tx.Exec(`UPDATE lifecycles SET rule_id = ?, severity = ?, category = ?, scanner = ?, file = ?, line = ?, title = ?,
+				last_seen = ?, rated = 1 WHERE rowid = ?`,
+				f.ID, f.Severity.String(), f.Category, f.Scanner, f.File, f.Line, f.Title, at, l.rowid)
+		} else {
+			err = insertLifecycle(tx, FindingLifecycle{
+				ProjectID: record.ProjectID, ProjectName: record.ProjectName, Finding: f,
+				Lifecycle: models.Lifecycle{FirstSeen: record.Timestamp, FirstCommit: record.Commit, LastSeen: record.Timestamp},
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for fp, l := range lifecycles {
+		if seen[fp] || (l.scanner != "" && !slices.Contains(record.ScannersRun, l.scanner)) {
+			continue
+		}
+		if _, err := tx.Exec("UPDATE lifecycles SET resolved_at = ? WHERE rowid = ?", at, l.rowid); err != nil {
+			return err
+		}
+	}
+
+	// Lifecycles are listed under the project's latest name.
+	_, err = tx.Exec("UPDATE lifecycles SET project_name = ? WHERE project_id = ? AND project_name != ?",
+		record.ProjectName, record.ProjectID, record.ProjectName)
+	return err
+}
+
+// Lifecycles returns the lifecycles of the findings of the project with
+// the given identity, or of every project for "": resolved ones, and the
+// open ones of each project's latest scan. Findings only known from scans
+// imported from JSON records are left out, as they have no severity.
+// They are sorted by severity, then oldest first.
+func Lifecycles(projectID string) ([]FindingLifecycle, error) {
+	db, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT `+lifecycleColumns+` FROM lifecycles
+		WHERE rated AND (? = '' OR project_id = ?)`, projectID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("reading finding history: %w", err)
+	}
+	defer rows.Close()
+
+	var out []FindingLifecycle
+	for rows.Next() {
+		var (
+			l                             FindingLifecycle
+			severity                      string
+			firstSeen, lastSeen, resolved int64
+			rated                         bool
+		)
+		f := &l.Finding
+		if err := rows.Scan(&l.ProjectID, &l.ProjectName, &f.ID, &severity, &f.Category, &f.Scanner, &f.File, &f.Line, &f.Title,
+			&firstSeen, &l.FirstCommit, &lastSeen, &resolved, &rated); err != nil {
+			return nil, fmt.Errorf("reading finding history: %w", err)
+		}
+		f.Severity = models.ParseSeverity(severity)
+		l.FirstSeen, l.LastSeen = time.Unix(0, firstSeen), time.Unix(0, lastSeen)
+		if resolved != 0 {
+			l.ResolvedAt = time.Unix(0, resolved)
+		}
+		out = append(out, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading finding history: %w", err)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Finding.Severity != b.Finding.Severity {
+			return a.Finding.Severity > b.Finding.Severity
+		}
+		if !a.FirstSeen.Equal(b.FirstSeen) {
+			return a.FirstSeen.Before(b.FirstSeen)
+		}
+		return a.Finding.Fingerprint() < b.Finding.Fingerprint()
+	})
+	return out, nil
+}
+
+// Annotate sets the Lifecycle of each finding in report, a scan not yet
+// saved, from the open lifecycles of its project. Findings the previous
+// scan didn't report start their lifecycle with this scan.
+func Annotate(report *models.ScanReport) error {
+	db, err := open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT fingerprint, first_seen, first_commit FROM lifecycles
+		WHERE project_id = ? AND resolved_at = 0`, projectID(&report.ProjectContext))
+	if err != nil {
+		return fmt.Errorf("reading finding history: %w", err)
+	}
+	defer rows.Close()
+
+	lifecycles := make(map[string]models.Lifecycle)
+	for rows.Next() {
+		var (
+			fp        string
+			firstSeen int64
+			l         models.Lifecycle
+		)
+		if err := rows.Scan(&fp, &firstSeen, &l.FirstCommit); err != nil {
+			return fmt.Errorf("reading finding history: %w", err)
+		}
+		l.FirstSeen = time.Unix(0, firstSeen)
+		lifecycles[fp] = l
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("reading finding history: %w", err)
+	}
+
+	for i, f := range report.Findings {
+		lc, ok := lifecycles[f.Fingerprint()]
+		if !ok {
+			lc = models.Lifecycle{FirstSeen: report.CompletedAt, FirstCommit: report.ProjectContext.GitCommit}
+		}
+		lc.LastSeen = report.CompletedAt
+		report.Findings[i].Lifecycle = &lc
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// legacyRecord is a scan as earlier versions of Ward stored it: one JSON
+// file per scan in ~/.ward/store, with findings as "ID|file|line" keys.
+type legacyRecord struct {
+	ID           string         `json:"id"`
+	ProjectName  string         `json:"project_name"`
+	ProjectPath  string         `json:"project_path"`
+	Timestamp    time.Time      `json:"timestamp"`
+	Duration     string         `json:"duration"`
+	FindingCount int            `json:"finding_count"`
+	BySeverity   map[string]int `json:"by_severity"`
+	ScannersRun  []string       `json:"scanners_run"`
+	FindingIDs   []string       `json:"finding_ids"`
+	ReportFile   string         `json:"report_file,omitempty"` // gzipped full report next to the record
+}
+
+// importJSON copies the JSON records in dir into the database. Files that
+// can't be read are skipped, as ListRecords used to; the files are left
+// in place and are no longer read.
+func importJSON(tx *sql.Tx, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("importing scan records: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var lr legacyRecord
+		if err := json.Unmarshal(data, &lr); err != nil || lr.ID == "" {
+			continue
+		}
+
+		duration, _ := time.ParseDuration(lr.Duration)
+		record := &ScanRecord{
+			ID:           lr.ID,
+			ProjectID:    lr.ProjectPath,
+			ProjectName:  lr.ProjectName,
+			ProjectPath:  lr.ProjectPath,
+			Timestamp:    lr.Timestamp,
+			Duration:     duration,
+			FindingCount: lr.FindingCount,
+			BySeverity:   lr.BySeverity,
+			ScannersRun:  lr.ScannersRun,
+		}
+		findings := make([]models.Finding, 0, len(lr.FindingIDs))
+		for _, k := range lr.FindingIDs {
+			findings = append(findings, parseFindingKey(k))
+		}
+
+		// A record with its full report (already in the stored format)
+		// has complete findings; without one the keys have to do.
+		var report []byte
+		if lr.ReportFile != "" {
+			if data, err := os.ReadFile(filepath.Join(dir, lr.ReportFile)); err == nil {
+				if full, err := decodeReport(data); err == nil {
+					report, findings = data, full.Findings
+					record.ProjectID = projectID(&full.ProjectContext)
+					record.Commit = full.ProjectContext.GitCommit
+				}
+			}
+		}
+
+		if _, err := insertScan(tx, record, findings, report); err != nil {
+			return fmt.Errorf("importing scan record %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// addProjectID keys scans on the project identity rather than the path
+// (schema version 2). Stored scans of a git checkout get the identity of
+// its origin remote, so their history carries on; the rest keep their
+// path, which is what the identity falls back to.
+func addProjectID(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE scans ADD COLUMN project_id TEXT NOT NULL DEFAULT '';
+		UPDATE scans SET project_id = project_path;
+		CREATE INDEX scans_project_id ON scans (project_id, scanned_at);`); err != nil {
+		return err
+	}
+	return backfill(tx, "project_id", func(r *models.ScanReport) string {
+		return projectID(&r.ProjectContext)
+	})
+}
+
+// addCommit records the commit each scan ran at (schema version 3), so
+// finding lifecycles can say where a finding appeared. Imported scans
+// have no report to take it from.
+func addCommit(tx *sql.Tx) error {
+	if _, err := tx.Exec(`ALTER TABLE scans ADD COLUMN git_commit TEXT NOT NULL DEFAULT ''`); err != nil {
+		return err
+	}
+	return backfill(tx, "git_commit", func(r *models.ScanReport) string {
+		return r.ProjectContext.GitCommit
+	})
+}
+
+// addLifecycles keeps the lifecycle of each finding in a table (schema
+// version 4), so a scan moves it on rather than replaying the project's
+// history. It is filled by replaying the stored scans once.
+func addLifecycles(tx *sql.Tx) error {
+	if _, err := tx.Exec(`CREATE TABLE lifecycles (
+		project_id   TEXT NOT NULL,
+		project_name TEXT NOT NULL,
+		fingerprint  TEXT NOT NULL,
+		rule_id      TEXT NOT NULL,
+		severity     TEXT NOT NULL DEFAULT '',
+		category     TEXT NOT NULL DEFAULT '',
+		scanner      TEXT NOT NULL DEFAULT '',
+		file         TEXT NOT NULL,
+		line         INTEGER NOT NULL,
+		title        TEXT NOT NULL DEFAULT '',
+		first_seen   INTEGER NOT NULL,
+		first_commit TEXT NOT NULL DEFAULT '',
+		last_seen    INTEGER NOT NULL,
+		resolved_at  INTEGER NOT NULL DEFAULT 0,
+		rated        INTEGER NOT NULL DEFAULT 1
+	);
+	CREATE INDEX lifecycles_project ON lifecycles (project_id, resolved_at);
+	CREATE UNIQUE INDEX lifecycles_open ON lifecycles (project_id, fingerprint) WHERE resolved_at = 0;`); err != nil {
+		return err
+	}
+
+	trackers, err := trackLifecycles(tx, "")
+	if err != nil {
+		return err
+	}
+	for id, t := range trackers {
+		all := t.done
+		for _, l := range t.open {
+			all = append(all, *l)
+		}
+		for _, l := range all {
+			l.ProjectID, l.ProjectName = id, t.projectName
+			if err := insertLifecycle(tx, l); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// backfill sets column of each scan with a stored report to value of the
+// report, where that isn't empty.
+func backfill(tx *sql.Tx, column string, value func(*models.ScanReport) string) error {
+	rows, err := tx.Query("SELECT id, report FROM scans WHERE report IS NOT NULL")
+	if err != nil {
+		return err
+	}
+	values := make(map[string]string)
+	for rows.Next() {
+		var id string
+		var data []byte
+		if err := rows.Scan(&id, &data); err != nil {
+			rows.Close()
+			return err
+		}
+		if report, err := decodeReport(data); err == nil {
+			if v := value(report); v != "" {
+				values[id] = v
+			}
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, v := range values {
+		if _, err := tx.Exec("UPDATE scans SET "+column+" = ? WHERE id = ?", v, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseFindingKey reverses the "ID|file|line" keys of legacy records.
+// The file is everything between the first and last "|", so paths
+// containing one survive.
+func parseFindingKey(key string) models.Finding {
+	id, rest, _ := strings.Cut(key, "|")
+	f := models.Finding{ID: id, File: rest}
+	if i := strings.LastIndex(rest, "|"); i >= 0 {
+		f.File = rest[:i]
+		fmt.Sscan(rest[i+1:], &f.Line)
+	}
+	return f
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// reportFormat is bumped when the stored report can no longer be read
+// into models.ScanReport as it is.
+const reportFormat = 1
+
+// storedReport is the gzip-compressed JSON document kept for each scan,
+// so reports can be rendered again without rescanning.
+type storedReport struct {
+	Format int                `json:"format"`
+	Report *models.ScanReport `json:"report"`
+}
+
+// encodeReport compresses report for the store. The project's .env
+// values are left out: they hold secrets and no reporter uses them.
+func encodeReport(report *models.ScanReport) ([]byte, error) {
+	stored := *report
+	stored.ProjectContext.EnvVariables = nil
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(storedReport{Format: reportFormat, Report: &stored}); err != nil {
+		return nil, fmt.Errorf("encoding scan report: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("encoding scan report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeReport(data []byte) (*models.ScanReport, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("reading scan report: %w", err)
+	}
+	defer zr.Close()
+
+	var sr storedReport
+	if err := json.NewDecoder(zr).Decode(&sr); err != nil {
+		return nil, fmt.Errorf("reading scan report: %w", err)
+	}
+	if sr.Format != reportFormat || sr.Report == nil {
+		return nil, fmt.Errorf("scan report has unsupported format %d", sr.Format)
+	}
+	return sr.Report, nil
+}
@@ -2,165 +2,463 @@ package store
 
 import (
 	"crypto/sha256"
-	"encoding/json"
+	"database/sql"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/eljakani/ward/internal/config"
 	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/resolver"
 )
 
-// ScanRecord is the stored representation of a scan result.
+// ScanRecord is the stored summary of a scan.
 type ScanRecord struct {
-	ID          string         `json:"id"`
-	ProjectName string         `json:"project_name"`
-	ProjectPath string         `json:"project_path"`
-	Timestamp   time.Time      `json:"timestamp"`
-	Duration    string         `json:"duration"`
-	FindingCount int           `json:"finding_count"`
-	BySeverity  map[string]int `json:"by_severity"`
-	ScannersRun []string       `json:"scanners_run"`
-	FindingIDs  []string       `json:"finding_ids"` // unique finding IDs for diffing
+	ID           string
+	ProjectID    string // stable project identity, see models.ProjectContext
+	ProjectName  string
+	ProjectPath  string
+	Timestamp    time.Time
+	Duration     time.Duration
+	FindingCount int
+	BySeverity   map[string]int
+	ScannersRun  []string
+	Commit       string // commit the scan ran at; empty outside git and for imported scans
+	HasReport    bool   // the full report is stored; scans imported from JSON records only have their findings' rule, file and line
 }
 
 // Diff represents the difference between two scans.
 type Diff struct {
-	NewFindings     []string `json:"new_findings"`
-	ResolvedFindings []string `json:"resolved_findings"`
-	TotalBefore     int      `json:"total_before"`
-	TotalAfter      int      `json:"total_after"`
+	New         []models.Finding
+	Resolved    []models.Finding // as stored with the previous scan
+	TotalBefore int
+	TotalAfter  int
+	PreviousAt  time.Time
 }
 
-// Save stores a scan report in ~/.ward/store/.
-func Save(report *models.ScanReport) (*ScanRecord, error) {
-	storeDir, err := config.StoreDir()
-	if err != nil {
-		return nil, err
-	}
+// Filter selects stored scans. Zero fields match every scan.
+type Filter struct {
+	ProjectID   string    // exact project identity
+	ProjectName string    // case-insensitive substring of the project name
+	Rule        string    // scans with a finding of this rule
+	File        string    // scans with a finding in this file, or under this directory
+	Since       time.Time // scans at or after
+	Until       time.Time // scans before
+	Limit       int       // at most this many, most recent first; 0 = all
+}
 
+// severityColumns are the severities of the scans table's count
+// columns, in column order.
+var severityColumns = []models.Severity{
+	models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo,
+}
+
+const scanColumns = `id, project_id, project_name, project_path, scanned_at, duration, finding_count,
+	critical, high, medium, low, info, scanners, git_commit, report IS NOT NULL`
+
+// Save stores a scan report in ~/.ward/store/ and moves the lifecycles
+// of its project's findings past it. tracked are the findings the scan
+// made before its severity threshold and baseline filtered them; nil
+// tracks those of the report.
+func Save(report *models.ScanReport, tracked []models.Finding) (*ScanRecord, error) {
 	record := &ScanRecord{
 		ID:           generateID(report),
+		ProjectID:    projectID(&report.ProjectContext),
 		ProjectName:  report.ProjectContext.ProjectName,
 		ProjectPath:  report.ProjectContext.RootPath,
 		Timestamp:    report.CompletedAt,
-		Duration:     report.Duration.String(),
+		Duration:     report.Duration,
 		FindingCount: len(report.Findings),
 		BySeverity:   make(map[string]int),
 		ScannersRun:  report.ScannersRun,
-		FindingIDs:   extractFindingKeys(report.Findings),
+		Commit:       report.ProjectContext.GitCommit,
+		HasReport:    true,
 	}
-
-	counts := report.CountBySeverity()
-	for sev, count := range counts {
+	for sev, count := range report.CountBySeverity() {
 		record.BySeverity[sev.String()] = count
 	}
 
-	data, err := json.MarshalIndent(record, "", "  ")
+	data, err := encodeReport(report)
 	if err != nil {
-		return nil, fmt.Errorf("marshalling scan record: %w", err)
+		return nil, err
 	}
 
-	filename := fmt.Sprintf("%s_%s.json",
-		record.Timestamp.Format("2006-01-02T15-04-05"),
-		sanitizeName(record.ProjectName))
+	db, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
 
-	path := filepath.Join(storeDir, filename)
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return nil, fmt.Errorf("writing scan record: %w", err)
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("saving scan: %w", err)
 	}
+	defer tx.Rollback()
 
+	inserted, err := insertScan(tx, record, report.Findings, data)
+	if err != nil {
+		return nil, fmt.Errorf("saving scan: %w", err)
+	}
+	if inserted {
+		if tracked == nil {
+			tracked = report.Findings
+		}
+		if err := trackScan(tx, record, tracked); err != nil {
+			return nil, fmt.Errorf("saving scan: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("saving scan: %w", err)
+	}
 	return record, nil
 }
 
-// ListRecords returns all stored scan records, most recent first.
-func ListRecords() ([]ScanRecord, error) {
-	storeDir, err := config.StoreDir()
+// insertScan adds a scan and its findings, and reports whether it did: a
+// scan whose ID is already stored is left as it is.
+func insertScan(tx *sql.Tx, record *ScanRecord, findings []models.Finding, report []byte) (bool, error) {
+	counts := make([]any, len(severityColumns))
+	for i, sev := range severityColumns {
+		counts[i] = record.BySeverity[sev.String()]
+	}
+
+	res, err := tx.Exec(`INSERT OR IGNORE INTO scans (id, project_id, project_name, project_path, scanned_at, duration, finding_count,
+		critical, high, medium, low, info, scanners, git_commit, report) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		append(append([]any{record.ID, record.ProjectID, record.ProjectName, record.ProjectPath, record.Timestamp.UnixNano(),
+			int64(record.Duration), record.FindingCount}, counts...),
+			strings.Join(record.ScannersRun, ","), record.Commit, report)...)
 	if err != nil {
-		return nil, err
+		return false, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return false, nil
 	}
 
-	entries, err := os.ReadDir(storeDir)
+	stmt, err := tx.Prepare(`INSERT INTO findings (scan_id, fingerprint, rule_id, severity, category, scanner, file, line, title)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
+		return false, err
+	}
+	defer stmt.Close()
+
+	for _, f := range findings {
+		severity := f.Severity.String()
+		if report == nil { // imported from a JSON record's finding keys
+			severity = ""
 		}
+		if _, err := stmt.Exec(record.ID, f.Fingerprint(), f.ID, severity, f.Category, f.Scanner, f.File, f.Line, f.Title); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ListRecords returns the stored scans that pass f, most recent first.
+func ListRecords(f Filter) ([]ScanRecord, error) {
+	var (
+		where []string
+		args  []any
+	)
+	if f.ProjectID != "" {
+		where = append(where, "project_id = ?")
+		args = append(args, f.ProjectID)
+	}
+	if f.ProjectName != "" {
+		where = append(where, `project_name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(f.ProjectName)+"%")
+	}
+	if f.Rule != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM findings WHERE scan_id = scans.id AND rule_id = ? COLLATE NOCASE)")
+		args = append(args, f.Rule)
+	}
+	if f.File != "" {
+		file := strings.TrimSuffix(f.File, "/")
+		where = append(where, `EXISTS (SELECT 1 FROM findings WHERE scan_id = scans.id AND (file = ? OR file LIKE ? ESCAPE '\'))`)
+		args = append(args, file, escapeLike(file)+"/%")
+	}
+	if !f.Since.IsZero() {
+		where = append(where, "scanned_at >= ?")
+		args = append(args, f.Since.UnixNano())
+	}
+	if !f.Until.IsZero() {
+		where = append(where, "scanned_at < ?")
+		args = append(args, f.Until.UnixNano())
+	}
+
+	query := "SELECT " + scanColumns + " FROM scans"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY scanned_at DESC"
+	if f.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", f.Limit)
+	}
+
+	db, err := open()
+	if err != nil {
 		return nil, err
 	}
+	defer db.Close()
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing scans: %w", err)
+	}
+	defer rows.Close()
 
 	var records []ScanRecord
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
-			continue
+	for rows.Next() {
+		r, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing scans: %w", err)
 		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing scans: %w", err)
+	}
+	return records, nil
+}
+
+// LastRecord returns the most recent scan of the project with the given
+// identity.
+func LastRecord(projectID string) (*ScanRecord, error) {
+	records, err := ListRecords(Filter{ProjectID: projectID, Limit: 1})
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	return &records[0], nil
+}
 
-		data, err := os.ReadFile(filepath.Join(storeDir, entry.Name()))
+// FindRecord looks up a stored scan by ID, by a unique prefix of its ID,
+// or as "latest" for the most recent scan.
+func FindRecord(ref string) (*ScanRecord, error) {
+	if ref == "latest" {
+		last, err := ListRecords(Filter{Limit: 1})
 		if err != nil {
-			continue
+			return nil, err
 		}
+		if len(last) == 0 {
+			return nil, fmt.Errorf("no stored scans; run ward scan first")
+		}
+		return &last[0], nil
+	}
 
-		var record ScanRecord
-		if err := json.Unmarshal(data, &record); err != nil {
-			continue
+	db, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT "+scanColumns+` FROM scans WHERE id LIKE ? ESCAPE '\' ORDER BY id = ? DESC LIMIT 2`,
+		escapeLike(ref)+"%", ref)
+	if err != nil {
+		return nil, fmt.Errorf("finding scan: %w", err)
+	}
+	defer rows.Close()
+
+	var found []ScanRecord
+	for rows.Next() {
+		r, err := scanRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("finding scan: %w", err)
 		}
+		found = append(found, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("finding scan: %w", err)
+	}
 
-		records = append(records, record)
+	switch {
+	case len(found) == 0:
+		return nil, fmt.Errorf("no stored scan with ID %q", ref)
+	case found[0].ID == ref || len(found) == 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("scan ID %q is ambiguous: matches %s and %s", ref, found[0].ID, found[1].ID)
 	}
+}
 
-	sort.Slice(records, func(i, j int) bool {
-		return records[i].Timestamp.After(records[j].Timestamp)
-	})
+// LoadReport reads the full report stored with record.
+func LoadReport(record *ScanRecord) (*models.ScanReport, error) {
+	db, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
 
-	return records, nil
+	var data []byte
+	err = db.QueryRow("SELECT report FROM scans WHERE id = ?", record.ID).Scan(&data)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("no stored scan with ID %q", record.ID)
+	case err != nil:
+		return nil, fmt.Errorf("reading scan report: %w", err)
+	case data == nil:
+		return nil, fmt.Errorf("scan %s was stored without its full report by an older Ward; scan again to keep one", record.ID)
+	}
+	return decodeReport(data)
 }
 
-// LastRecord returns the most recent scan for a given project path.
-func LastRecord(projectPath string) (*ScanRecord, error) {
-	records, err := ListRecords()
+// Findings returns the findings stored for a scan, sorted by severity.
+// Findings of scans imported from JSON records carry only the rule ID,
+// file and line.
+func Findings(scanID string) ([]models.Finding, error) {
+	db, err := open()
 	if err != nil {
 		return nil, err
 	}
+	defer db.Close()
+	return findings(db, scanID)
+}
 
-	for _, r := range records {
-		if r.ProjectPath == projectPath {
-			return &r, nil
+func findings(db *sql.DB, scanID string) ([]models.Finding, error) {
+	rows, err := db.Query(`SELECT rule_id, severity, category, scanner, file, line, title
+		FROM findings WHERE scan_id = ?`, scanID)
+	if err != nil {
+		return nil, fmt.Errorf("reading findings: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.Finding
+	for rows.Next() {
+		var f models.Finding
+		var severity string
+		if err := rows.Scan(&f.ID, &severity, &f.Category, &f.Scanner, &f.File, &f.Line, &f.Title); err != nil {
+			return nil, fmt.Errorf("reading findings: %w", err)
 		}
+		f.Severity = models.ParseSeverity(severity)
+		out = append(out, f)
 	}
-	return nil, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading findings: %w", err)
+	}
+	models.SortFindings(out)
+	return out, nil
+}
+
+// Prune deletes the scans that keep no longer retains: those beyond the
+// KeepLast most recent of each project, and those older than KeepDays.
+// It returns how many scans it deleted.
+func Prune(keep config.StoreConfig) (int, error) {
+	if keep.KeepLast <= 0 && keep.KeepDays <= 0 {
+		return 0, nil
+	}
+
+	db, err := open()
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("pruning scans: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleted := 0
+	if keep.KeepDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -keep.KeepDays)
+		res, err := tx.Exec("DELETE FROM scans WHERE scanned_at < ?", cutoff.UnixNano())
+		if err != nil {
+			return 0, fmt.Errorf("pruning scans: %w", err)
+		}
+		n, _ := res.RowsAffected()
+		deleted += int(n)
+	}
+	if keep.KeepLast > 0 {
+		res, err := tx.Exec(`DELETE FROM scans WHERE id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id ORDER BY scanned_at DESC) AS n FROM scans
+			) WHERE n > ?)`, keep.KeepLast)
+		if err != nil {
+			return 0, fmt.Errorf("pruning scans: %w", err)
+		}
+		n, _ := res.RowsAffected()
+		deleted += int(n)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("pruning scans: %w", err)
+	}
+
+	if deleted > 0 {
+		// Return the freed pages to the file system.
+		db.Exec("PRAGMA incremental_vacuum")
+	}
+	return deleted, nil
 }
 
 // CompareLast diffs the current scan against the most recent stored scan for the same project.
 func CompareLast(report *models.ScanReport) (*Diff, error) {
-	last, err := LastRecord(report.ProjectContext.RootPath)
+	last, err := LastRecord(projectID(&report.ProjectContext))
 	if err != nil || last == nil {
 		return nil, err
 	}
+	previous, err := Findings(last.ID)
+	if err != nil {
+		return nil, err
+	}
 
-	currentKeys := extractFindingKeys(report.Findings)
-	currentSet := toSet(currentKeys)
-	previousSet := toSet(last.FindingIDs)
-
-	diff := &Diff{
+	added, resolved, _ := models.CompareFindings(previous, report.Findings)
+	return &Diff{
+		New:         added,
+		Resolved:    resolved,
 		TotalBefore: last.FindingCount,
 		TotalAfter:  len(report.Findings),
+		PreviousAt:  last.Timestamp,
+	}, nil
+}
+
+// Changes returns d for report.Changes.
+func (d *Diff) Changes() *models.ScanChanges {
+	return &models.ScanChanges{
+		PreviousAt:  d.PreviousAt,
+		TotalBefore: d.TotalBefore,
+		New:         d.New,
+		Resolved:    d.Resolved,
 	}
+}
 
-	for _, k := range currentKeys {
-		if !previousSet[k] {
-			diff.NewFindings = append(diff.NewFindings, k)
-		}
+// scanRecord reads a row of scanColumns.
+func scanRecord(row interface{ Scan(...any) error }) (ScanRecord, error) {
+	var (
+		r        ScanRecord
+		at, dur  int64
+		counts   [5]int
+		scanners string
+	)
+	err := row.Scan(&r.ID, &r.ProjectID, &r.ProjectName, &r.ProjectPath, &at, &dur, &r.FindingCount,
+		&counts[0], &counts[1], &counts[2], &counts[3], &counts[4], &scanners, &r.Commit, &r.HasReport)
+	if err != nil {
+		return r, err
 	}
 
-	for _, k := range last.FindingIDs {
-		if !currentSet[k] {
-			diff.ResolvedFindings = append(diff.ResolvedFindings, k)
+	r.Timestamp = time.Unix(0, at)
+	r.Duration = time.Duration(dur)
+	r.BySeverity = make(map[string]int)
+	for i, sev := range severityColumns {
+		if counts[i] > 0 {
+			r.BySeverity[sev.String()] = counts[i]
 		}
 	}
+	if scanners != "" {
+		r.ScannersRun = strings.Split(scanners, ",")
+	}
+	return r, nil
+}
 
-	return diff, nil
+// projectID returns the identity of the project pc describes. Reports
+// from before identities were resolved fall back to what the resolver
+// would have made of them without a project.id or composer name.
+func projectID(pc *models.ProjectContext) string {
+	switch {
+	case pc.ProjectID != "":
+		return pc.ProjectID
+	case pc.GitRemote != "":
+		return resolver.RemoteID(pc.GitRemote)
+	}
+	return pc.RootPath
 }
 
 func generateID(report *models.ScanReport) string {
@@ -170,29 +468,7 @@ func generateID(report *models.ScanReport) string {
 	return fmt.Sprintf("%x", h.Sum(nil))[:12]
 }
 
-func extractFindingKeys(findings []models.Finding) []string {
-	var keys []string
-	for _, f := range findings {
-		key := fmt.Sprintf("%s|%s|%d", f.ID, f.File, f.Line)
-		keys = append(keys, key)
-	}
-	sort.Strings(keys)
-	return keys
-}
-
-func sanitizeName(name string) string {
-	name = strings.ReplaceAll(name, "/", "_")
-	name = strings.ReplaceAll(name, " ", "_")
-	if len(name) > 40 {
-		name = name[:40]
-	}
-	return name
-}
-
-func toSet(items []string) map[string]bool {
-	m := make(map[string]bool, len(items))
-	for _, item := range items {
-		m[item] = true
-	}
-	return m
+// escapeLike escapes the LIKE wildcards in s, for use with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
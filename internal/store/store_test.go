@@ -0,0 +1,403 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+)
+
+func testScan(path string, at time.Time, findings ...models.Finding) *models.ScanReport {
+	return &models.ScanReport{
+		ProjectContext: models.ProjectContext{
+			ProjectName:  filepath.Base(path),
+			RootPath:     path,
+			EnvVariables: map[string]string{"APP_KEY": "secret"},
+		},
+		ScannersRun: []string{"env-scanner", "rules-scanner"},
+		Findings:    findings,
+		StartedAt:   at.Add(-time.Second),
+		CompletedAt: at,
+		Duration:    time.Second,
+	}
+}
+
+var (
+	debugFinding = models.Finding{ID: "ENV-002", Title: "APP_DEBUG is enabled", Severity: models.SeverityHigh, Scanner: "env-scanner", File: ".env", Line: 2}
+	sqlFinding   = models.Finding{ID: "INJECT-001", Title: "Raw SQL", Severity: models.SeverityCritical, Scanner: "rules-scanner", File: "app/Http/Controllers/UserController.php", Line: 14}
+)
+
+func TestSaveAndQuery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now()
+
+	first, err := Save(testScan("/srv/api", now.Add(-48*time.Hour), debugFinding, sqlFinding), nil)
+	if err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := Save(testScan("/srv/api", now, debugFinding), nil); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	if _, err := Save(testScan("/srv/shop", now.Add(-time.Hour)), nil); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   int
+	}{
+		{"all", Filter{}, 3},
+		{"project", Filter{ProjectID: "/srv/api"}, 2},
+		{"project name", Filter{ProjectName: "SHO"}, 1},
+		{"rule", Filter{Rule: "inject-001"}, 1},
+		{"file", Filter{File: ".env"}, 2},
+		{"directory", Filter{File: "app/Http/"}, 1},
+		{"since", Filter{Since: now.Add(-2 * time.Hour)}, 2},
+		{"until", Filter{Until: now.Add(-2 * time.Hour)}, 1},
+		{"limit", Filter{Limit: 1}, 1},
+	}
+	for _, tt := range tests {
+		records, err := ListRecords(tt.filter)
+		if err != nil {
+			t.Fatalf("%s: ListRecords() error: %v", tt.name, err)
+		}
+		if len(records) != tt.want {
+			t.Errorf("%s: got %d records, want %d", tt.name, len(records), tt.want)
+		}
+	}
+
+	records, _ := ListRecords(Filter{})
+	if records[0].ProjectPath != "/srv/api" || !records[0].Timestamp.Equal(now) {
+		t.Errorf("records are not most recent first: %+v", records[0])
+	}
+
+	got, err := FindRecord(first.ID[:6])
+	if err != nil || got.ID != first.ID {
+		t.Fatalf("FindRecord(prefix) = %v, %v", got, err)
+	}
+	if got.BySeverity["Critical"] != 1 || got.Duration != time.Second || len(got.ScannersRun) != 2 || !got.HasReport {
+		t.Errorf("record = %+v", got)
+	}
+	if _, err := FindRecord("zzz"); err == nil {
+		t.Error("FindRecord(unknown) should fail")
+	}
+
+	report, err := LoadReport(got)
+	if err != nil {
+		t.Fatalf("LoadReport() error: %v", err)
+	}
+	if len(report.Findings) != 2 || report.ProjectContext.EnvVariables != nil {
+		t.Errorf("stored report = %+v, want 2 findings and no .env values", report)
+	}
+}
+
+func TestCompareLast(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now()
+
+	if diff, err := CompareLast(testScan("/srv/api", now)); diff != nil || err != nil {
+		t.Fatalf("CompareLast() without history = %v, %v", diff, err)
+	}
+	if _, err := Save(testScan("/srv/api", now.Add(-time.Hour), debugFinding), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := CompareLast(testScan("/srv/api", now, sqlFinding))
+	if err != nil {
+		t.Fatalf("CompareLast() error: %v", err)
+	}
+	if len(diff.New) != 1 || diff.New[0].ID != "INJECT-001" {
+		t.Errorf("new = %+v", diff.New)
+	}
+	if len(diff.Resolved) != 1 || diff.Resolved[0].Title != debugFinding.Title || diff.Resolved[0].Severity != models.SeverityHigh {
+		t.Errorf("resolved = %+v, want the stored finding", diff.Resolved)
+	}
+}
+
+func TestCompareLast_ProjectIdentity(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now()
+
+	// A remote scan, cloned into a temporary directory.
+	remote := testScan("/tmp/ward-scan-123", now.Add(-time.Hour), debugFinding)
+	remote.ProjectContext.ProjectID = "github.com/acme/shop"
+	if _, err := Save(remote, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same repository checked out elsewhere.
+	local := testScan("/home/dev/shop", now, debugFinding, sqlFinding)
+	local.ProjectContext.ProjectID = "github.com/acme/shop"
+	diff, err := CompareLast(local)
+	if err != nil || diff == nil {
+		t.Fatalf("CompareLast() = %v, %v; want a diff against the remote scan", diff, err)
+	}
+	if len(diff.New) != 1 || len(diff.Resolved) != 0 {
+		t.Errorf("diff = %d new, %d resolved; want 1 new", len(diff.New), len(diff.Resolved))
+	}
+
+	if diff, _ := CompareLast(testScan("/home/dev/shop", now)); diff != nil {
+		t.Error("CompareLast() matched a scan of another project by path")
+	}
+}
+
+func TestMigrate_FromV1(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".ward", "store")
+	os.MkdirAll(dir, 0755)
+
+	// A version 1 store, keyed on paths.
+	db, err := sql.Open("sqlite", filepath.Join(dir, dbFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(schema + "PRAGMA user_version = 1;"); err != nil {
+		t.Fatal(err)
+	}
+	withRemote := testScan("/srv/shop", time.Now())
+	withRemote.ProjectContext.GitRemote = "git@github.com:Acme/shop.git"
+	withRemote.ProjectContext.GitCommit = "3f2a1c9e8b7d"
+	data, err := encodeReport(withRemote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	insert := `INSERT INTO scans (id, project_name, project_path, scanned_at, duration, finding_count, scanners, report)
+		VALUES (?, 'shop', ?, 0, 0, 0, '', ?)`
+	db.Exec(insert, "aaaa11112222", "/srv/shop", data)
+	db.Exec(insert, "bbbb11112222", "/srv/api", nil)
+	db.Exec(`INSERT INTO findings (scan_id, fingerprint, rule_id, severity, scanner, file, line)
+		VALUES ('aaaa11112222', ?, 'ENV-002', 'high', 'env-scanner', '.env', 2)`, debugFinding.Fingerprint())
+	db.Close()
+
+	tests := []struct {
+		id, projectID, commit string
+	}{
+		{"aaaa11112222", "github.com/acme/shop", "3f2a1c9e8b7d"},
+		{"bbbb11112222", "/srv/api", ""},
+	}
+	for _, tt := range tests {
+		r, err := FindRecord(tt.id)
+		if err != nil {
+			t.Fatalf("FindRecord(%s) error: %v", tt.id, err)
+		}
+		if r.ProjectID != tt.projectID || r.Commit != tt.commit {
+			t.Errorf("scan %s has project ID %q and commit %q, want %q and %q", tt.id, r.ProjectID, r.Commit, tt.projectID, tt.commit)
+		}
+	}
+
+	// The lifecycles table is filled from the stored history.
+	lifecycles, err := Lifecycles("github.com/acme/shop")
+	if err != nil {
+		t.Fatalf("Lifecycles() error: %v", err)
+	}
+	if len(lifecycles) != 1 || !lifecycles[0].Open() || lifecycles[0].FirstCommit != "3f2a1c9e8b7d" {
+		t.Errorf("migrated lifecycles = %+v, want the open debug finding", lifecycles)
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".ward", "store")
+	os.MkdirAll(dir, 0755)
+
+	os.WriteFile(filepath.Join(dir, "2026-01-05T10-00-00_api.json"), []byte(`{
+  "id": "aaaa11112222",
+  "project_name": "api",
+  "project_path": "/srv/api",
+  "timestamp": "2026-01-05T10:00:00Z",
+  "duration": "1.5s",
+  "finding_count": 2,
+  "by_severity": {"High": 1, "Low": 1},
+  "scanners_run": ["env-scanner"],
+  "finding_ids": ["ENV-002|.env|2", "ENV-006|config/a|b.php|4"]
+}`), 0644)
+	os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644)
+
+	records, err := ListRecords(Filter{})
+	if err != nil {
+		t.Fatalf("ListRecords() error: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want the one valid JSON record", len(records))
+	}
+	r := records[0]
+	if r.ID != "aaaa11112222" || r.Duration != 1500*time.Millisecond || r.BySeverity["High"] != 1 || r.HasReport {
+		t.Errorf("imported record = %+v", r)
+	}
+
+	findings, err := Findings(r.ID)
+	if err != nil {
+		t.Fatalf("Findings() error: %v", err)
+	}
+	if len(findings) != 2 || findings[1].File != "config/a|b.php" || findings[1].Line != 4 {
+		t.Errorf("findings = %+v", findings)
+	}
+	if _, err := LoadReport(&r); err == nil {
+		t.Error("LoadReport() of an imported record without a report should fail")
+	}
+
+	// The records are imported once, not on every open.
+	os.Remove(filepath.Join(dir, "2026-01-05T10-00-00_api.json"))
+	os.WriteFile(filepath.Join(dir, "2026-01-06T10-00-00_api.json"), []byte(`{"id": "bbbb11112222", "project_path": "/srv/api"}`), 0644)
+	if records, _ := ListRecords(Filter{}); len(records) != 1 || records[0].ID != "aaaa11112222" {
+		t.Errorf("records after reopening = %+v", records)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now()
+
+	for i := range 4 {
+		for _, p := range []string{"/srv/api", "/srv/shop"} {
+			if _, err := Save(testScan(p, now.AddDate(0, 0, -10*i), debugFinding), nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	n, err := Prune(config.StoreConfig{KeepDays: 25})
+	if err != nil || n != 2 {
+		t.Fatalf("Prune(keep_days) = %d, %v; want 2 deleted", n, err)
+	}
+	n, err = Prune(config.StoreConfig{KeepLast: 2})
+	if err != nil || n != 2 {
+		t.Fatalf("Prune(keep_last) = %d, %v; want 2 deleted", n, err)
+	}
+
+	for _, p := range []string{"/srv/api", "/srv/shop"} {
+		records, _ := ListRecords(Filter{ProjectID: p})
+		if len(records) != 2 || !records[0].Timestamp.Equal(now) {
+			t.Errorf("%s keeps %+v, want the 2 most recent scans", p, records)
+		}
+	}
+
+	db, err := open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var orphans int
+	db.QueryRow("SELECT COUNT(*) FROM findings WHERE scan_id NOT IN (SELECT id FROM scans)").Scan(&orphans)
+	if orphans != 0 {
+		t.Errorf("%d findings of deleted scans remain", orphans)
+	}
+}
+
+func TestSave_Concurrent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report := testScan(fmt.Sprintf("/srv/app%d", i), now, debugFinding, sqlFinding)
+			if _, err := Save(report, nil); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent Save() error: %v", err)
+	}
+
+	records, err := ListRecords(Filter{})
+	if err != nil || len(records) != 8 {
+		t.Fatalf("ListRecords() = %d records, %v; want 8", len(records), err)
+	}
+}
+
+func TestLifecycles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	day := 24 * time.Hour
+	start := time.Now().Add(-30 * day)
+
+	scans := []*models.ScanReport{
+		testScan("/srv/api", start, debugFinding, sqlFinding),
+		testScan("/srv/api", start.Add(2*day), debugFinding, sqlFinding),
+		testScan("/srv/api", start.Add(5*day), sqlFinding),               // debug resolved after 5d
+		testScan("/srv/api", start.Add(9*day), debugFinding, sqlFinding), // and back
+	}
+	// A scan that only ran the env scanner doesn't resolve the SQL finding.
+	envOnly := testScan("/srv/api", start.Add(10*day), debugFinding)
+	envOnly.ScannersRun = []string{"env-scanner"}
+	scans = append(scans, envOnly)
+	scans[0].ProjectContext.GitCommit = "3f2a1c9e8b7d"
+	for _, s := range scans {
+		if _, err := Save(s, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lifecycles, err := Lifecycles("/srv/api")
+	if err != nil {
+		t.Fatalf("Lifecycles() error: %v", err)
+	}
+	if len(lifecycles) != 3 {
+		t.Fatalf("got %d lifecycles, want 3: %+v", len(lifecycles), lifecycles)
+	}
+	sql, resolved, reopened := lifecycles[0], lifecycles[1], lifecycles[2]
+	if sql.Finding.ID != "INJECT-001" || !sql.Open() || !sql.FirstSeen.Equal(start) || sql.FirstCommit != "3f2a1c9e8b7d" {
+		t.Errorf("SQL finding lifecycle = %+v, want open since the first scan", sql)
+	}
+	if resolved.Open() || resolved.Age() != 5*day {
+		t.Errorf("resolved lifecycle = %+v, want resolved after 5 days", resolved)
+	}
+	if !reopened.Open() || !reopened.FirstSeen.Equal(start.Add(9*day)) || reopened.Age() != day {
+		t.Errorf("reopened lifecycle = %+v, want a new lifecycle from day 9", reopened)
+	}
+
+	report := testScan("/srv/api", start.Add(12*day), sqlFinding, models.Finding{ID: "ENV-001", Scanner: "env-scanner", File: ".env"})
+	if err := Annotate(report); err != nil {
+		t.Fatalf("Annotate() error: %v", err)
+	}
+	if l := report.Findings[0].Lifecycle; l == nil || !l.FirstSeen.Equal(start) || l.Age() != 12*day {
+		t.Errorf("annotated lifecycle = %+v, want open for 12 days", l)
+	}
+	if l := report.Findings[1].Lifecycle; l == nil || l.String() != "new in this scan" {
+		t.Errorf("new finding lifecycle = %+v", l)
+	}
+}
+
+func TestLifecycles_FilteredFindingsStayOpen(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	day := 24 * time.Hour
+	start := time.Now().Add(-10 * day)
+
+	if _, err := Save(testScan("/srv/api", start, debugFinding, sqlFinding), nil); err != nil {
+		t.Fatal(err)
+	}
+	// The debug finding is below this scan's threshold: the report leaves
+	// it out, but the scan still made it.
+	report := testScan("/srv/api", start.Add(day), sqlFinding)
+	if _, err := Save(report, []models.Finding{debugFinding, sqlFinding}); err != nil {
+		t.Fatal(err)
+	}
+
+	lifecycles, err := Lifecycles("/srv/api")
+	if err != nil {
+		t.Fatalf("Lifecycles() error: %v", err)
+	}
+	if len(lifecycles) != 2 {
+		t.Fatalf("got %d lifecycles, want 2: %+v", len(lifecycles), lifecycles)
+	}
+	for _, l := range lifecycles {
+		if !l.Open() || !l.FirstSeen.Equal(start) || l.Age() != day {
+			t.Errorf("lifecycle of %s = %+v, want open since the first scan", l.Finding.ID, l)
+		}
+	}
+}
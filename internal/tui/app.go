@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
@@ -53,6 +54,11 @@ type App struct {
 	// Sub-views
 	scanView    *views.ScanView
 	resultsView *views.ResultsView
+	historyView *views.HistoryView
+	compareView *views.CompareView
+	rulesView   *views.RulesView
+	triage      views.TriageOptions
+	rules       views.RulesOptions
 }
 
 // NewApp creates the root TUI model.
@@ -83,6 +89,17 @@ func NewApp(bus *eventbus.EventBus, targetPath string, version string) *App {
 	}
 }
 
+// SetTriage configures where the results view saves triage and how it
+// opens files.
+func (a *App) SetTriage(opts views.TriageOptions) {
+	a.triage = opts
+}
+
+// SetRules configures where the rules view saves overrides.
+func (a *App) SetRules(opts views.RulesOptions) {
+	a.rules = opts
+}
+
 // Init returns the initial commands.
 func (a *App) Init() tea.Cmd {
 	return tea.Batch(
@@ -104,6 +121,11 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a, nil
 
 	case tea.KeyMsg:
+		// An open search input or reason prompt takes every key but ctrl+c.
+		if a.activeView == ViewResults && a.resultsView != nil && a.resultsView.Capturing() && msg.String() != "ctrl+c" {
+			return a, a.resultsView.HandleKey(msg)
+		}
+
 		switch {
 		case key.Matches(msg, a.keys.Quit):
 			return a, tea.Quit
@@ -113,17 +135,43 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, a.keys.Tab):
 			if a.scanComplete && a.activeView == ViewScan {
 				a.activeView = ViewResults
+				a.propagateSize()
 				return a, nil
-			} else if a.activeView == ViewResults {
-				// Delegate tab to results view for panel switching
-				cmd := a.resultsView.HandleKey(msg)
+			} else if a.activeView == ViewResults || a.activeView == ViewCompare || a.activeView == ViewRules {
+				// Delegate tab to the view for panel switching
+				cmd := a.delegateKeyToView(msg)
 				return a, cmd
 			}
 			return a, nil
 		case key.Matches(msg, a.keys.Escape):
-			if a.activeView == ViewResults {
+			switch a.activeView {
+			case ViewResults:
 				a.activeView = ViewScan
-				return a, nil
+			case ViewHistory, ViewRules:
+				a.activeView = ViewResults
+			case ViewCompare:
+				a.activeView = ViewHistory
+			}
+			a.propagateSize()
+			return a, nil
+		case key.Matches(msg, a.keys.History) && a.report != nil &&
+			(a.activeView == ViewScan || a.activeView == ViewResults):
+			a.activeView = ViewHistory
+			a.propagateSize()
+			if a.historyView == nil {
+				a.historyView = views.NewHistoryView(a.theme, a.report)
+				a.propagateSize()
+				return a, a.historyView.Load()
+			}
+			return a, nil
+		case key.Matches(msg, a.keys.Rules) && a.report != nil &&
+			(a.activeView == ViewScan || a.activeView == ViewResults):
+			a.activeView = ViewRules
+			a.propagateSize()
+			if a.rulesView == nil {
+				a.rulesView = views.NewRulesView(a.theme, a.report, a.rules)
+				a.propagateSize()
+				return a, a.rulesView.Load()
 			}
 			return a, nil
 		}
@@ -149,8 +197,33 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.spinner, cmd = a.spinner.Update(msg)
 		cmds = append(cmds, cmd)
 
+	case views.HistoryLoadedMsg:
+		if a.historyView != nil {
+			a.historyView.SetRecords(msg)
+		}
+
+	case views.RulesLoadedMsg:
+		if a.rulesView != nil {
+			a.rulesView.SetRules(msg)
+		}
+
+	case views.CompareReadyMsg:
+		if msg.Err != nil {
+			a.historyView.SetStatus(fmt.Sprintf("Reading scan %s: %v", msg.Record.ID, msg.Err))
+			break
+		}
+		a.compareView = views.NewCompareView(a.theme, a.report, msg.Record, msg.Findings)
+		a.activeView = ViewCompare
+		a.propagateSize()
+
+	case views.StatusMsg:
+		if a.resultsView != nil {
+			a.resultsView.SetStatus(msg)
+		}
+
 	case switchViewMsg:
 		a.activeView = msg.view
+		a.propagateSize() // the footer differs between views
 	}
 
 	return a, tea.Batch(cmds...)
@@ -182,6 +255,12 @@ func (a *App) View() string {
 		} else {
 			content = a.theme.Muted.Render("\n  No results available yet.")
 		}
+	case ViewHistory:
+		content = a.historyView.View(a.width, contentH)
+	case ViewCompare:
+		content = a.compareView.View(a.width, contentH)
+	case ViewRules:
+		content = a.rulesView.View(a.width, contentH)
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
@@ -255,7 +334,7 @@ func (a *App) handleBusEvent(event eventbus.Event) tea.Cmd {
 		a.scanRunning = false
 		a.scanComplete = true
 		a.scanView.SetScanComplete(true)
-		a.resultsView = views.NewResultsView(a.theme, a.report)
+		a.resultsView = views.NewResultsView(a.theme, a.report, a.triage)
 		a.propagateSize()
 		return switchViewCmd(ViewResults)
 
@@ -298,6 +377,15 @@ func (a *App) propagateSize() {
 	if a.resultsView != nil {
 		a.resultsView.SetSize(a.width, contentH)
 	}
+	if a.historyView != nil {
+		a.historyView.SetSize(a.width, contentH)
+	}
+	if a.compareView != nil {
+		a.compareView.SetSize(a.width, contentH)
+	}
+	if a.rulesView != nil {
+		a.rulesView.SetSize(a.width, contentH)
+	}
 	a.help.Width = a.width
 }
 
@@ -310,6 +398,12 @@ func (a *App) delegateKeyToView(msg tea.KeyMsg) tea.Cmd {
 		if a.resultsView != nil {
 			return a.resultsView.HandleKey(msg)
 		}
+	case ViewHistory:
+		return a.historyView.HandleKey(msg)
+	case ViewCompare:
+		return a.compareView.HandleKey(msg)
+	case ViewRules:
+		return a.rulesView.HandleKey(msg)
 	}
 	return nil
 }
@@ -332,7 +426,11 @@ func (a *App) renderHeader() string {
 }
 
 func (a *App) renderFooter() string {
-	return components.RenderFooter(a.help, a.keys, a.theme, a.width)
+	footer := components.RenderFooter(a.help, a.keys, a.theme, a.width)
+	if a.activeView == ViewResults && a.resultsView != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, a.resultsView.FilterBar(a.width), footer)
+	}
+	return footer
 }
 
 func tickCmd() tea.Cmd {
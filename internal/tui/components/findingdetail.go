@@ -15,6 +15,8 @@ import (
 type FindingDetail struct {
 	viewport viewport.Model
 	finding  *models.Finding
+	location int // 0 shows the finding's own line, i > 0 its Related[i-1]
+	codeLine int // first content line of the Code section
 	theme    *theme.Theme
 	width    int
 	height   int
@@ -26,12 +28,40 @@ func NewFindingDetail(t *theme.Theme) *FindingDetail {
 	return &FindingDetail{viewport: vp, theme: t}
 }
 
-// SetFinding sets the finding to display.
+// SetFinding sets the finding to display. Setting the finding already
+// shown keeps the location stepped to.
 func (d *FindingDetail) SetFinding(f *models.Finding) {
+	if f != d.finding {
+		d.location = 0
+	}
 	d.finding = f
 	d.rebuildContent()
 }
 
+// StepLocation moves the source view by delta through the finding's line
+// and its related locations, wrapping around at either end.
+func (d *FindingDetail) StepLocation(delta int) {
+	if d.finding == nil || len(d.finding.Related) == 0 {
+		return
+	}
+	n := len(d.finding.Related) + 1
+	d.location = ((d.location+delta)%n + n) % n
+	d.rebuildContent()
+	d.viewport.SetYOffset(d.codeLine)
+}
+
+// Location returns the file and line the source view shows.
+func (d *FindingDetail) Location() (string, int) {
+	if d.finding == nil {
+		return "", 0
+	}
+	if d.location > 0 && d.location <= len(d.finding.Related) {
+		r := d.finding.Related[d.location-1]
+		return r.File, r.Line
+	}
+	return d.finding.File, d.finding.Line
+}
+
 // SetSize updates the panel dimensions.
 func (d *FindingDetail) SetSize(w, h int) {
 	d.width = w
@@ -80,21 +110,66 @@ func (d *FindingDetail) rebuildContent() {
 		)
 	}
 
-	// Location
+	// Location, and the locations related to it
 	if f.File != "" {
 		location := fmt.Sprintf("  %s:%d", f.File, f.Line)
+		if len(f.Related) > 0 && d.location == 0 {
+			location = fmt.Sprintf("  ▶ %s:%d", f.File, f.Line)
+		}
 		sections = append(sections,
 			d.theme.Subtitle.Render("  Location"),
 			d.theme.AccentStyle.Render(location),
+		)
+		if len(f.Related) > 0 {
+			sections = append(sections, "", d.theme.Subtitle.Render("  Related")+
+				d.theme.Muted.Render("  [ ] step through"))
+			for i, r := range f.Related {
+				line := fmt.Sprintf("%s:%d", r.File, r.Line)
+				if r.Note != "" {
+					line += "  (" + r.Note + ")"
+				}
+				if i+1 == d.location {
+					sections = append(sections, d.theme.AccentStyle.Render("  ▶ "+line))
+				} else {
+					sections = append(sections, "    "+line)
+				}
+			}
+		}
+		sections = append(sections, "")
+	}
+
+	// Age across stored scans
+	if f.Lifecycle != nil {
+		sections = append(sections,
+			d.theme.Subtitle.Render("  Age"),
+			"  "+f.Lifecycle.String(),
 			"",
 		)
 	}
 
-	// Code snippet
-	if f.CodeSnippet != "" {
-		codeBlock := d.theme.Code.Width(contentWidth - 2).Render(f.CodeSnippet)
+	// Source around the current location, or the bare snippet
+	file, line, snippet, ctx := f.File, f.Line, f.CodeSnippet, f.Context
+	title := "  Code"
+	if len(f.Related) > 0 {
+		if d.location > 0 && d.location <= len(f.Related) {
+			r := f.Related[d.location-1]
+			file, line, snippet, ctx = r.File, r.Line, r.Snippet, r.Context
+			title = fmt.Sprintf("  Code · %s:%d", r.File, r.Line)
+		}
+		title += fmt.Sprintf(" (%d/%d)", d.location+1, len(f.Related)+1)
+	}
+	d.codeLine = lipgloss.Height(strings.Join(sections, "\n"))
+	switch {
+	case ctx != nil:
+		sections = append(sections,
+			d.theme.Subtitle.Render(title),
+			indent(renderSource(d.theme, file, line, ctx, contentWidth-2), "  "),
+			"",
+		)
+	case snippet != "":
+		codeBlock := d.theme.Code.Width(contentWidth - 2).Render(snippet)
 		sections = append(sections,
-			d.theme.Subtitle.Render("  Code"),
+			d.theme.Subtitle.Render(title),
 			"  "+codeBlock,
 			"",
 		)
@@ -109,6 +184,17 @@ func (d *FindingDetail) rebuildContent() {
 		)
 	}
 
+	// Suggested fix
+	if f.Fix != nil {
+		sections = append(sections,
+			d.theme.Subtitle.Render("  Suggested Fix"),
+			wordWrap(f.Fix.Description, contentWidth),
+			"  "+d.theme.Code.Width(contentWidth-2).Render("- "+f.Fix.Original+"\n+ "+f.Fix.Replacement),
+			d.theme.Muted.Render("  Apply with: ward fix --apply"),
+			"",
+		)
+	}
+
 	// References
 	if len(f.References) > 0 {
 		sections = append(sections, d.theme.Subtitle.Render("  References"))
@@ -135,6 +221,10 @@ func (d *FindingDetail) View() string {
 	return border.Render(content)
 }
 
+func indent(text, prefix string) string {
+	return prefix + strings.ReplaceAll(text, "\n", "\n"+prefix)
+}
+
 func wordWrap(text string, width int) string {
 	if width <= 0 {
 		return text
@@ -1,6 +1,9 @@
 package components
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/eljakani/ward/internal/tui/theme"
@@ -12,6 +15,32 @@ func RenderFooter(helpModel help.Model, keys help.KeyMap, t *theme.Theme, width
 	return t.FooterBar.Width(width).Render(helpView)
 }
 
+// RenderFilterBar renders the results filter line: the open text input,
+// a chip per active filter, how many findings pass, and the outcome of
+// the last action.
+func RenderFilterBar(t *theme.Theme, input string, chips []string, shown, total int, notice string, width int) string {
+	var parts []string
+	if input != "" {
+		parts = append(parts, input)
+	}
+	for _, c := range chips {
+		parts = append(parts, t.Chip.Render(c))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, t.Muted.Render("No filters"))
+	}
+
+	count := fmt.Sprintf("%d findings", total)
+	if shown != total {
+		count = fmt.Sprintf("%d of %d findings", shown, total)
+	}
+	parts = append(parts, t.Muted.Render("· "+count))
+	if notice != "" {
+		parts = append(parts, t.AccentStyle.Render("· "+notice))
+	}
+	return t.FooterBar.Width(width).Render(strings.Join(parts, " "))
+}
+
 // RenderSeparator renders a horizontal line separator.
 func RenderSeparator(t *theme.Theme, width int) string {
 	line := lipgloss.NewStyle().
@@ -0,0 +1,326 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/snippet"
+	"github.com/eljakani/ward/internal/tui/theme"
+)
+
+// tokenKind classifies a run of source text for highlighting.
+type tokenKind int
+
+const (
+	tokenPlain tokenKind = iota
+	tokenKeyword
+	tokenString
+	tokenVariable
+	tokenComment
+	tokenDirective
+)
+
+type token struct {
+	text string
+	kind tokenKind
+}
+
+var phpKeywords = map[string]bool{}
+
+func init() {
+	for _, k := range strings.Fields(`abstract and array as break callable case catch class clone const
+		continue declare default do echo else elseif empty enddeclare endfor endforeach endif
+		endswitch endwhile enum eval exit extends false final finally fn for foreach function
+		global goto if implements include include_once instanceof insteadof interface isset
+		list match namespace new null or parent print private protected public readonly require
+		require_once return self static switch throw trait true try unset use var while xor yield`) {
+		phpKeywords[k] = true
+	}
+}
+
+// highlighter splits consecutive lines of PHP or Blade into tokens. It is
+// line-oriented and only carries the state that commonly spans lines:
+// block comments and @php blocks. A window that starts inside such a
+// construct is highlighted as if it didn't.
+type highlighter struct {
+	lang         string // "php", "blade", or "" for plain text
+	inComment    bool   // inside /* ... */
+	inBladeNote  bool   // inside {{-- ... --}}
+	inBladeBlock bool   // between @php and @endphp
+}
+
+func (h *highlighter) line(s string) []token {
+	switch h.lang {
+	case "php":
+		return h.php(s)
+	case "blade":
+		return h.blade(s)
+	}
+	return []token{{text: s}}
+}
+
+// php tokenizes PHP code.
+func (h *highlighter) php(s string) []token {
+	var out []token
+	emit := func(text string, kind tokenKind) {
+		if text == "" {
+			return
+		}
+		if n := len(out); n > 0 && out[n-1].kind == kind {
+			out[n-1].text += text
+			return
+		}
+		out = append(out, token{text: text, kind: kind})
+	}
+
+	for i := 0; i < len(s); {
+		if h.inComment {
+			end := strings.Index(s[i:], "*/")
+			if end < 0 {
+				emit(s[i:], tokenComment)
+				return out
+			}
+			emit(s[i:i+end+2], tokenComment)
+			i += end + 2
+			h.inComment = false
+			continue
+		}
+
+		rest := s[i:]
+		switch c := s[i]; {
+		case strings.HasPrefix(rest, "/*"):
+			h.inComment = true
+			emit("/*", tokenComment)
+			i += 2
+		case strings.HasPrefix(rest, "//"), c == '#' && !strings.HasPrefix(rest, "#["):
+			emit(rest, tokenComment)
+			return out
+		case c == '\'' || c == '"' || c == '`':
+			end := closingQuote(s, i)
+			emit(s[i:end], tokenString)
+			i = end
+		case c == '$' && i+1 < len(s) && isIdentStart(s[i+1]):
+			end := identEnd(s, i+1)
+			emit(s[i:end], tokenVariable)
+			i = end
+		case isIdentStart(c):
+			end := identEnd(s, i)
+			word := s[i:end]
+			if phpKeywords[strings.ToLower(word)] && (i == 0 || s[i-1] != '>' && s[i-1] != ':') {
+				emit(word, tokenKeyword)
+			} else {
+				emit(word, tokenPlain)
+			}
+			i = end
+		default:
+			emit(s[i:i+1], tokenPlain)
+			i++
+		}
+	}
+	return out
+}
+
+// blade tokenizes a Blade template: directives, echo tags and comments,
+// with the PHP inside them tokenized as PHP. Markup is left plain.
+func (h *highlighter) blade(s string) []token {
+	var out []token
+	plain := func(text string) {
+		if text == "" {
+			return
+		}
+		if n := len(out); n > 0 && out[n-1].kind == tokenPlain {
+			out[n-1].text += text
+			return
+		}
+		out = append(out, token{text: text})
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case h.inBladeNote:
+			end := strings.Index(rest, "--}}")
+			if end < 0 {
+				return append(out, token{text: rest, kind: tokenComment})
+			}
+			out = append(out, token{text: rest[:end+4], kind: tokenComment})
+			h.inBladeNote = false
+			i += end + 4
+		case h.inBladeBlock:
+			end := strings.Index(rest, "@endphp")
+			if end < 0 {
+				return append(out, h.php(rest)...)
+			}
+			out = append(out, h.php(rest[:end])...)
+			out = append(out, token{text: "@endphp", kind: tokenDirective})
+			h.inBladeBlock = false
+			i += end + len("@endphp")
+		case strings.HasPrefix(rest, "{{--"):
+			h.inBladeNote = true
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest, "-->")
+			if end < 0 {
+				return append(out, token{text: rest, kind: tokenComment})
+			}
+			out = append(out, token{text: rest[:end+3], kind: tokenComment})
+			i += end + 3
+		case strings.HasPrefix(rest, "{{"), strings.HasPrefix(rest, "{!!"):
+			open, closing := "{{", "}}"
+			if strings.HasPrefix(rest, "{!!") {
+				open, closing = "{!!", "!!}"
+			}
+			out = append(out, token{text: open, kind: tokenDirective})
+			i += len(open)
+			end := strings.Index(s[i:], closing)
+			if end < 0 {
+				return append(out, h.php(s[i:])...)
+			}
+			out = append(out, h.php(s[i:i+end])...)
+			out = append(out, token{text: closing, kind: tokenDirective})
+			i += end + len(closing)
+		case s[i] == '@' && i+1 < len(s) && isIdentStart(s[i+1]) && (i == 0 || !isIdentChar(s[i-1])):
+			end := identEnd(s, i+1)
+			out = append(out, token{text: s[i:end], kind: tokenDirective})
+			if s[i+1:end] == "php" && !strings.HasPrefix(strings.TrimLeft(s[end:], " "), "(") {
+				h.inBladeBlock = true
+			}
+			i = end
+			// Directive arguments are PHP.
+			if args := strings.TrimLeft(s[i:], " "); strings.HasPrefix(args, "(") {
+				open := i + len(s[i:]) - len(args)
+				end := closingParen(s, open)
+				plain(s[i:open])
+				out = append(out, h.php(s[open:end])...)
+				i = end
+			}
+		default:
+			plain(s[i : i+1])
+			i++
+		}
+	}
+	return out
+}
+
+// closingQuote returns the index just past the string literal starting at
+// s[start], or len(s) when it continues on the next line.
+func closingQuote(s string, start int) int {
+	q := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case q:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// closingParen returns the index just past the parenthesis matching the
+// one at s[open], or len(s) when it isn't closed on this line.
+func closingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"':
+			i = closingQuote(s, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+func identEnd(s string, start int) int {
+	i := start
+	for i < len(s) && isIdentChar(s[i]) {
+		i++
+	}
+	return i
+}
+
+func tokenStyle(t *theme.Theme, kind tokenKind) lipgloss.Style {
+	switch kind {
+	case tokenKeyword:
+		return t.Keyword
+	case tokenString:
+		return t.String
+	case tokenVariable:
+		return t.Variable
+	case tokenComment:
+		return t.Comment
+	case tokenDirective:
+		return t.Directive
+	}
+	return lipgloss.NewStyle().Foreground(t.Colors.Text)
+}
+
+// renderSource renders a context window of file with line numbers and
+// syntax highlighting, marking line. Lines are cut to width.
+func renderSource(t *theme.Theme, file string, line int, ctx *models.SourceContext, width int) string {
+	h := &highlighter{lang: snippet.Language(file)}
+	numWidth := len(strconv.Itoa(ctx.EndLine()))
+	gutterWidth := numWidth + 5 // "▶ " + number + " │ "
+
+	rows := make([]string, len(ctx.Lines))
+	for i, text := range ctx.Lines {
+		n := ctx.StartLine + i
+		base := lipgloss.NewStyle()
+		marker := "  "
+		if n == line {
+			base = t.MatchLine
+			marker = "▶ "
+		}
+
+		var sb strings.Builder
+		sb.WriteString(t.LineNumber.Inherit(base).Render(fmt.Sprintf("%s%*d │ ", marker, numWidth, n)))
+		room := width - gutterWidth
+		for _, tok := range h.line(text) {
+			if room <= 0 {
+				break
+			}
+			text := clipWidth(tok.text, room)
+			room -= lipgloss.Width(text)
+			sb.WriteString(tokenStyle(t, tok.kind).Inherit(base).Render(text))
+		}
+		if n == line && room > 0 {
+			sb.WriteString(base.Render(strings.Repeat(" ", room)))
+		}
+		rows[i] = sb.String()
+	}
+	return strings.Join(rows, "\n")
+}
+
+// clipWidth cuts s to at most width cells.
+func clipWidth(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	var sb strings.Builder
+	used := 0
+	for _, r := range s {
+		w := lipgloss.Width(string(r))
+		if used+w > width {
+			break
+		}
+		sb.WriteRune(r)
+		used += w
+	}
+	return sb.String()
+}
@@ -0,0 +1,77 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/tui/theme"
+)
+
+// kinds renders tokens as "kind:text" pairs, skipping plain text.
+func kinds(tokens []token) string {
+	names := map[tokenKind]string{tokenKeyword: "kw", tokenString: "str", tokenVariable: "var", tokenComment: "cmt", tokenDirective: "dir"}
+	var out []string
+	for _, t := range tokens {
+		if t.kind != tokenPlain {
+			out = append(out, names[t.kind]+":"+t.text)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+func TestHighlighter_PHP(t *testing.T) {
+	h := &highlighter{lang: "php"}
+	tests := []struct {
+		line, want string
+	}{
+		{`return DB::select("SELECT $id"); // raw`, `kw:return str:"SELECT $id" cmt:// raw`},
+		{`$this->new = 'it\'s';`, `var:$this str:'it\'s'`},
+		{`/* one`, `cmt:/* one`},
+		{`two */ if ($a) {`, `cmt:two */ kw:if var:$a`},
+		{`#[Attribute] # note`, `cmt:# note`},
+	}
+	for _, tt := range tests {
+		if got := kinds(h.line(tt.line)); got != tt.want {
+			t.Errorf("%s\n got %s\nwant %s", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestHighlighter_Blade(t *testing.T) {
+	h := &highlighter{lang: "blade"}
+	tests := []struct {
+		line, want string
+	}{
+		{`@if ($user->isAdmin())`, `dir:@if var:$user`},
+		{`<p>{!! $bio !!}</p> {{-- note`, `dir:{!! var:$bio dir:!!} cmt:{{-- note`},
+		{`--}} mail@example.com`, `cmt:--}}`},
+		{`@php`, `dir:@php`},
+		{`$x = 'y';`, `var:$x str:'y'`},
+		{`@endphp {{ $x }} don't`, `dir:@endphp dir:{{ var:$x dir:}}`},
+	}
+	for _, tt := range tests {
+		if got := kinds(h.line(tt.line)); got != tt.want {
+			t.Errorf("%s\n got %s\nwant %s", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSource(t *testing.T) {
+	ctx := &models.SourceContext{StartLine: 9, Lines: []string{"{", "    eval($code);", "}"}}
+	out := renderSource(theme.DefaultTheme(), "app/A.php", 10, ctx, 12)
+
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("rendered %d lines, want 3", len(lines))
+	}
+	if !strings.Contains(lines[1], "▶ 10 │") || !strings.Contains(lines[0], "   9 │") {
+		t.Errorf("gutter should number lines and mark the match:\n%s", out)
+	}
+	for _, l := range lines {
+		if w := lipgloss.Width(l); w > 12 {
+			t.Errorf("line %q is %d cells, want at most 12", l, w)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package components
+
+import "strings"
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws one block per value, scaled to the largest value.
+func Sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		i := 0
+		if peak > 0 {
+			i = v * (len(sparkBlocks) - 1) / peak
+		}
+		sb.WriteRune(sparkBlocks[i])
+	}
+	return sb.String()
+}
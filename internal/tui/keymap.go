@@ -12,8 +12,35 @@ type KeyMap struct {
 	Tab          key.Binding
 	Escape       key.Binding
 	SortFindings key.Binding
+	History      key.Binding
+	Rules        key.Binding
 	ScrollUp     key.Binding
 	ScrollDown   key.Binding
+	PrevLocation key.Binding
+	NextLocation key.Binding
+
+	// Results filtering and grouping
+	Search         key.Binding
+	FilterSeverity key.Binding
+	FilterScanner  key.Binding
+	FilterCategory key.Binding
+	ClearFilters   key.Binding
+	Group          key.Binding
+	Fold           key.Binding
+	FoldAll        key.Binding
+
+	// Triage
+	FalsePositive key.Binding
+	AcceptRisk    key.Binding
+	ToFix         key.Binding
+	OpenEditor    key.Binding
+	CopyMarkdown  key.Binding
+
+	// Rules
+	ToggleRule    key.Binding
+	RaiseSeverity key.Binding
+	LowerSeverity key.Binding
+	ConfigTarget  key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -51,6 +78,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "sort"),
 		),
+		History: key.NewBinding(
+			key.WithKeys("h"),
+			key.WithHelp("h", "history"),
+		),
+		Rules: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "rules"),
+		),
 		ScrollUp: key.NewBinding(
 			key.WithKeys("pgup"),
 			key.WithHelp("pgup", "scroll up"),
@@ -59,19 +94,100 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("pgdown"),
 			key.WithHelp("pgdown", "scroll down"),
 		),
+		PrevLocation: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev location"),
+		),
+		NextLocation: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next location"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		FilterSeverity: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5"),
+			key.WithHelp("1-5", "toggle severity"),
+		),
+		FilterScanner: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "cycle scanner"),
+		),
+		FilterCategory: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "cycle category"),
+		),
+		ClearFilters: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "clear filters"),
+		),
+		Group: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "group by"),
+		),
+		Fold: key.NewBinding(
+			key.WithKeys("enter", " "),
+			key.WithHelp("enter/space", "fold group"),
+		),
+		FoldAll: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "fold all"),
+		),
+		FalsePositive: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "false positive"),
+		),
+		AcceptRisk: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "accept risk"),
+		),
+		ToFix: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "to fix"),
+		),
+		OpenEditor: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in editor"),
+		),
+		CopyMarkdown: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy markdown"),
+		),
+		ToggleRule: key.NewBinding(
+			key.WithKeys("e", " "),
+			key.WithHelp("e/space", "enable/disable rule"),
+		),
+		RaiseSeverity: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "raise severity"),
+		),
+		LowerSeverity: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "lower severity"),
+		),
+		ConfigTarget: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "project/user config"),
+		),
 	}
 }
 
 // ShortHelp returns bindings for the compact help view.
 func (k KeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Help, k.Quit, k.Tab, k.Up, k.Down}
+	return []key.Binding{k.Help, k.Quit, k.Tab, k.Up, k.Down, k.Search}
 }
 
 // FullHelp returns all bindings for the expanded help view.
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Enter, k.Escape},
-		{k.Tab, k.SortFindings, k.ScrollUp, k.ScrollDown},
+		{k.Tab, k.SortFindings, k.History, k.Rules, k.ScrollUp, k.ScrollDown},
+		{k.PrevLocation, k.NextLocation},
+		{k.Search, k.FilterSeverity, k.FilterScanner, k.FilterCategory, k.ClearFilters},
+		{k.Group, k.Fold, k.FoldAll},
+		{k.FalsePositive, k.AcceptRisk, k.ToFix, k.OpenEditor, k.CopyMarkdown},
+		{k.ToggleRule, k.RaiseSeverity, k.LowerSeverity, k.ConfigTarget},
 		{k.Help, k.Quit},
 	}
 }
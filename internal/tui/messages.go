@@ -8,6 +8,9 @@ type ViewID int
 const (
 	ViewScan    ViewID = iota
 	ViewResults
+	ViewHistory
+	ViewCompare
+	ViewRules
 )
 
 // switchViewMsg requests a view change.
@@ -80,6 +80,9 @@ type Theme struct {
 	TableRowAlt   lipgloss.Style
 	TableSelected lipgloss.Style
 
+	// Filters
+	Chip lipgloss.Style
+
 	// Text
 	Title    lipgloss.Style
 	Subtitle lipgloss.Style
@@ -87,6 +90,15 @@ type Theme struct {
 	Bold     lipgloss.Style
 	Code     lipgloss.Style
 
+	// Source context
+	LineNumber lipgloss.Style
+	MatchLine  lipgloss.Style // background of the finding's line
+	Keyword    lipgloss.Style
+	String     lipgloss.Style
+	Variable   lipgloss.Style
+	Comment    lipgloss.Style
+	Directive  lipgloss.Style // Blade directives and echo tags
+
 	// Accents
 	AccentStyle  lipgloss.Style
 	SuccessStyle lipgloss.Style
@@ -172,6 +184,11 @@ func DefaultTheme() *Theme {
 			Bold(true).
 			Background(lipgloss.AdaptiveColor{Light: "#EDE7F6", Dark: "#2A2040"}),
 
+		Chip: lipgloss.NewStyle().
+			Foreground(c.Primary).
+			Background(lipgloss.AdaptiveColor{Light: "#EDE7F6", Dark: "#2A2040"}).
+			Padding(0, 1),
+
 		Title: lipgloss.NewStyle().
 			Foreground(c.Text).
 			Bold(true),
@@ -191,6 +208,29 @@ func DefaultTheme() *Theme {
 			Foreground(lipgloss.AdaptiveColor{Light: "#D32F2F", Dark: "#FF8A80"}).
 			Padding(0, 1),
 
+		LineNumber: lipgloss.NewStyle().
+			Foreground(c.TextDim),
+
+		MatchLine: lipgloss.NewStyle().
+			Background(lipgloss.AdaptiveColor{Light: "#FFEBEE", Dark: "#3A2030"}),
+
+		Keyword: lipgloss.NewStyle().
+			Foreground(c.Primary).
+			Bold(true),
+
+		String: lipgloss.NewStyle().
+			Foreground(c.Low),
+
+		Variable: lipgloss.NewStyle().
+			Foreground(c.Accent),
+
+		Comment: lipgloss.NewStyle().
+			Foreground(c.TextDim).
+			Italic(true),
+
+		Directive: lipgloss.NewStyle().
+			Foreground(c.High),
+
 		AccentStyle: lipgloss.NewStyle().
 			Foreground(c.Accent),
 
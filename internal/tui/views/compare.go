@@ -0,0 +1,210 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/store"
+	"github.com/eljakani/ward/internal/tui/components"
+	"github.com/eljakani/ward/internal/tui/theme"
+)
+
+// compareRow is a finding the current scan added or resolved.
+type compareRow struct {
+	finding models.Finding
+	added   bool
+}
+
+// CompareView lists the findings the current scan added and resolved
+// since an earlier stored scan.
+type CompareView struct {
+	theme  *theme.Theme
+	report *models.ScanReport // the scan just run
+	before store.ScanRecord
+
+	rows      []compareRow // added, then resolved, each most severe first
+	unchanged int
+
+	table  table.Model
+	detail *components.FindingDetail
+
+	// Layout
+	width      int
+	height     int
+	focusPanel int // 0 = table, 1 = detail
+
+	// Key bindings (local)
+	tabKey     key.Binding
+	prevLocKey key.Binding
+	nextLocKey key.Binding
+}
+
+// NewCompareView compares report with an earlier scan and its findings.
+func NewCompareView(t *theme.Theme, report *models.ScanReport, before store.ScanRecord, previous []models.Finding) *CompareView {
+	v := &CompareView{
+		theme:  t,
+		report: report,
+		before: before,
+		detail: components.NewFindingDetail(t),
+		tabKey: key.NewBinding(
+			key.WithKeys("tab"),
+		),
+		prevLocKey: key.NewBinding(
+			key.WithKeys("["),
+		),
+		nextLocKey: key.NewBinding(
+			key.WithKeys("]"),
+		),
+	}
+
+	added, resolved, unchanged := models.CompareFindings(previous, report.Findings)
+	for _, f := range added {
+		v.rows = append(v.rows, compareRow{finding: f, added: true})
+	}
+	for _, f := range resolved {
+		v.rows = append(v.rows, compareRow{finding: f})
+	}
+	v.unchanged = len(unchanged)
+
+	columns := []table.Column{
+		{Title: "Change", Width: 10},
+		{Title: "Sev", Width: 9},
+		{Title: "Title", Width: 24},
+		{Title: "File", Width: 18},
+	}
+	rows := make([]table.Row, len(v.rows))
+	for i, r := range v.rows {
+		change := "− resolved"
+		if r.added {
+			change = "+ new"
+		}
+		rows[i] = table.Row{
+			change,
+			r.finding.Severity.String(),
+			truncate(r.finding.Title, 22),
+			truncate(fmt.Sprintf("%s:%d", r.finding.File, r.finding.Line), 16),
+		}
+	}
+	tbl := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+	s := table.DefaultStyles()
+	s.Header = t.TableHeader
+	s.Selected = t.TableSelected
+	tbl.SetStyles(s)
+	v.table = tbl
+
+	v.syncDetail()
+	return v
+}
+
+// SetSize updates dimensions and propagates to sub-components.
+func (v *CompareView) SetSize(w, h int) {
+	v.width = w
+	v.height = h
+
+	// Layout overhead:
+	//   title:            1 line
+	//   summary:          1 line
+	//   separator:        1 line
+	//   total overhead:   3 lines
+	bodyH := h - 3
+	if bodyH < 6 {
+		bodyH = 6
+	}
+
+	tableW := int(float64(w) * 0.50)
+	detailW := w - tableW - 3
+
+	v.table.SetWidth(tableW)
+	v.table.SetHeight(bodyH - 2)
+	v.detail.SetSize(detailW, bodyH)
+}
+
+// HandleKey routes key events for table navigation and detail scrolling.
+func (v *CompareView) HandleKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, v.tabKey):
+		v.focusPanel = (v.focusPanel + 1) % 2
+		v.table.Focus()
+		if v.focusPanel == 1 {
+			v.table.Blur()
+		}
+		return nil
+	case key.Matches(msg, v.prevLocKey):
+		v.detail.StepLocation(-1)
+		return nil
+	case key.Matches(msg, v.nextLocKey):
+		v.detail.StepLocation(1)
+		return nil
+	}
+
+	if v.focusPanel == 0 {
+		var cmd tea.Cmd
+		v.table, cmd = v.table.Update(msg)
+		v.syncDetail()
+		return cmd
+	}
+
+	v.detail.HandleKey(msg)
+	return nil
+}
+
+func (v *CompareView) syncDetail() {
+	idx := v.table.Cursor()
+	if idx >= 0 && idx < len(v.rows) {
+		v.detail.SetFinding(&v.rows[idx].finding)
+		return
+	}
+	v.detail.SetFinding(nil)
+}
+
+// View renders the comparison.
+func (v *CompareView) View(width, height int) string {
+	if width == 0 || height == 0 {
+		return ""
+	}
+
+	title := v.theme.Title.Render(fmt.Sprintf("  Compared with scan %s  —  %s",
+		v.before.ID, v.before.Timestamp.Local().Format("2006-01-02 15:04")))
+
+	var added int
+	for _, r := range v.rows {
+		if r.added {
+			added++
+		}
+	}
+	summary := fmt.Sprintf("%s   %s   %s   %s",
+		v.theme.SeverityStyles[models.SeverityCritical].Render(fmt.Sprintf(" +%d new ", added)),
+		v.theme.SeverityStyles[models.SeverityLow].Render(fmt.Sprintf(" −%d resolved ", len(v.rows)-added)),
+		v.theme.Muted.Render(fmt.Sprintf("%d unchanged", v.unchanged)),
+		v.theme.Muted.Render(fmt.Sprintf("(%d → %d findings)", v.before.FindingCount, len(v.report.Findings))),
+	)
+
+	var body string
+	if len(v.rows) == 0 {
+		body = v.theme.Muted.Render("\n  No findings were added or resolved since this scan.")
+	} else {
+		border := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(v.theme.Colors.Border)
+		if v.focusPanel == 0 {
+			border = border.BorderForeground(v.theme.Colors.Primary)
+		}
+		body = lipgloss.JoinHorizontal(lipgloss.Top, border.Render(v.table.View()), " ", v.detail.View())
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.PlaceHorizontal(width, lipgloss.Center, title),
+		lipgloss.PlaceHorizontal(width, lipgloss.Center, summary),
+		components.RenderSeparator(v.theme, width),
+		body,
+	)
+}
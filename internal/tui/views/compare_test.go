@@ -0,0 +1,34 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/store"
+	"github.com/eljakani/ward/internal/tui/theme"
+)
+
+func TestNewCompareView(t *testing.T) {
+	resolved := models.Finding{ID: "AUTH-001", Title: "Route without auth", Severity: models.SeverityHigh, File: "routes/web.php", Line: 10}
+	previous := []models.Finding{testFindings[0], testFindings[3], resolved}
+	report := &models.ScanReport{Findings: testFindings}
+
+	v := NewCompareView(theme.DefaultTheme(), report, store.ScanRecord{ID: "abc"}, previous)
+
+	var added []string
+	for _, r := range v.rows[:len(v.rows)-1] {
+		if !r.added {
+			t.Fatalf("expected added findings before resolved ones, got %s resolved", r.finding.ID)
+		}
+		added = append(added, r.finding.File)
+	}
+	if len(added) != 2 || added[0] != "app/Http/Controllers/UserController.php" {
+		t.Errorf("added = %q, want the two INJ-001 findings", added)
+	}
+	if last := v.rows[len(v.rows)-1]; last.added || last.finding.ID != "AUTH-001" {
+		t.Errorf("last row = %s (added %v), want AUTH-001 resolved", last.finding.ID, last.added)
+	}
+	if v.unchanged != 2 {
+		t.Errorf("unchanged = %d, want 2", v.unchanged)
+	}
+}
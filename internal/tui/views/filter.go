@@ -0,0 +1,173 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+// Grouping determines how the results table groups findings.
+type Grouping int
+
+const (
+	GroupNone Grouping = iota
+	GroupByFile
+	GroupByRule
+	GroupByCategory
+)
+
+func (g Grouping) String() string {
+	switch g {
+	case GroupByFile:
+		return "File"
+	case GroupByRule:
+		return "Rule"
+	case GroupByCategory:
+		return "Category"
+	default:
+		return "None"
+	}
+}
+
+// key returns the group f belongs to.
+func (g Grouping) key(f models.Finding) string {
+	switch g {
+	case GroupByFile:
+		return f.File
+	case GroupByRule:
+		return f.ID
+	case GroupByCategory:
+		return f.Category
+	}
+	return ""
+}
+
+// filterSeverities are the severities the 1–5 keys toggle, in key order.
+var filterSeverities = []models.Severity{
+	models.SeverityCritical, models.SeverityHigh, models.SeverityMedium, models.SeverityLow, models.SeverityInfo,
+}
+
+// findingFilter narrows the findings the results table lists. Zero
+// fields match every finding.
+type findingFilter struct {
+	query      string                   // case-insensitive substring of the title, file or code snippet
+	severities map[models.Severity]bool // severities shown; none selected shows all
+	scanner    string
+	category   string
+}
+
+func (f *findingFilter) match(fd models.Finding) bool {
+	if len(f.severities) > 0 && !f.severities[fd.Severity] {
+		return false
+	}
+	if f.scanner != "" && fd.Scanner != f.scanner {
+		return false
+	}
+	if f.category != "" && fd.Category != f.category {
+		return false
+	}
+	if f.query != "" {
+		q := strings.ToLower(f.query)
+		if !strings.Contains(strings.ToLower(fd.Title), q) &&
+			!strings.Contains(strings.ToLower(fd.File), q) &&
+			!strings.Contains(strings.ToLower(fd.CodeSnippet), q) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *findingFilter) toggleSeverity(sev models.Severity) {
+	if f.severities == nil {
+		f.severities = make(map[models.Severity]bool)
+	}
+	if f.severities[sev] {
+		delete(f.severities, sev)
+	} else {
+		f.severities[sev] = true
+	}
+}
+
+func (f *findingFilter) active() bool {
+	return f.query != "" || len(f.severities) > 0 || f.scanner != "" || f.category != ""
+}
+
+// chips describes the active filters, one label each.
+func (f *findingFilter) chips() []string {
+	var chips []string
+	for _, sev := range filterSeverities {
+		if f.severities[sev] {
+			chips = append(chips, sev.String())
+		}
+	}
+	if f.scanner != "" {
+		chips = append(chips, "scanner: "+strings.TrimSuffix(f.scanner, "-scanner"))
+	}
+	if f.category != "" {
+		chips = append(chips, "category: "+f.category)
+	}
+	if f.query != "" {
+		chips = append(chips, fmt.Sprintf("/%s", f.query))
+	}
+	return chips
+}
+
+// nextValue returns the value after cur in the sorted distinct values of
+// field across findings, cycling through "" (all) after the last.
+func nextValue(findings []models.Finding, field func(models.Finding) string, cur string) string {
+	var values []string
+	for _, f := range findings {
+		if v := field(f); v != "" && !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	i := slices.Index(values, cur)
+	if i+1 < len(values) {
+		return values[i+1]
+	}
+	return ""
+}
+
+// findingGroup is a run of findings sharing a group key.
+type findingGroup struct {
+	key      string
+	findings []models.Finding
+}
+
+// groupFindings splits sorted findings into groups, the group with the
+// most severe finding first and then by key. Findings keep their order
+// within a group.
+func groupFindings(findings []models.Finding, g Grouping) []findingGroup {
+	var groups []findingGroup
+	index := make(map[string]int)
+	for _, f := range findings {
+		k := g.key(f)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, findingGroup{key: k})
+		}
+		groups[i].findings = append(groups[i].findings, f)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := maxSeverity(groups[i].findings), maxSeverity(groups[j].findings)
+		if a != b {
+			return a > b
+		}
+		return groups[i].key < groups[j].key
+	})
+	return groups
+}
+
+func maxSeverity(findings []models.Finding) models.Severity {
+	sev := models.SeverityInfo
+	for _, f := range findings {
+		sev = max(sev, f.Severity)
+	}
+	return sev
+}
@@ -0,0 +1,90 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/eljakani/ward/internal/models"
+)
+
+var testFindings = []models.Finding{
+	{ID: "ENV-001", Title: "Debug mode enabled", Severity: models.SeverityHigh, Category: "Configuration", Scanner: "env-scanner", File: ".env"},
+	{ID: "INJ-001", Title: "Raw SQL query", Severity: models.SeverityCritical, Category: "Injection", Scanner: "rules-scanner", File: "app/Http/Controllers/UserController.php", CodeSnippet: "DB::raw($request->input('sort'))"},
+	{ID: "INJ-001", Title: "Raw SQL query", Severity: models.SeverityCritical, Category: "Injection", Scanner: "rules-scanner", File: "app/Models/Post.php"},
+	{ID: "CFG-004", Title: "Session cookie not secure", Severity: models.SeverityMedium, Category: "Configuration", Scanner: "config-scanner", File: "config/session.php"},
+}
+
+func TestFindingFilter_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter findingFilter
+		want   int
+	}{
+		{"none", findingFilter{}, 4},
+		{"title", findingFilter{query: "raw sql"}, 2},
+		{"file", findingFilter{query: "SESSION.php"}, 1},
+		{"snippet", findingFilter{query: "input('sort')"}, 1},
+		{"severities", findingFilter{severities: map[models.Severity]bool{models.SeverityHigh: true, models.SeverityMedium: true}}, 2},
+		{"scanner", findingFilter{scanner: "rules-scanner"}, 2},
+		{"category and query", findingFilter{category: "Configuration", query: "debug"}, 1},
+	}
+	for _, tt := range tests {
+		got := 0
+		for _, f := range testFindings {
+			if tt.filter.match(f) {
+				got++
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%s: matched %d findings, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindingFilter_Chips(t *testing.T) {
+	var f findingFilter
+	f.toggleSeverity(models.SeverityInfo)
+	f.toggleSeverity(models.SeverityCritical)
+	f.toggleSeverity(models.SeverityInfo)
+	f.scanner = "rules-scanner"
+	f.query = "raw"
+
+	want := []string{"Critical", "scanner: rules", "/raw"}
+	got := f.chips()
+	if len(got) != len(want) {
+		t.Fatalf("chips() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("chips()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNextValue(t *testing.T) {
+	scanner := func(f models.Finding) string { return f.Scanner }
+	var got []string
+	cur := ""
+	for range 4 {
+		cur = nextValue(testFindings, scanner, cur)
+		got = append(got, cur)
+	}
+	want := []string{"config-scanner", "env-scanner", "rules-scanner", ""}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("cycle %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGroupFindings(t *testing.T) {
+	groups := groupFindings(testFindings, GroupByCategory)
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+	if groups[0].key != "Injection" || len(groups[0].findings) != 2 {
+		t.Errorf("first group = %q with %d findings, want Injection with 2", groups[0].key, len(groups[0].findings))
+	}
+	if groups[1].key != "Configuration" || groups[1].findings[0].ID != "ENV-001" {
+		t.Errorf("second group = %q starting with %s, want Configuration starting with ENV-001", groups[1].key, groups[1].findings[0].ID)
+	}
+}
@@ -0,0 +1,282 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/store"
+	"github.com/eljakani/ward/internal/tui/components"
+	"github.com/eljakani/ward/internal/tui/theme"
+)
+
+// HistoryLoadedMsg carries the stored scans of the project, most recent
+// first.
+type HistoryLoadedMsg struct {
+	Records []store.ScanRecord
+	Err     error
+}
+
+// CompareReadyMsg carries an earlier scan to compare the current one
+// with.
+type CompareReadyMsg struct {
+	Record   store.ScanRecord
+	Findings []models.Finding
+	Err      error
+}
+
+// HistoryView lists the stored scans of the scanned project, with a
+// trend of their severity counts.
+type HistoryView struct {
+	theme  *theme.Theme
+	report *models.ScanReport // the scan just run
+
+	records []store.ScanRecord // most recent first
+	loaded  bool
+	err     error
+	notice  string
+
+	table table.Model
+
+	// Layout
+	width  int
+	height int
+
+	// Key bindings (local)
+	compareKey key.Binding
+}
+
+// NewHistoryView creates the history view for the project of report.
+// Call Load to read the stored scans.
+func NewHistoryView(t *theme.Theme, report *models.ScanReport) *HistoryView {
+	v := &HistoryView{
+		theme:  t,
+		report: report,
+		compareKey: key.NewBinding(
+			key.WithKeys("enter"),
+		),
+	}
+
+	columns := []table.Column{
+		{Title: "Scan", Width: 12},
+		{Title: "Date", Width: 16},
+		{Title: "Commit", Width: 8},
+		{Title: "Crit", Width: 5},
+		{Title: "High", Width: 5},
+		{Title: "Med", Width: 5},
+		{Title: "Low", Width: 5},
+		{Title: "Info", Width: 5},
+		{Title: "Total", Width: 6},
+		{Title: "", Width: 8},
+	}
+	tbl := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(10),
+	)
+	s := table.DefaultStyles()
+	s.Header = t.TableHeader
+	s.Selected = t.TableSelected
+	tbl.SetStyles(s)
+	v.table = tbl
+
+	return v
+}
+
+// Load reads the stored scans of the project.
+func (v *HistoryView) Load() tea.Cmd {
+	projectID := v.report.ProjectContext.ProjectID
+	return func() tea.Msg {
+		records, err := store.ListRecords(store.Filter{ProjectID: projectID})
+		return HistoryLoadedMsg{Records: records, Err: err}
+	}
+}
+
+// SetRecords shows the scans Load read.
+func (v *HistoryView) SetRecords(msg HistoryLoadedMsg) {
+	v.loaded = true
+	v.records, v.err = msg.Records, msg.Err
+
+	rows := make([]table.Row, len(v.records))
+	for i, r := range v.records {
+		var note string
+		if v.isCurrent(r) {
+			note = "current"
+		}
+		rows[i] = table.Row{
+			r.ID,
+			r.Timestamp.Local().Format("2006-01-02 15:04"),
+			shortCommit(r.Commit),
+			countCell(r.BySeverity, models.SeverityCritical),
+			countCell(r.BySeverity, models.SeverityHigh),
+			countCell(r.BySeverity, models.SeverityMedium),
+			countCell(r.BySeverity, models.SeverityLow),
+			countCell(r.BySeverity, models.SeverityInfo),
+			fmt.Sprintf("%d", r.FindingCount),
+			note,
+		}
+	}
+	v.table.SetRows(rows)
+
+	// Preselect the scan before the current one, the usual comparison.
+	if len(v.records) > 1 && v.isCurrent(v.records[0]) {
+		v.table.SetCursor(1)
+	}
+}
+
+// isCurrent reports whether r is the stored copy of the scan just run.
+func (v *HistoryView) isCurrent(r store.ScanRecord) bool {
+	return r.Timestamp.Equal(v.report.CompletedAt)
+}
+
+// SetStatus shows the outcome of an action, such as a failed comparison.
+func (v *HistoryView) SetStatus(notice string) {
+	v.notice = notice
+}
+
+// SetSize updates dimensions and propagates to sub-components.
+func (v *HistoryView) SetSize(w, h int) {
+	v.width = w
+	v.height = h
+
+	// Layout overhead:
+	//   title:            1 line
+	//   trend panel:      8 lines (6 rows + border)
+	//   hint:             1 line
+	//   table border:     2 lines
+	//   total overhead:  12 lines
+	bodyH := h - 12
+	if bodyH < 4 {
+		bodyH = 4
+	}
+	v.table.SetWidth(w - 2)
+	v.table.SetHeight(bodyH)
+}
+
+// HandleKey routes key events for table navigation and comparison.
+func (v *HistoryView) HandleKey(msg tea.KeyMsg) tea.Cmd {
+	v.notice = ""
+	if key.Matches(msg, v.compareKey) {
+		idx := v.table.Cursor()
+		if idx < 0 || idx >= len(v.records) {
+			return nil
+		}
+		r := v.records[idx]
+		if v.isCurrent(r) {
+			v.notice = "This is the current scan; select an earlier one"
+			return nil
+		}
+		return compareCmd(r)
+	}
+
+	var cmd tea.Cmd
+	v.table, cmd = v.table.Update(msg)
+	return cmd
+}
+
+// compareCmd reads the findings of r, in full when its report is stored.
+func compareCmd(r store.ScanRecord) tea.Cmd {
+	return func() tea.Msg {
+		if r.HasReport {
+			report, err := store.LoadReport(&r)
+			if err != nil {
+				return CompareReadyMsg{Record: r, Err: err}
+			}
+			return CompareReadyMsg{Record: r, Findings: report.Findings}
+		}
+		findings, err := store.Findings(r.ID)
+		return CompareReadyMsg{Record: r, Findings: findings, Err: err}
+	}
+}
+
+// View renders the history view.
+func (v *HistoryView) View(width, height int) string {
+	if width == 0 || height == 0 {
+		return ""
+	}
+
+	name := v.report.ProjectContext.ProjectName
+	if name == "" {
+		name = v.report.ProjectContext.ProjectID
+	}
+	title := v.theme.Title.Render(fmt.Sprintf("  Scan History  —  %s · %d scans", name, len(v.records)))
+	title = lipgloss.PlaceHorizontal(width, lipgloss.Center, title)
+
+	switch {
+	case !v.loaded:
+		return lipgloss.JoinVertical(lipgloss.Left, title, v.theme.Muted.Render("\n  Loading scan history..."))
+	case v.err != nil:
+		return lipgloss.JoinVertical(lipgloss.Left, title, v.theme.Muted.Render("\n  "+v.err.Error()))
+	}
+
+	hint := v.theme.Muted.Render("  enter: compare the current scan with the selected one  |  esc: back")
+	if v.notice != "" {
+		hint = v.theme.AccentStyle.Render("  " + v.notice)
+	}
+
+	border := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(v.theme.Colors.Primary)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		v.renderTrend(width),
+		hint,
+		border.Render(v.table.View()),
+	)
+}
+
+// renderTrend charts the severity counts of the listed scans, oldest on
+// the left, as far back as the width allows.
+func (v *HistoryView) renderTrend(width int) string {
+	n := min(len(v.records), max(width-40, 1))
+	values := func(count func(store.ScanRecord) int) []int {
+		out := make([]int, n)
+		for i := range n {
+			out[n-1-i] = count(v.records[i])
+		}
+		return out
+	}
+
+	var lines []string
+	for _, sev := range filterSeverities {
+		color := lipgloss.NewStyle().Foreground(v.theme.SeverityStyles[sev].GetBackground())
+		line := trendRow(values(func(r store.ScanRecord) int { return r.BySeverity[sev.String()] }), color)
+		lines = append(lines, fmt.Sprintf("%-9s %s", sev, line))
+	}
+	total := trendRow(values(func(r store.ScanRecord) int { return r.FindingCount }), v.theme.AccentStyle)
+	lines = append(lines, fmt.Sprintf("%-9s %s", "Total", total))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(v.theme.Colors.Border).
+		Width(width - 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+// trendRow renders a sparkline of values in style and the first and
+// last value.
+func trendRow(values []int, style lipgloss.Style) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s  %d → %d", style.Render(components.Sparkline(values)), values[0], values[len(values)-1])
+}
+
+func countCell(bySeverity map[string]int, sev models.Severity) string {
+	if c := bySeverity[sev.String()]; c > 0 {
+		return fmt.Sprintf("%d", c)
+	}
+	return "·"
+}
+
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
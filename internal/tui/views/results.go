@@ -7,8 +7,10 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/baseline"
 	"github.com/eljakani/ward/internal/models"
 	"github.com/eljakani/ward/internal/tui/components"
 	"github.com/eljakani/ward/internal/tui/theme"
@@ -43,12 +45,28 @@ type ResultsView struct {
 
 	// Table
 	table    table.Model
-	findings []models.Finding
+	findings []models.Finding // every finding, sorted
+	rows     []resultRow      // what the table lists
 
 	// Sort
 	sortColumn    SortColumn
 	sortAscending bool
 
+	// Filter and grouping
+	filter    findingFilter
+	shown     int // findings passing the filter
+	grouping  Grouping
+	collapsed map[string]bool // folded groups by key
+	search    textinput.Model
+	searching bool
+
+	// Triage
+	triage    TriageOptions
+	statuses  map[string]baseline.Status // by fingerprint
+	reason    textinput.Model            // accepted risk reason prompt
+	reasonFor *models.Finding            // finding the prompt is open for
+	notice    string                     // outcome of the last action
+
 	// Detail panel
 	detail *components.FindingDetail
 
@@ -58,34 +76,151 @@ type ResultsView struct {
 	focusPanel int // 0 = table, 1 = detail
 
 	// Key bindings (local)
-	tabKey  key.Binding
-	sortKey key.Binding
+	tabKey      key.Binding
+	sortKey     key.Binding
+	searchKey   key.Binding
+	scannerKey  key.Binding
+	categoryKey key.Binding
+	clearKey    key.Binding
+	groupKey    key.Binding
+	foldKey     key.Binding
+	foldAllKey  key.Binding
+	fpKey       key.Binding
+	riskKey     key.Binding
+	toFixKey    key.Binding
+	openKey     key.Binding
+	copyKey     key.Binding
+	prevLocKey  key.Binding
+	nextLocKey  key.Binding
+}
+
+// resultRow is a row of the results table: a group header, or a finding.
+type resultRow struct {
+	group   *findingGroup // set on group headers
+	finding *models.Finding
+}
+
+// id identifies the row across refreshes, to keep it selected.
+func (r resultRow) id() string {
+	if r.group != nil {
+		return "group:" + r.group.key
+	}
+	return r.finding.Fingerprint()
 }
 
 // NewResultsView creates a new results view from a scan report.
-func NewResultsView(t *theme.Theme, report *models.ScanReport) *ResultsView {
+func NewResultsView(t *theme.Theme, report *models.ScanReport, triage TriageOptions) *ResultsView {
+	search := textinput.New()
+	search.Prompt = "/"
+	search.Placeholder = "search title, file, code"
+	search.PromptStyle = t.AccentStyle
+
+	reason := textinput.New()
+	reason.Prompt = "Accepted risk because: "
+	reason.PromptStyle = t.AccentStyle
+
 	v := &ResultsView{
-		theme:    t,
-		report:   report,
-		findings: make([]models.Finding, len(report.Findings)),
-		detail:   components.NewFindingDetail(t),
+		theme:     t,
+		report:    report,
+		findings:  make([]models.Finding, len(report.Findings)),
+		detail:    components.NewFindingDetail(t),
+		collapsed: make(map[string]bool),
+		search:    search,
+		triage:    triage,
+		statuses:  make(map[string]baseline.Status),
+		reason:    reason,
 		tabKey: key.NewBinding(
 			key.WithKeys("tab"),
 		),
 		sortKey: key.NewBinding(
 			key.WithKeys("s"),
 		),
+		searchKey: key.NewBinding(
+			key.WithKeys("/"),
+		),
+		scannerKey: key.NewBinding(
+			key.WithKeys("S"),
+		),
+		categoryKey: key.NewBinding(
+			key.WithKeys("c"),
+		),
+		clearKey: key.NewBinding(
+			key.WithKeys("x"),
+		),
+		groupKey: key.NewBinding(
+			key.WithKeys("z"),
+		),
+		foldKey: key.NewBinding(
+			key.WithKeys("enter", " "),
+		),
+		foldAllKey: key.NewBinding(
+			key.WithKeys("Z"),
+		),
+		fpKey: key.NewBinding(
+			key.WithKeys("F"),
+		),
+		riskKey: key.NewBinding(
+			key.WithKeys("A"),
+		),
+		toFixKey: key.NewBinding(
+			key.WithKeys("T"),
+		),
+		openKey: key.NewBinding(
+			key.WithKeys("o"),
+		),
+		copyKey: key.NewBinding(
+			key.WithKeys("y"),
+		),
+		prevLocKey: key.NewBinding(
+			key.WithKeys("["),
+		),
+		nextLocKey: key.NewBinding(
+			key.WithKeys("]"),
+		),
 	}
 	copy(v.findings, report.Findings)
+	for _, f := range v.findings {
+		if f.Triage != "" {
+			v.statuses[f.Fingerprint()] = baseline.Status(f.Triage)
+		}
+	}
 	v.sortFindings()
 	v.buildTable()
+	v.refresh()
+
+	return v
+}
+
+// Capturing reports whether the view wants every key, global ones
+// included, because the search input or the reason prompt is open.
+func (v *ResultsView) Capturing() bool {
+	return v.searching || v.reasonFor != nil
+}
 
-	// Select first finding if available
-	if len(v.findings) > 0 {
-		v.detail.SetFinding(&v.findings[0])
+// SetStatus shows the outcome of an action that finished outside the view.
+func (v *ResultsView) SetStatus(msg StatusMsg) {
+	v.notice = msg.Text
+	if msg.Err != nil {
+		v.notice = msg.Err.Error()
 	}
+}
 
-	return v
+// FilterBar renders the active filters and how many findings pass them.
+func (v *ResultsView) FilterBar(width int) string {
+	var input string
+	f := v.filter
+	switch {
+	case v.reasonFor != nil:
+		input = v.reason.View()
+	case v.searching:
+		input = v.search.View()
+		f.query = "" // shown in the input
+	}
+	chips := f.chips()
+	if v.grouping != GroupNone {
+		chips = append(chips, "by "+v.grouping.String())
+	}
+	return components.RenderFilterBar(v.theme, input, chips, v.shown, len(v.findings), v.notice, width)
 }
 
 // SetSize updates dimensions and propagates to sub-components.
@@ -124,23 +259,16 @@ func (v *ResultsView) buildTable() {
 		{Title: "Line", Width: 6},
 	}
 
-	var rows []table.Row
-	for _, f := range v.findings {
-		rows = append(rows, table.Row{
-			f.Severity.String(),
-			truncate(f.Category, 12),
-			truncate(f.Title, 26),
-			truncate(f.File, 20),
-			fmt.Sprintf("%d", f.Line),
-		})
-	}
-
 	t := table.New(
 		table.WithColumns(columns),
-		table.WithRows(rows),
 		table.WithFocused(true),
 		table.WithHeight(20),
 	)
+	// Space folds groups rather than paging.
+	t.KeyMap.PageDown = key.NewBinding(
+		key.WithKeys("f", "pgdown"),
+		key.WithHelp("f/pgdn", "page down"),
+	)
 
 	s := table.DefaultStyles()
 	s.Header = v.theme.TableHeader
@@ -150,6 +278,154 @@ func (v *ResultsView) buildTable() {
 	v.table = t
 }
 
+// refresh rebuilds the table rows from the filter and grouping, keeping
+// the selected row when it is still listed.
+func (v *ResultsView) refresh() {
+	var selected string
+	if i := v.table.Cursor(); i >= 0 && i < len(v.rows) {
+		selected = v.rows[i].id()
+	}
+
+	var matched []models.Finding
+	for _, f := range v.findings {
+		if v.filter.match(f) {
+			matched = append(matched, f)
+		}
+	}
+	v.shown = len(matched)
+
+	v.rows = v.rows[:0]
+	if v.grouping == GroupNone {
+		for i := range matched {
+			v.rows = append(v.rows, resultRow{finding: &matched[i]})
+		}
+	} else {
+		groups := groupFindings(matched, v.grouping)
+		for gi := range groups {
+			g := &groups[gi]
+			v.rows = append(v.rows, resultRow{group: g})
+			if v.collapsed[g.key] {
+				continue
+			}
+			for i := range g.findings {
+				v.rows = append(v.rows, resultRow{finding: &g.findings[i]})
+			}
+		}
+	}
+
+	rows := make([]table.Row, len(v.rows))
+	cursor := 0
+	for i, r := range v.rows {
+		if r.id() == selected {
+			cursor = i
+		}
+		if r.group != nil {
+			fold := "▾"
+			if v.collapsed[r.group.key] {
+				fold = "▸"
+			}
+			label := r.group.key
+			if label == "" {
+				label = "(none)"
+			}
+			rows[i] = table.Row{fmt.Sprintf("%s %d", fold, len(r.group.findings)), "", truncate(label, 26), "", ""}
+			continue
+		}
+		f := r.finding
+		title := f.Title
+		if label, ok := triageLabels[v.statuses[f.Fingerprint()]]; ok {
+			title = "[" + label + "] " + title
+		}
+		rows[i] = table.Row{
+			f.Severity.String(),
+			truncate(f.Category, 12),
+			truncate(title, 26),
+			truncate(f.File, 20),
+			fmt.Sprintf("%d", f.Line),
+		}
+	}
+	v.table.SetRows(rows)
+	v.table.SetCursor(cursor)
+	v.syncDetail()
+}
+
+// syncDetail shows the selected finding in the detail panel.
+func (v *ResultsView) syncDetail() {
+	idx := v.table.Cursor()
+	if idx >= 0 && idx < len(v.rows) && v.rows[idx].finding != nil {
+		v.detail.SetFinding(v.rows[idx].finding)
+		return
+	}
+	v.detail.SetFinding(nil)
+}
+
+// toggleFold folds or unfolds the group of the selected row.
+func (v *ResultsView) toggleFold() {
+	idx := v.table.Cursor()
+	if v.grouping == GroupNone || idx < 0 || idx >= len(v.rows) {
+		return
+	}
+	r := v.rows[idx]
+	var k string
+	if r.group != nil {
+		k = r.group.key
+	} else {
+		k = v.grouping.key(*r.finding)
+		// Folding from a finding selects its header.
+		for i := idx; i >= 0; i-- {
+			if v.rows[i].group != nil {
+				v.table.SetCursor(i)
+				break
+			}
+		}
+	}
+	v.collapsed[k] = !v.collapsed[k]
+	v.refresh()
+}
+
+// toggleFoldAll folds every group, or unfolds them all when they
+// already are.
+func (v *ResultsView) toggleFoldAll() {
+	var groups []string
+	folded := true
+	for _, r := range v.rows {
+		if r.group != nil {
+			groups = append(groups, r.group.key)
+			folded = folded && v.collapsed[r.group.key]
+		}
+	}
+	for _, k := range groups {
+		v.collapsed[k] = !folded
+	}
+	v.refresh()
+}
+
+// handleSearchKey edits the search query, filtering as it changes.
+// Enter keeps the query, esc clears it.
+func (v *ResultsView) handleSearchKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		v.searching = false
+		v.search.Blur()
+		return nil
+	case "esc":
+		v.searching = false
+		v.search.Blur()
+		v.search.SetValue("")
+		v.filter.query = ""
+		v.refresh()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	v.search, cmd = v.search.Update(msg)
+	if q := v.search.Value(); q != v.filter.query {
+		v.filter.query = q
+		v.refresh()
+	}
+	return cmd
+}
+
 func (v *ResultsView) sortFindings() {
 	sort.SliceStable(v.findings, func(i, j int) bool {
 		switch v.sortColumn {
@@ -164,8 +440,23 @@ func (v *ResultsView) sortFindings() {
 	})
 }
 
-// HandleKey routes key events for table navigation, sorting, and detail scrolling.
+// HandleKey routes key events for table navigation, sorting, filtering,
+// grouping, and detail scrolling.
 func (v *ResultsView) HandleKey(msg tea.KeyMsg) tea.Cmd {
+	if v.reasonFor != nil {
+		return v.handleReasonKey(msg)
+	}
+	if v.searching {
+		return v.handleSearchKey(msg)
+	}
+	v.notice = ""
+
+	if n := msg.String(); len(n) == 1 && n[0] >= '1' && int(n[0]-'1') < len(filterSeverities) {
+		v.filter.toggleSeverity(filterSeverities[n[0]-'1'])
+		v.refresh()
+		return nil
+	}
+
 	switch {
 	case key.Matches(msg, v.tabKey):
 		v.focusPanel = (v.focusPanel + 1) % 2
@@ -177,18 +468,66 @@ func (v *ResultsView) HandleKey(msg tea.KeyMsg) tea.Cmd {
 	case key.Matches(msg, v.sortKey):
 		v.sortColumn = (v.sortColumn + 1) % 3
 		v.sortFindings()
-		v.buildTable()
+		v.refresh()
+		return nil
+	case key.Matches(msg, v.searchKey):
+		v.searching = true
+		v.search.SetValue(v.filter.query)
+		v.search.CursorEnd()
+		return v.search.Focus()
+	case key.Matches(msg, v.scannerKey):
+		v.filter.scanner = nextValue(v.findings, func(f models.Finding) string { return f.Scanner }, v.filter.scanner)
+		v.refresh()
+		return nil
+	case key.Matches(msg, v.categoryKey):
+		v.filter.category = nextValue(v.findings, func(f models.Finding) string { return f.Category }, v.filter.category)
+		v.refresh()
+		return nil
+	case key.Matches(msg, v.clearKey):
+		v.filter = findingFilter{}
+		v.search.SetValue("")
+		v.refresh()
+		return nil
+	case key.Matches(msg, v.groupKey):
+		v.grouping = (v.grouping + 1) % 4
+		v.refresh()
+		return nil
+	case key.Matches(msg, v.foldAllKey):
+		v.toggleFoldAll()
+		return nil
+	case key.Matches(msg, v.fpKey):
+		v.markSelected(baseline.StatusFalsePositive)
+		return nil
+	case key.Matches(msg, v.riskKey):
+		v.markSelected(baseline.StatusAcceptedRisk)
+		if v.reasonFor != nil {
+			return textinput.Blink
+		}
+		return nil
+	case key.Matches(msg, v.toFixKey):
+		v.markSelected(baseline.StatusToFix)
+		return nil
+	case key.Matches(msg, v.openKey):
+		return v.openSelected()
+	case key.Matches(msg, v.copyKey):
+		v.copySelected()
+		return nil
+	case key.Matches(msg, v.prevLocKey):
+		v.detail.StepLocation(-1)
+		return nil
+	case key.Matches(msg, v.nextLocKey):
+		v.detail.StepLocation(1)
 		return nil
 	}
 
 	if v.focusPanel == 0 {
+		if key.Matches(msg, v.foldKey) {
+			v.toggleFold()
+			return nil
+		}
 		var cmd tea.Cmd
 		v.table, cmd = v.table.Update(msg)
-		// Update detail panel when selection changes
-		idx := v.table.Cursor()
-		if idx >= 0 && idx < len(v.findings) {
-			v.detail.SetFinding(&v.findings[idx])
-		}
+		v.syncDetail()
 		return cmd
 	}
 
@@ -208,8 +547,8 @@ func (v *ResultsView) View(width, height int) string {
 	sep := components.RenderSeparator(v.theme, width)
 
 	// 2. Sort indicator
-	sortInfo := v.theme.Muted.Render(fmt.Sprintf("  Sorted by: %s  |  Panel: %s",
-		v.sortColumn.String(), panelName(v.focusPanel)))
+	sortInfo := v.theme.Muted.Render(fmt.Sprintf("  Sorted by: %s  |  Grouped by: %s  |  Panel: %s",
+		v.sortColumn.String(), v.grouping.String(), panelName(v.focusPanel)))
 
 	// 3. Body: table (left) + detail (right)
 	tableView := v.renderTable()
@@ -0,0 +1,545 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/eljakani/ward/internal/config"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/tui/components"
+	"github.com/eljakani/ward/internal/tui/theme"
+	"gopkg.in/yaml.v3"
+)
+
+// RulesOptions configures where the rules view saves overrides.
+type RulesOptions struct {
+	ProjectRoot string // local project whose config can hold overrides; empty for remote scans
+}
+
+// RulesLoadedMsg carries every loaded rule and the merged config whose
+// overrides apply to them.
+type RulesLoadedMsg struct {
+	Rules   []config.RuleDefinition // as written in their files
+	Config  *config.WardConfig
+	Origins config.Origins
+	Err     error
+}
+
+// ruleSeverities are the severities a rule can be overridden to, most
+// severe first.
+var ruleSeverities = []string{"critical", "high", "medium", "low", "info"}
+
+// RulesView lists the loaded rules with their effective state, and edits
+// their overrides in the user or project config.
+type RulesView struct {
+	theme *theme.Theme
+	opts  RulesOptions
+
+	rules   []config.RuleDefinition
+	cfg     *config.WardConfig
+	origins config.Origins
+	hits    map[string]int // findings per rule in the current scan
+	loaded  bool
+	err     error
+	notice  string
+
+	toProject bool // save overrides to the project config, else the user config
+
+	table  table.Model
+	detail viewport.Model
+
+	// Layout
+	width      int
+	height     int
+	focusPanel int // 0 = table, 1 = detail
+
+	// Key bindings (local)
+	tabKey    key.Binding
+	toggleKey key.Binding
+	raiseKey  key.Binding
+	lowerKey  key.Binding
+	targetKey key.Binding
+}
+
+// NewRulesView creates the rules view, counting report's findings per
+// rule. Call Load to read the rules.
+func NewRulesView(t *theme.Theme, report *models.ScanReport, opts RulesOptions) *RulesView {
+	v := &RulesView{
+		theme:     t,
+		opts:      opts,
+		hits:      make(map[string]int),
+		toProject: opts.ProjectRoot != "",
+		detail:    viewport.New(40, 20),
+		tabKey: key.NewBinding(
+			key.WithKeys("tab"),
+		),
+		toggleKey: key.NewBinding(
+			key.WithKeys("e", " "),
+		),
+		raiseKey: key.NewBinding(
+			key.WithKeys("+", "="),
+		),
+		lowerKey: key.NewBinding(
+			key.WithKeys("-"),
+		),
+		targetKey: key.NewBinding(
+			key.WithKeys("p"),
+		),
+	}
+	for _, f := range report.Findings {
+		v.hits[f.ID]++
+	}
+
+	columns := []table.Column{
+		{Title: "On", Width: 3},
+		{Title: "Rule", Width: 10},
+		{Title: "Sev", Width: 9},
+		{Title: "Category", Width: 14},
+		{Title: "Tags", Width: 16},
+		{Title: "Source", Width: 16},
+		{Title: "Hits", Width: 4},
+	}
+	tbl := table.New(
+		table.WithColumns(columns),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+	s := table.DefaultStyles()
+	s.Header = t.TableHeader
+	s.Selected = t.TableSelected
+	tbl.SetStyles(s)
+	v.table = tbl
+
+	return v
+}
+
+// Load reads the rules and the config of the project.
+func (v *RulesView) Load() tea.Cmd {
+	root := v.opts.ProjectRoot
+	return func() tea.Msg {
+		return loadRules(root)
+	}
+}
+
+func loadRules(root string) RulesLoadedMsg {
+	cfg, origins, err := config.LoadProject(root)
+	if err != nil {
+		return RulesLoadedMsg{Err: err}
+	}
+	rules, err := config.LoadRuleCatalog(cfg)
+	return RulesLoadedMsg{Rules: rules, Config: cfg, Origins: origins, Err: err}
+}
+
+// SetRules shows the rules Load read.
+func (v *RulesView) SetRules(msg RulesLoadedMsg) {
+	v.loaded = true
+	v.err = msg.Err
+	if msg.Err != nil {
+		return
+	}
+	v.rules, v.cfg, v.origins = msg.Rules, msg.Config, msg.Origins
+
+	rows := make([]table.Row, len(v.rules))
+	for i, r := range v.rules {
+		eff := v.cfg.Rules.Effective(r)
+		on := "·"
+		if eff.Enabled {
+			on = "✓"
+		}
+		sev := strings.ToLower(eff.Severity)
+		if v.cfg.Rules.Override[r.ID].Severity != "" {
+			sev += "*"
+		}
+		hits := "·"
+		if n := v.hits[r.ID]; n > 0 {
+			hits = fmt.Sprintf("%d", n)
+		}
+		rows[i] = table.Row{
+			on,
+			r.ID,
+			sev,
+			truncate(r.Category, 14),
+			truncate(strings.Join(r.Tags, ","), 16),
+			truncate(filepath.Base(r.Source), 16),
+			hits,
+		}
+	}
+	v.table.SetRows(rows)
+	v.syncDetail()
+}
+
+// selected returns the rule on the selected row, or nil.
+func (v *RulesView) selected() *config.RuleDefinition {
+	idx := v.table.Cursor()
+	if idx < 0 || idx >= len(v.rules) {
+		return nil
+	}
+	return &v.rules[idx]
+}
+
+// SetSize updates dimensions and propagates to sub-components.
+func (v *RulesView) SetSize(w, h int) {
+	v.width = w
+	v.height = h
+
+	// Layout overhead:
+	//   title:            1 line
+	//   hint:             1 line
+	//   separator:        1 line
+	//   total overhead:   3 lines
+	bodyH := h - 3
+	if bodyH < 6 {
+		bodyH = 6
+	}
+
+	tableW := int(float64(w) * 0.55)
+	detailW := w - tableW - 3
+
+	v.table.SetWidth(tableW)
+	v.table.SetHeight(bodyH - 2)
+	v.detail.Width = detailW - 2
+	v.detail.Height = bodyH - 2
+	v.syncDetail()
+}
+
+// HandleKey routes key events for navigation and override editing.
+func (v *RulesView) HandleKey(msg tea.KeyMsg) tea.Cmd {
+	v.notice = ""
+	switch {
+	case key.Matches(msg, v.tabKey):
+		v.focusPanel = (v.focusPanel + 1) % 2
+		v.table.Focus()
+		if v.focusPanel == 1 {
+			v.table.Blur()
+		}
+		return nil
+	case key.Matches(msg, v.toggleKey):
+		v.toggleSelected()
+		return nil
+	case key.Matches(msg, v.raiseKey):
+		v.shiftSeverity(-1)
+		return nil
+	case key.Matches(msg, v.lowerKey):
+		v.shiftSeverity(1)
+		return nil
+	case key.Matches(msg, v.targetKey):
+		if v.opts.ProjectRoot == "" {
+			v.notice = "Overrides are only saved to the user config for remote scans"
+			return nil
+		}
+		v.toProject = !v.toProject
+		return nil
+	}
+
+	if v.focusPanel == 0 {
+		var cmd tea.Cmd
+		v.table, cmd = v.table.Update(msg)
+		v.syncDetail()
+		return cmd
+	}
+
+	var cmd tea.Cmd
+	v.detail, cmd = v.detail.Update(msg)
+	return cmd
+}
+
+// target returns the config file overrides are saved to.
+func (v *RulesView) target() (string, error) {
+	if !v.toProject {
+		return config.FilePath("config.yaml")
+	}
+	if path := config.ProjectFile(v.opts.ProjectRoot); path != "" {
+		return path, nil
+	}
+	return filepath.Join(v.opts.ProjectRoot, config.ProjectConfigNames[0]), nil
+}
+
+// toggleSelected disables an enabled rule, or enables a disabled one,
+// removing it from rules.disable where the config lists it there.
+func (v *RulesView) toggleSelected() {
+	r := v.selected()
+	if r == nil || v.cfg == nil {
+		return
+	}
+	path, err := v.target()
+	if err != nil {
+		v.notice = err.Error()
+		return
+	}
+	enabledKey := "rules.override." + r.ID + ".enabled"
+
+	enable := !v.cfg.Rules.Effective(*r).Enabled
+	if enable {
+		err = v.enable(*r, path, enabledKey)
+	} else {
+		err = config.SetInFile(path, enabledKey, "false")
+	}
+	if err != nil {
+		v.notice = err.Error()
+		return
+	}
+
+	state := "Disabled"
+	if enable {
+		state = "Enabled"
+	}
+	v.reload(r.ID, path, enabledKey, fmt.Sprintf("%s %s in %s", state, r.ID, v.displayPath(path)), func(eff config.RuleDefinition) bool {
+		return eff.Enabled == enable
+	})
+}
+
+// enable turns r on in path, first taking it out of rules.disable in the
+// file that lists it.
+func (v *RulesView) enable(r config.RuleDefinition, path, enabledKey string) error {
+	if from := v.origins.Lookup("rules.disable"); slices.Contains(v.cfg.Rules.Disable, r.ID) && from != config.SourceDefault {
+		rest := slices.DeleteFunc(slices.Clone(v.cfg.Rules.Disable), func(id string) bool { return id == r.ID })
+		if err := config.SetInFile(from, "rules.disable", strings.Join(rest, ",")); err != nil {
+			return err
+		}
+	}
+
+	// A rule on in its own file needs no override, unless another config
+	// turns it off.
+	override := v.cfg.Rules.Override[r.ID].Enabled
+	if r.Enabled && (override == nil || v.origins.Lookup(enabledKey) == path) {
+		return config.UnsetInFile(path, enabledKey)
+	}
+	return config.SetInFile(path, enabledKey, "true")
+}
+
+// shiftSeverity overrides the selected rule's severity one step up (-1)
+// or down (+1). Reaching the severity of the rule's file drops the
+// override.
+func (v *RulesView) shiftSeverity(delta int) {
+	r := v.selected()
+	if r == nil || v.cfg == nil {
+		return
+	}
+	path, err := v.target()
+	if err != nil {
+		v.notice = err.Error()
+		return
+	}
+
+	current := slices.Index(ruleSeverities, strings.ToLower(v.cfg.Rules.Effective(*r).Severity))
+	if current < 0 {
+		current = len(ruleSeverities) - 1
+	}
+	next := current + delta
+	if next < 0 || next >= len(ruleSeverities) {
+		return
+	}
+	sev := ruleSeverities[next]
+
+	severityKey := "rules.override." + r.ID + ".severity"
+	if strings.EqualFold(sev, r.Severity) {
+		err = config.UnsetInFile(path, severityKey)
+	} else {
+		err = config.SetInFile(path, severityKey, sev)
+	}
+	if err != nil {
+		v.notice = err.Error()
+		return
+	}
+
+	v.reload(r.ID, path, severityKey, fmt.Sprintf("Set %s to %s in %s", r.ID, sev, v.displayPath(path)), func(eff config.RuleDefinition) bool {
+		return strings.EqualFold(eff.Severity, sev)
+	})
+}
+
+// reload reads the config back after an edit of key for rule id in path,
+// and reports the edit or the file that still decides otherwise.
+func (v *RulesView) reload(id, path, key, done string, took func(config.RuleDefinition) bool) {
+	msg := loadRules(v.opts.ProjectRoot)
+	if msg.Err != nil {
+		v.notice = msg.Err.Error()
+		return
+	}
+	v.SetRules(msg)
+
+	for _, r := range v.rules {
+		if r.ID != id {
+			continue
+		}
+		if !took(v.cfg.Rules.Effective(r)) {
+			from := v.origins.Lookup(key)
+			if slices.Contains(v.cfg.Rules.Disable, id) {
+				from = v.origins.Lookup("rules.disable")
+			}
+			v.notice = fmt.Sprintf("Saved to %s, but %s overrides it", v.displayPath(path), v.displayPath(from))
+			return
+		}
+	}
+	v.notice = done + " — applies from the next scan"
+}
+
+// displayPath shortens path for the hint line.
+func (v *RulesView) displayPath(path string) string {
+	if v.opts.ProjectRoot != "" {
+		if rel, err := filepath.Rel(v.opts.ProjectRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.Join("~", rel)
+		}
+	}
+	return path
+}
+
+func (v *RulesView) syncDetail() {
+	r := v.selected()
+	if r == nil || v.cfg == nil {
+		v.detail.SetContent(v.theme.Muted.Render("  Select a rule to view details."))
+		return
+	}
+	v.detail.SetContent(v.renderRule(*r))
+	v.detail.GotoTop()
+}
+
+// renderRule describes r as the config runs it and where that comes from.
+func (v *RulesView) renderRule(r config.RuleDefinition) string {
+	eff := v.cfg.Rules.Effective(r)
+	width := max(v.detail.Width-4, 20)
+	wrap := lipgloss.NewStyle().Width(width).PaddingLeft(2)
+	ovKey := "rules.override." + r.ID
+
+	state := v.theme.SuccessStyle.Render("enabled")
+	if !eff.Enabled {
+		state = v.theme.ErrorStyle.Render("disabled")
+	}
+	switch {
+	case slices.Contains(v.cfg.Rules.Disable, r.ID):
+		state += v.theme.Muted.Render(" by rules.disable in " + v.displayPath(v.origins.Lookup("rules.disable")))
+	case v.cfg.Rules.Override[r.ID].Enabled != nil:
+		state += v.theme.Muted.Render(" by override in " + v.displayPath(v.origins.Lookup(ovKey+".enabled")))
+	default:
+		state += v.theme.Muted.Render(" in its rule file")
+	}
+
+	severity := strings.ToLower(eff.Severity)
+	if v.cfg.Rules.Override[r.ID].Severity != "" {
+		severity += v.theme.Muted.Render(fmt.Sprintf(" (overridden from %s in %s)",
+			strings.ToLower(r.Severity), v.displayPath(v.origins.Lookup(ovKey+".severity"))))
+	}
+
+	sections := []string{
+		lipgloss.JoinHorizontal(lipgloss.Top,
+			components.RenderSeverityBadge(models.ParseSeverity(eff.Severity), v.theme),
+			"  ",
+			v.theme.Title.Render(r.Title),
+		),
+		"",
+		v.theme.Muted.Render(fmt.Sprintf("  %s  |  Category: %s", r.ID, r.Category)),
+		"",
+		"  State:    " + state,
+		"  Severity: " + severity,
+		fmt.Sprintf("  Findings: %d in this scan", v.hits[r.ID]),
+		"  Source:   " + v.displayPath(r.Source),
+	}
+	if len(r.Tags) > 0 {
+		sections = append(sections, "  Tags:     "+strings.Join(r.Tags, ", "))
+	}
+	sections = append(sections, "")
+
+	if r.Description != "" {
+		sections = append(sections, v.theme.Subtitle.Render("  Description"), wrap.Render(r.Description), "")
+	}
+	if r.When != nil {
+		sections = append(sections, v.theme.Subtitle.Render("  Conditions"), v.yamlBlock(r.When, width), "")
+	}
+	if len(r.Patterns) > 0 {
+		sections = append(sections, v.theme.Subtitle.Render("  Patterns"), v.yamlBlock(r.Patterns, width), "")
+	}
+	if r.Match != nil {
+		sections = append(sections, v.theme.Subtitle.Render("  Match"), v.yamlBlock(r.Match, width), "")
+	}
+	if r.Remediation != "" {
+		sections = append(sections, v.theme.Subtitle.Render("  Remediation"), wrap.Render(strings.TrimSpace(r.Remediation)), "")
+	}
+	if len(r.References) > 0 {
+		sections = append(sections, v.theme.Subtitle.Render("  References"))
+		for _, ref := range r.References {
+			sections = append(sections, "  "+v.theme.AccentStyle.Render(ref))
+		}
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// yamlBlock renders part of a rule as it would be written in its file.
+func (v *RulesView) yamlBlock(x any, width int) string {
+	var buf strings.Builder
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(x); err != nil {
+		return "  " + err.Error()
+	}
+	return v.theme.Code.Width(width).MarginLeft(2).Render(strings.TrimSuffix(buf.String(), "\n"))
+}
+
+// View renders the rules view.
+func (v *RulesView) View(width, height int) string {
+	if width == 0 || height == 0 {
+		return ""
+	}
+
+	var enabled int
+	if v.cfg != nil {
+		for _, r := range v.rules {
+			if v.cfg.Rules.Effective(r).Enabled {
+				enabled++
+			}
+		}
+	}
+	title := v.theme.Title.Render(fmt.Sprintf("  Rules  —  %d loaded · %d enabled", len(v.rules), enabled))
+	title = lipgloss.PlaceHorizontal(width, lipgloss.Center, title)
+
+	switch {
+	case !v.loaded:
+		return lipgloss.JoinVertical(lipgloss.Left, title, v.theme.Muted.Render("\n  Loading rules..."))
+	case v.err != nil:
+		return lipgloss.JoinVertical(lipgloss.Left, title, v.theme.Muted.Render("\n  "+v.err.Error()))
+	}
+
+	var hint string
+	switch {
+	case v.notice != "":
+		hint = v.theme.AccentStyle.Render("  " + v.notice)
+	default:
+		path, _ := v.target()
+		hint = v.theme.Muted.Render(fmt.Sprintf("  e/space: enable or disable  |  +/-: severity  |  p: save to %s  |  esc: back",
+			v.displayPath(path)))
+	}
+
+	tableBorder := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(v.theme.Colors.Border)
+	detailBorder := tableBorder
+	if v.focusPanel == 0 {
+		tableBorder = tableBorder.BorderForeground(v.theme.Colors.Primary)
+	} else {
+		detailBorder = detailBorder.BorderForeground(v.theme.Colors.Primary)
+	}
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		tableBorder.Render(v.table.View()),
+		" ",
+		detailBorder.Render(v.detail.View()),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		hint,
+		components.RenderSeparator(v.theme, width),
+		body,
+	)
+}
@@ -0,0 +1,86 @@
+package views
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/tui/theme"
+)
+
+func TestRulesViewEditsOverrides(t *testing.T) {
+	home, root := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	write := func(path, content string) {
+		t.Helper()
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(home, ".ward", "rules", "test.yaml"), `rules:
+  - id: TEST-001
+    title: "Test rule"
+    severity: high
+    category: test
+    enabled: true
+`)
+	write(filepath.Join(home, ".ward", "config.yaml"), "rules:\n  disable: [TEST-001]\n")
+
+	report := &models.ScanReport{Findings: []models.Finding{{ID: "TEST-001"}, {ID: "TEST-001"}}}
+	v := NewRulesView(theme.DefaultTheme(), report, RulesOptions{ProjectRoot: root})
+	v.SetSize(160, 40)
+	v.SetRules(v.Load()().(RulesLoadedMsg))
+	if len(v.rules) != 1 || v.hits["TEST-001"] != 2 {
+		t.Fatalf("got %d rules and %d hits, want 1 and 2", len(v.rules), v.hits["TEST-001"])
+	}
+	if v.cfg.Rules.Effective(v.rules[0]).Enabled {
+		t.Fatal("TEST-001 should start disabled by rules.disable")
+	}
+
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+	project := filepath.Join(root, ".ward.yaml")
+
+	// Enabling takes the rule out of the user's rules.disable and needs no
+	// override, since its file enables it.
+	v.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if !v.cfg.Rules.Effective(v.rules[0]).Enabled {
+		t.Errorf("TEST-001 still disabled: %s", v.notice)
+	}
+	if got := read(filepath.Join(home, ".ward", "config.yaml")); strings.Contains(got, "TEST-001") {
+		t.Errorf("user config still disables TEST-001:\n%s", got)
+	}
+
+	v.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if got := read(project); !strings.Contains(got, "enabled: false") {
+		t.Errorf("project config doesn't disable TEST-001:\n%s", got)
+	}
+
+	// Raising the severity overrides it; lowering it back drops the
+	// override.
+	v.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if got := v.cfg.Rules.Effective(v.rules[0]).Severity; got != "critical" {
+		t.Errorf("severity = %q, want critical", got)
+	}
+	v.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	if got := read(project); strings.Contains(got, "severity") {
+		t.Errorf("severity override left behind:\n%s", got)
+	}
+
+	// Saving to the user config doesn't win over the project's override.
+	v.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	v.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if v.cfg.Rules.Effective(v.rules[0]).Enabled || !strings.Contains(v.notice, ".ward.yaml overrides it") {
+		t.Errorf("notice = %q, want the project config named", v.notice)
+	}
+}
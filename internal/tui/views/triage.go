@@ -0,0 +1,247 @@
+package views
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/eljakani/ward/internal/baseline"
+	"github.com/eljakani/ward/internal/models"
+	"github.com/eljakani/ward/internal/reporter"
+	"github.com/muesli/termenv"
+)
+
+// TriageOptions configures the triage actions of the results view.
+type TriageOptions struct {
+	Baseline string // baseline file triage is saved to; empty disables marking
+	Editor   string // editor preset or template, see editorCommand
+}
+
+// StatusMsg reports the outcome of an action that finished outside the
+// view, such as an editor closing.
+type StatusMsg struct {
+	Text string
+	Err  error
+}
+
+// editorPresets are the URL templates of the editors triage.editor can
+// name.
+var editorPresets = map[string]string{
+	"vscode":   "vscode://file/{file}:{line}",
+	"phpstorm": "phpstorm://open?file={file}&line={line}",
+}
+
+// triageLabels mark triaged findings in the table.
+var triageLabels = map[baseline.Status]string{
+	baseline.StatusFalsePositive: "FP",
+	baseline.StatusAcceptedRisk:  "Risk",
+	baseline.StatusToFix:         "Fix",
+}
+
+func triageName(s baseline.Status) string {
+	switch s {
+	case baseline.StatusFalsePositive:
+		return "false positive"
+	case baseline.StatusAcceptedRisk:
+		return "accepted risk"
+	case baseline.StatusToFix:
+		return "to fix"
+	}
+	return string(s)
+}
+
+// selected returns the finding on the selected row, or nil on a group
+// header or an empty table.
+func (v *ResultsView) selected() *models.Finding {
+	idx := v.table.Cursor()
+	if idx < 0 || idx >= len(v.rows) {
+		return nil
+	}
+	return v.rows[idx].finding
+}
+
+// markSelected sets the triage status of the selected finding, or clears
+// it when the finding already has that status. Accepted risks ask for a
+// reason first.
+func (v *ResultsView) markSelected(status baseline.Status) {
+	f := v.selected()
+	if f == nil {
+		return
+	}
+	if v.triage.Baseline == "" {
+		v.notice = "Triage is only saved for local projects"
+		return
+	}
+
+	fp := f.Fingerprint()
+	if v.statuses[fp] == status {
+		if err := baseline.Untriage(v.triage.Baseline, *f); err != nil {
+			v.notice = err.Error()
+			return
+		}
+		delete(v.statuses, fp)
+		v.notice = fmt.Sprintf("Cleared %s from %s", f.ID, filepath.Base(v.triage.Baseline))
+		v.refresh()
+		return
+	}
+
+	if status == baseline.StatusAcceptedRisk {
+		v.reasonFor = f
+		v.reason.SetValue("")
+		v.reason.Focus()
+		return
+	}
+	v.saveTriage(f, status, "")
+}
+
+func (v *ResultsView) saveTriage(f *models.Finding, status baseline.Status, reason string) {
+	if err := baseline.Triage(v.triage.Baseline, *f, status, reason); err != nil {
+		v.notice = err.Error()
+		return
+	}
+	v.statuses[f.Fingerprint()] = status
+	v.notice = fmt.Sprintf("Marked %s as %s in %s", f.ID, triageName(status), filepath.Base(v.triage.Baseline))
+	v.refresh()
+}
+
+// handleReasonKey edits the reason of an accepted risk. Enter saves it,
+// esc cancels.
+func (v *ResultsView) handleReasonKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		reason := strings.TrimSpace(v.reason.Value())
+		if reason == "" {
+			v.notice = "An accepted risk needs a reason"
+			return nil
+		}
+		f := v.reasonFor
+		v.reasonFor = nil
+		v.reason.Blur()
+		v.saveTriage(f, baseline.StatusAcceptedRisk, reason)
+		return nil
+	case "esc":
+		v.reasonFor = nil
+		v.reason.Blur()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	v.reason, cmd = v.reason.Update(msg)
+	v.notice = ""
+	return cmd
+}
+
+// openSelected opens the location the detail panel shows, the selected
+// finding's line or one related to it, in a browser-registered editor for
+// URL templates or by running the editor command with the TUI suspended.
+func (v *ResultsView) openSelected() tea.Cmd {
+	if v.selected() == nil {
+		return nil
+	}
+	file, line := v.detail.Location()
+	if file == "" {
+		return nil
+	}
+	path := file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(v.report.ProjectContext.RootPath, path)
+	}
+
+	link, args, err := editorCommand(v.triage.Editor, os.Getenv("EDITOR"), path, line)
+	if err != nil {
+		v.notice = err.Error()
+		return nil
+	}
+	if link != "" {
+		if err := openURL(link); err != nil {
+			v.notice = fmt.Sprintf("Opening %s: %v", link, err)
+			return nil
+		}
+		v.notice = "Opened " + file
+		return nil
+	}
+
+	name := file
+	return tea.ExecProcess(exec.Command(args[0], args[1:]...), func(err error) tea.Msg {
+		if err != nil {
+			return StatusMsg{Err: fmt.Errorf("running %s: %w", args[0], err)}
+		}
+		return StatusMsg{Text: "Edited " + name}
+	})
+}
+
+// editorCommand expands the editor template for file at line. A preset
+// name or a template with "://" gives a URL to open, with file escaped
+// for the path or query it lands in; anything else is a command, split on
+// spaces. An empty template runs $EDITOR +{line} {file}.
+func editorCommand(tmpl, editor, file string, line int) (link string, args []string, err error) {
+	if line < 1 {
+		line = 1
+	}
+	expand := strings.NewReplacer("{file}", file, "{line}", strconv.Itoa(line)).Replace
+
+	if preset, ok := editorPresets[tmpl]; ok {
+		tmpl = preset
+	}
+	if strings.Contains(tmpl, "://") {
+		path, query, _ := strings.Cut(tmpl, "?")
+		link = strings.NewReplacer("{file}", (&url.URL{Path: file}).EscapedPath(), "{line}", strconv.Itoa(line)).Replace(path)
+		if query != "" {
+			link += "?" + strings.NewReplacer("{file}", queryEscapePath(file), "{line}", strconv.Itoa(line)).Replace(query)
+		}
+		return link, nil, nil
+	}
+	if tmpl == "" {
+		if editor == "" {
+			return "", nil, fmt.Errorf("set $EDITOR or triage.editor to open files")
+		}
+		tmpl = editor + " +{line} {file}"
+	}
+
+	for _, field := range strings.Fields(tmpl) {
+		args = append(args, expand(field))
+	}
+	return "", args, nil
+}
+
+// queryEscapePath escapes file as a query value, leaving its slashes,
+// which a query may contain, readable.
+func queryEscapePath(file string) string {
+	return strings.NewReplacer("+", "%20", "%2F", "/").Replace(url.QueryEscape(file))
+}
+
+// openURL hands link to the system's URL handler.
+func openURL(link string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", link)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", link)
+	default:
+		cmd = exec.Command("xdg-open", link)
+	}
+	return cmd.Start()
+}
+
+// copySelected copies the selected finding as Markdown, through the
+// system clipboard or, where there is none (e.g. over SSH), the
+// terminal's OSC 52 sequence.
+func (v *ResultsView) copySelected() {
+	f := v.selected()
+	if f == nil {
+		return
+	}
+	md := reporter.FindingMarkdown(*f)
+	if err := clipboard.WriteAll(md); err != nil {
+		termenv.NewOutput(os.Stdout).Copy(md)
+	}
+	v.notice = fmt.Sprintf("Copied %s as Markdown", f.ID)
+}
@@ -0,0 +1,46 @@
+package views
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		name, tmpl, editor string
+		wantURL            string
+		wantArgs           []string
+	}{
+		{"editor", "", "vim", "", []string{"vim", "+12", "/app/routes/web.php"}},
+		{"vscode", "vscode", "vim", "vscode://file//app/routes/web.php:12", nil},
+		{"phpstorm", "phpstorm", "", "phpstorm://open?file=/app/routes/web.php&line=12", nil},
+		{"url", "idea://open?file={file}&line={line}", "", "idea://open?file=/app/routes/web.php&line=12", nil},
+		{"command", "code -g {file}:{line}", "", "", []string{"code", "-g", "/app/routes/web.php:12"}},
+	}
+	for _, tt := range tests {
+		url, args, err := editorCommand(tt.tmpl, tt.editor, "/app/routes/web.php", 12)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if url != tt.wantURL || !slices.Equal(args, tt.wantArgs) {
+			t.Errorf("%s: got %q %q, want %q %q", tt.name, url, args, tt.wantURL, tt.wantArgs)
+		}
+	}
+
+	// Paths with spaces, # or & stay one path in the URL.
+	escaped := []struct{ tmpl, want string }{
+		{"vscode", "vscode://file//app/my%20app/%23tmp/a&b.php:3"},
+		{"phpstorm", "phpstorm://open?file=/app/my%20app/%23tmp/a%26b.php&line=3"},
+	}
+	for _, tt := range escaped {
+		url, _, err := editorCommand(tt.tmpl, "", "/app/my app/#tmp/a&b.php", 3)
+		if err != nil || url != tt.want {
+			t.Errorf("%s: got %q, %v, want %q", tt.tmpl, url, err, tt.want)
+		}
+	}
+
+	if _, _, err := editorCommand("", "", "/app/routes/web.php", 0); err == nil {
+		t.Error("expected an error without an editor")
+	}
+}